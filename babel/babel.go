@@ -0,0 +1,125 @@
+// Package babel converts between the AST produced by Babel and the ESTree
+// Nodes of pifke.org/estree.
+//
+// Babel's AST is a dialect of ESTree, which differs in a handful of node
+// types: literals are split into StringLiteral, NumericLiteral, and so on;
+// object and class members are ObjectProperty, ObjectMethod, ClassMethod, and
+// ClassProperty; optional chains are built from OptionalMemberExpression and
+// OptionalCallExpression rather than wrapped in a ChainExpression; import()
+// is a CallExpression with an Import callee; export * as ns from "mod" is an
+// ExportNamedDeclaration with an ExportNamespaceSpecifier; directives are
+// listed separately from the body of a Program or BlockStatement; and a
+// Program is wrapped in a File.  Unlike the TypeScript and Flow extensions,
+// these differences affect Nodes the estree package already knows about, so
+// conversion happens on the JSON, before decoding or after encoding.
+//
+// Babel's estree plugin produces ESTree directly; its output can be decoded
+// with encoding/json, and does not need this package.
+package babel
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"pifke.org/estree"
+)
+
+// Unmarshal decodes a Babel File or Program.
+func Unmarshal(b []byte) (estree.Program, error) {
+	var p estree.Program
+	m, err := ToESTree(b)
+	if err == nil {
+		err = json.Unmarshal(m, &p)
+	}
+	return p, err
+}
+
+// UnmarshalExpression decodes a Babel Expression, such as is returned by
+// Babel's parseExpression.
+func UnmarshalExpression(b []byte) (estree.Expression, error) {
+	m, err := ToESTree(b)
+	if err != nil {
+		return nil, err
+	}
+	return estree.UnmarshalExpression(m)
+}
+
+// Marshal encodes p as a Babel File.  Program.Comments are moved to the File.
+func Marshal(p estree.Program) ([]byte, error) {
+	x, err := decode(json.Marshal(p))
+	if err != nil {
+		return nil, err
+	}
+	program := toBabel(x).(map[string]interface{})
+	file := map[string]interface{}{
+		"type":     "File",
+		"program":  program,
+		"comments": []interface{}{},
+	}
+	copyLocation(file, program)
+	if comments, ok := program["comments"]; ok {
+		file["comments"] = comments
+		delete(program, "comments")
+	}
+	return json.Marshal(file)
+}
+
+// MarshalNode encodes n as Babel JSON.  Unlike Marshal, a Program is not
+// wrapped in a File.
+func MarshalNode(n estree.Node) ([]byte, error) {
+	x, err := decode(json.Marshal(n))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(toBabel(x))
+}
+
+// ToESTree converts Babel JSON to ESTree JSON, which can be decoded into the
+// Nodes of pifke.org/estree.  A File is replaced by its Program, with the
+// File's comments.
+func ToESTree(b []byte) ([]byte, error) {
+	x, err := decode(b, nil)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(toESTree(x))
+}
+
+// FromESTree converts ESTree JSON to Babel JSON.  It is the inverse of
+// ToESTree, except that a Program is not wrapped in a File.
+func FromESTree(b []byte) ([]byte, error) {
+	x, err := decode(b, nil)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(toBabel(x))
+}
+
+// decode returns the generic JSON value of b, with numbers left as
+// json.Number so they are re-encoded exactly.  It accepts the results of
+// json.Marshal.
+func decode(b []byte, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	var x interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	err = d.Decode(&x)
+	return x, err
+}
+
+// copyLocation copies the location properties of the node src to dst.
+func copyLocation(dst, src map[string]interface{}) {
+	for _, k := range []string{"loc", "start", "end", "range"} {
+		if v, ok := src[k]; ok {
+			dst[k] = v
+		}
+	}
+}
+
+// functionProperties are the properties of a FunctionExpression which Babel
+// puts directly on an ObjectMethod or ClassMethod.
+var functionProperties = []string{
+	"params", "body", "generator", "async", "returnType", "typeParameters",
+}
@@ -0,0 +1,569 @@
+package babel
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"sort"
+	"testing"
+
+	"pifke.org/estree"
+)
+
+// testFile is the Babel encoding of:
+//
+//	"use strict";
+//	// hi
+//	var o = {a: 1, b() {}, get c() { return 'x'; }, [d]: null};
+//	class A { #x = 1n; static m() {} #p() {} }
+//	a?.b.c();
+//	x.y?.z;
+//	/a/g;
+//	function f() { 'use asm'; }
+const testFile = `{
+	"type": "File",
+	"program": {
+		"type": "Program",
+		"sourceType": "module",
+		"directives": [{
+			"type": "Directive",
+			"value": {
+				"type": "DirectiveLiteral",
+				"value": "use strict",
+				"extra": {"raw": "\"use strict\"", "rawValue": "use strict", "expressionValue": "use strict"}
+			}
+		}],
+		"body": [{
+			"type": "VariableDeclaration",
+			"kind": "var",
+			"declarations": [{
+				"type": "VariableDeclarator",
+				"id": {"type": "Identifier", "name": "o"},
+				"init": {
+					"type": "ObjectExpression",
+					"properties": [{
+						"type": "ObjectProperty",
+						"key": {"type": "Identifier", "name": "a"},
+						"value": {"type": "NumericLiteral", "value": 1, "extra": {"raw": "1", "rawValue": 1}},
+						"computed": false,
+						"shorthand": false
+					}, {
+						"type": "ObjectMethod",
+						"kind": "method",
+						"key": {"type": "Identifier", "name": "b"},
+						"computed": false,
+						"id": null,
+						"params": [],
+						"body": {"type": "BlockStatement", "body": [], "directives": []},
+						"generator": false,
+						"async": false
+					}, {
+						"type": "ObjectMethod",
+						"kind": "get",
+						"key": {"type": "Identifier", "name": "c"},
+						"computed": false,
+						"id": null,
+						"params": [],
+						"body": {
+							"type": "BlockStatement",
+							"body": [{
+								"type": "ReturnStatement",
+								"argument": {"type": "StringLiteral", "value": "x", "extra": {"raw": "'x'", "rawValue": "x"}}
+							}],
+							"directives": []
+						},
+						"generator": false,
+						"async": false
+					}, {
+						"type": "ObjectProperty",
+						"key": {"type": "Identifier", "name": "d"},
+						"value": {"type": "NullLiteral"},
+						"computed": true,
+						"shorthand": false
+					}]
+				}
+			}]
+		}, {
+			"type": "ClassDeclaration",
+			"id": {"type": "Identifier", "name": "A"},
+			"superClass": null,
+			"body": {
+				"type": "ClassBody",
+				"body": [{
+					"type": "ClassPrivateProperty",
+					"key": {"type": "PrivateName", "id": {"type": "Identifier", "name": "x"}},
+					"value": {"type": "BigIntLiteral", "value": "1", "extra": {"raw": "1n", "rawValue": "1"}},
+					"static": false
+				}, {
+					"type": "ClassMethod",
+					"kind": "method",
+					"key": {"type": "Identifier", "name": "m"},
+					"computed": false,
+					"static": true,
+					"id": null,
+					"params": [],
+					"body": {"type": "BlockStatement", "body": [], "directives": []},
+					"generator": false,
+					"async": false
+				}, {
+					"type": "ClassPrivateMethod",
+					"kind": "method",
+					"key": {"type": "PrivateName", "id": {"type": "Identifier", "name": "p"}},
+					"computed": false,
+					"static": false,
+					"id": null,
+					"params": [],
+					"body": {"type": "BlockStatement", "body": [], "directives": []},
+					"generator": false,
+					"async": false
+				}]
+			}
+		}, {
+			"type": "ExpressionStatement",
+			"expression": {
+				"type": "OptionalCallExpression",
+				"callee": {
+					"type": "OptionalMemberExpression",
+					"object": {
+						"type": "OptionalMemberExpression",
+						"object": {"type": "Identifier", "name": "a"},
+						"property": {"type": "Identifier", "name": "b"},
+						"computed": false,
+						"optional": true
+					},
+					"property": {"type": "Identifier", "name": "c"},
+					"computed": false,
+					"optional": false
+				},
+				"arguments": [],
+				"optional": false
+			}
+		}, {
+			"type": "ExpressionStatement",
+			"expression": {
+				"type": "OptionalMemberExpression",
+				"object": {
+					"type": "MemberExpression",
+					"object": {"type": "Identifier", "name": "x"},
+					"property": {"type": "Identifier", "name": "y"},
+					"computed": false
+				},
+				"property": {"type": "Identifier", "name": "z"},
+				"computed": false,
+				"optional": true
+			}
+		}, {
+			"type": "ExpressionStatement",
+			"expression": {"type": "RegExpLiteral", "pattern": "a", "flags": "g", "extra": {"raw": "/a/g"}}
+		}, {
+			"type": "FunctionDeclaration",
+			"id": {"type": "Identifier", "name": "f"},
+			"params": [],
+			"body": {
+				"type": "BlockStatement",
+				"body": [],
+				"directives": [{
+					"type": "Directive",
+					"value": {
+						"type": "DirectiveLiteral",
+						"value": "use asm",
+						"extra": {"raw": "'use asm'", "rawValue": "use asm", "expressionValue": "use asm"}
+					}
+				}]
+			},
+			"generator": false,
+			"async": false
+		}]
+	},
+	"comments": [{"type": "CommentLine", "value": " hi"}]
+}`
+
+var testProgram = estree.Program{
+	Body: []estree.DirectiveOrStatement{
+		estree.Directive{
+			Expression: estree.StringLiteral{Value: "use strict", Raw: `"use strict"`},
+			Directive:  "use strict",
+		},
+		estree.VariableDeclaration{
+			Declarations: []estree.VariableDeclarator{{
+				ID: estree.Identifier{Name: "o"},
+				Init: estree.ObjectExpression{
+					Properties: []estree.PropertyOrSpread{
+						estree.Property{
+							Key:   estree.Identifier{Name: "a"},
+							Value: estree.NumberLiteral{Value: 1, Raw: "1"},
+							Kind:  estree.Init,
+						},
+						estree.Property{
+							Key:    estree.Identifier{Name: "b"},
+							Value:  estree.FunctionExpression{},
+							Kind:   estree.Init,
+							Method: true,
+						},
+						estree.Property{
+							Key: estree.Identifier{Name: "c"},
+							Value: estree.FunctionExpression{
+								Body: estree.FunctionBody{
+									Body: []estree.DirectiveOrStatement{
+										estree.ReturnStatement{
+											Argument: estree.StringLiteral{Value: "x", Raw: "'x'"},
+										},
+									},
+								},
+							},
+							Kind: estree.Get,
+						},
+						estree.Property{
+							Key:      estree.Identifier{Name: "d"},
+							Value:    estree.NullLiteral{},
+							Kind:     estree.Init,
+							Computed: true,
+						},
+					},
+				},
+			}},
+			Kind: estree.Var,
+		},
+		estree.ClassDeclaration{
+			ID: estree.Identifier{Name: "A"},
+			Body: estree.ClassBody{
+				Body: []estree.ClassElement{
+					estree.PropertyDefinition{
+						Key:   estree.PrivateIdentifier{Name: "x"},
+						Value: estree.BigIntLiteral{Value: big.NewInt(1), Raw: "1n"},
+					},
+					estree.MethodDefinition{
+						Key:    estree.Identifier{Name: "m"},
+						Value:  estree.FunctionExpression{},
+						Kind:   estree.Method,
+						Static: true,
+					},
+					estree.MethodDefinition{
+						Key:   estree.PrivateIdentifier{Name: "p"},
+						Value: estree.FunctionExpression{},
+						Kind:  estree.Method,
+					},
+				},
+			},
+		},
+		estree.ExpressionStatement{
+			Expression: estree.ChainExpression{
+				Expression: estree.CallExpression{
+					Callee: estree.MemberExpression{
+						Object: estree.MemberExpression{
+							Object:   estree.Identifier{Name: "a"},
+							Property: estree.Identifier{Name: "b"},
+							Optional: true,
+						},
+						Property: estree.Identifier{Name: "c"},
+					},
+				},
+			},
+		},
+		estree.ExpressionStatement{
+			Expression: estree.ChainExpression{
+				Expression: estree.MemberExpression{
+					Object: estree.MemberExpression{
+						Object:   estree.Identifier{Name: "x"},
+						Property: estree.Identifier{Name: "y"},
+					},
+					Property: estree.Identifier{Name: "z"},
+					Optional: true,
+				},
+			},
+		},
+		estree.ExpressionStatement{
+			Expression: estree.RegExpLiteral{Pattern: "a", Flags: "g", Raw: "/a/g"},
+		},
+		estree.FunctionDeclaration{
+			ID: estree.Identifier{Name: "f"},
+			Body: estree.FunctionBody{
+				Body: []estree.DirectiveOrStatement{
+					estree.Directive{
+						Expression: estree.StringLiteral{Value: "use asm", Raw: "'use asm'"},
+						Directive:  "use asm",
+					},
+				},
+			},
+		},
+	},
+	SourceType: estree.Module,
+	Comments:   []estree.Comment{{Kind: estree.Line, Value: " hi"}},
+}
+
+// nodeTypes returns the type of every object in the JSON value x, in a
+// consistent order.
+func nodeTypes(x interface{}) []string {
+	var types []string
+	switch x := x.(type) {
+	case map[string]interface{}:
+		if typ, ok := x["type"].(string); ok {
+			types = append(types, typ)
+		}
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			types = append(types, nodeTypes(x[k])...)
+		}
+	case []interface{}:
+		for _, v := range x {
+			types = append(types, nodeTypes(v)...)
+		}
+	}
+	return types
+}
+
+func TestUnmarshal(t *testing.T) {
+	p, err := Unmarshal([]byte(testFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p, testProgram) {
+		t.Errorf("expected %+v, got %+v", testProgram, p)
+	}
+	if errs := p.Errors(); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	// A bare Program, without the File, is also accepted.
+	var file struct {
+		Program json.RawMessage `json:"program"`
+	}
+	if err := json.Unmarshal([]byte(testFile), &file); err != nil {
+		t.Fatal(err)
+	}
+	p, err = Unmarshal(file.Program)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := testProgram
+	expect.Comments = nil
+	if !reflect.DeepEqual(p, expect) {
+		t.Errorf("expected %+v, got %+v", expect, p)
+	}
+
+	if _, err := Unmarshal([]byte(`{"type":"File","program":`)); err == nil {
+		t.Error("expected error decoding truncated JSON")
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	b, err := Marshal(testProgram)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := Unmarshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p, testProgram) {
+		t.Errorf("roundtrip failed, expected %+v, got %+v", testProgram, p)
+		t.Log("marshal:", string(b))
+	}
+
+	var in, out interface{}
+	if err := json.Unmarshal([]byte(testFile), &in); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if expect, got := nodeTypes(in), nodeTypes(out); !reflect.DeepEqual(expect, got) {
+		t.Errorf("expected types %v, got %v", expect, got)
+	}
+}
+
+func TestUnmarshalExpression(t *testing.T) {
+	for _, test := range []struct {
+		babel  string
+		expect estree.Expression
+	}{
+		{
+			`{"type":"BooleanLiteral","value":true}`,
+			estree.BoolLiteral{Value: true},
+		},
+		{
+			`{"type":"StringLiteral","value":"it's","extra":{"raw":"'it\\'s'","rawValue":"it's"}}`,
+			estree.StringLiteral{Value: "it's", Raw: `'it\'s'`},
+		},
+		{
+			`{"type":"NumericLiteral","value":31,"extra":{"raw":"0x1F","rawValue":31}}`,
+			estree.NumberLiteral{Value: 31, Raw: "0x1F"},
+		},
+		{
+			`{"type":"CallExpression","callee":{"type":"Import"},"arguments":[{"type":"StringLiteral","value":"m"}]}`,
+			estree.ImportExpression{Source: estree.StringLiteral{Value: "m"}},
+		},
+		{
+			// (a?.b).c: the parenthesized chain ends before .c
+			`{"type":"MemberExpression","object":{"type":"OptionalMemberExpression","object":{"type":"Identifier","name":"a"},"property":{"type":"Identifier","name":"b"},"computed":false,"optional":true},"property":{"type":"Identifier","name":"c"},"computed":false}`,
+			estree.MemberExpression{
+				Object: estree.ChainExpression{
+					Expression: estree.MemberExpression{
+						Object:   estree.Identifier{Name: "a"},
+						Property: estree.Identifier{Name: "b"},
+						Optional: true,
+					},
+				},
+				Property: estree.Identifier{Name: "c"},
+			},
+		},
+	} {
+		e, err := UnmarshalExpression([]byte(test.babel))
+		if err != nil {
+			t.Errorf("unexpected error decoding %s: %v", test.babel, err)
+		} else if !reflect.DeepEqual(e, test.expect) {
+			t.Errorf("expected %+v, got %+v", test.expect, e)
+		}
+	}
+
+	if _, err := UnmarshalExpression([]byte(`{"type":"Nonsense"}`)); err == nil {
+		t.Error("expected error decoding unknown type")
+	}
+}
+
+func TestMarshalNode(t *testing.T) {
+	b, err := MarshalNode(estree.ChainExpression{
+		Expression: estree.MemberExpression{
+			Object: estree.MemberExpression{
+				Object:   estree.Identifier{Name: "x"},
+				Property: estree.Identifier{Name: "y"},
+			},
+			Property: estree.Identifier{Name: "z"},
+			Optional: true,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var x struct {
+		Type   string `json:"type"`
+		Object struct {
+			Type string `json:"type"`
+		} `json:"object"`
+	}
+	if err := json.Unmarshal(b, &x); err != nil {
+		t.Fatal(err)
+	} else if x.Type != "OptionalMemberExpression" || x.Object.Type != "MemberExpression" {
+		t.Errorf("expected OptionalMemberExpression of MemberExpression, got %s", b)
+	}
+}
+
+func TestFromESTree(t *testing.T) {
+	// Directives are moved from the body to the directives property.
+	b, err := FromESTree([]byte(`{"type":"Program","body":[{"type":"ExpressionStatement","expression":{"type":"Literal","value":"use strict","raw":"'use strict'"},"directive":"use strict"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var x struct {
+		Body       []interface{} `json:"body"`
+		Directives []struct {
+			Value struct {
+				Type  string `json:"type"`
+				Value string `json:"value"`
+			} `json:"value"`
+		} `json:"directives"`
+	}
+	if err := json.Unmarshal(b, &x); err != nil {
+		t.Fatal(err)
+	} else if len(x.Body) != 0 || len(x.Directives) != 1 || x.Directives[0].Value.Type != "DirectiveLiteral" || x.Directives[0].Value.Value != "use strict" {
+		t.Errorf("expected a single directive, got %s", b)
+	}
+
+	if _, err := FromESTree([]byte(`{`)); err == nil {
+		t.Error("expected error decoding truncated JSON")
+	}
+}
+
+func TestBigIntLiteral(t *testing.T) {
+	in := `{"type":"BigIntLiteral","value":"0x1f","extra":{"raw":"0x1fn","rawValue":"0x1f"}}`
+	e, err := UnmarshalExpression([]byte(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := MarshalNode(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var x struct {
+		Value string `json:"value"`
+		Extra struct {
+			Raw      string `json:"raw"`
+			RawValue string `json:"rawValue"`
+		} `json:"extra"`
+	}
+	if err := json.Unmarshal(b, &x); err != nil {
+		t.Fatal(err)
+	} else if x.Value != "0x1f" || x.Extra.RawValue != "0x1f" || x.Extra.Raw != "0x1fn" {
+		t.Errorf("expected 0x1f, got %s", b)
+	}
+}
+
+func TestModuleRoundtrip(t *testing.T) {
+	// export * as ns from "m"; import("m", {with: {}});
+	in := `{"type":"Program","sourceType":"module","directives":[],"body":[` +
+		`{"type":"ExportNamedDeclaration","declaration":null,"specifiers":[` +
+		`{"type":"ExportNamespaceSpecifier","exported":{"type":"Identifier","name":"ns"}}],` +
+		`"source":{"type":"StringLiteral","value":"m","extra":{"raw":"\"m\"","rawValue":"m"}}},` +
+		`{"type":"ExpressionStatement","expression":{"type":"CallExpression","callee":{"type":"Import"},` +
+		`"arguments":[{"type":"StringLiteral","value":"m"},{"type":"ObjectExpression","properties":[]}]}}]}`
+	p, err := Unmarshal([]byte(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := estree.Program{
+		SourceType: estree.Module,
+		Body: []estree.DirectiveOrStatement{
+			estree.ExportAllDeclaration{
+				Exported: estree.Identifier{Name: "ns"},
+				Source:   estree.StringLiteral{Value: "m", Raw: `"m"`},
+			},
+			estree.ExpressionStatement{
+				Expression: estree.ImportExpression{
+					Source:  estree.StringLiteral{Value: "m"},
+					Options: estree.ObjectExpression{},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(p, expect) {
+		t.Errorf("expected %+v, got %+v", expect, p)
+	}
+
+	b, err := MarshalNode(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var x struct {
+		Body []struct {
+			Type       string `json:"type"`
+			Specifiers []struct {
+				Type string `json:"type"`
+			} `json:"specifiers"`
+			Expression struct {
+				Type   string `json:"type"`
+				Callee struct {
+					Type string `json:"type"`
+				} `json:"callee"`
+				Arguments []interface{} `json:"arguments"`
+			} `json:"expression"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(b, &x); err != nil {
+		t.Fatal(err)
+	}
+	if len(x.Body) != 2 || x.Body[0].Type != "ExportNamedDeclaration" || len(x.Body[0].Specifiers) != 1 || x.Body[0].Specifiers[0].Type != "ExportNamespaceSpecifier" {
+		t.Errorf("expected ExportNamedDeclaration with ExportNamespaceSpecifier, got %s", b)
+	}
+	if e := x.Body[1].Expression; e.Type != "CallExpression" || e.Callee.Type != "Import" || len(e.Arguments) != 2 {
+		t.Errorf("expected CallExpression with Import callee, got %s", b)
+	}
+	if p2, err := Unmarshal(b); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(p2, expect) {
+		t.Errorf("roundtrip failed, expected %+v, got %+v", expect, p2)
+	}
+}
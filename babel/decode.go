@@ -0,0 +1,216 @@
+package babel
+
+import "strings"
+
+// toESTree converts the generic JSON value x from Babel to ESTree.  Objects
+// are modified in place.
+func toESTree(x interface{}) interface{} {
+	switch x := x.(type) {
+	case map[string]interface{}:
+		return nodeToESTree(x)
+	case []interface{}:
+		for i, v := range x {
+			x[i] = toESTree(v)
+		}
+	}
+	return x
+}
+
+// nodeToESTree converts the JSON object x from Babel to ESTree, after
+// converting its properties.
+func nodeToESTree(x map[string]interface{}) interface{} {
+	typ, _ := x["type"].(string)
+	if isOptionalChain(x) {
+		ce := map[string]interface{}{
+			"type":       "ChainExpression",
+			"expression": chainToESTree(x),
+		}
+		copyLocation(ce, x)
+		return ce
+	}
+	for k, v := range x {
+		x[k] = toESTree(v)
+	}
+	if loc, ok := x["loc"].(map[string]interface{}); ok && loc["source"] == nil && loc["filename"] != nil {
+		loc["source"] = loc["filename"]
+	}
+
+	switch typ {
+	case "File":
+		program, ok := x["program"].(map[string]interface{})
+		if !ok {
+			break
+		}
+		if comments, ok := x["comments"]; ok {
+			program["comments"] = comments
+		}
+		return program
+
+	case "Program", "BlockStatement":
+		if ds, ok := x["directives"].([]interface{}); ok {
+			body, _ := x["body"].([]interface{})
+			for i, d := range ds {
+				ds[i] = directiveToESTree(d)
+			}
+			x["body"] = append(ds, body...)
+			delete(x, "directives")
+		}
+
+	case "StringLiteral", "NumericLiteral", "BooleanLiteral", "NullLiteral":
+		x["type"] = "Literal"
+		literalToESTree(x)
+	case "BigIntLiteral":
+		x["type"], x["bigint"], x["value"] = "Literal", x["value"], nil
+		literalToESTree(x)
+	case "RegExpLiteral":
+		x["type"], x["value"] = "Literal", nil
+		x["regex"] = map[string]interface{}{
+			"pattern": x["pattern"],
+			"flags":   x["flags"],
+		}
+		delete(x, "pattern")
+		delete(x, "flags")
+		literalToESTree(x)
+
+	case "ObjectProperty":
+		x["type"], x["kind"], x["method"] = "Property", "init", false
+	case "ObjectMethod":
+		method := x["kind"] == "method"
+		if method {
+			x["kind"] = "init"
+		}
+		x["type"], x["value"] = "Property", methodToESTree(x)
+		x["method"], x["shorthand"] = method, false
+
+	case "ClassMethod", "ClassPrivateMethod":
+		x["type"], x["value"] = "MethodDefinition", methodToESTree(x)
+	case "ClassProperty", "ClassPrivateProperty":
+		x["type"] = "PropertyDefinition"
+
+	case "PrivateName":
+		x["type"] = "PrivateIdentifier"
+		if id, ok := x["id"].(map[string]interface{}); ok {
+			x["name"] = id["name"]
+		}
+		delete(x, "id")
+
+	case "CallExpression":
+		// import(source) is a CallExpression with an Import callee, unless
+		// Babel's createImportExpressions option is set.
+		callee, _ := x["callee"].(map[string]interface{})
+		args, _ := x["arguments"].([]interface{})
+		if callee == nil || callee["type"] != "Import" || len(args) == 0 {
+			break
+		}
+		x["type"], x["source"], x["options"] = "ImportExpression", args[0], nil
+		if len(args) > 1 {
+			x["options"] = args[1]
+		}
+		delete(x, "callee")
+		delete(x, "arguments")
+		delete(x, "optional")
+
+	case "ExportNamedDeclaration":
+		// export * as ns from "mod" is an ExportNamespaceSpecifier, where
+		// ESTree has an ExportAllDeclaration with an exported name.
+		specs, _ := x["specifiers"].([]interface{})
+		if len(specs) != 1 {
+			break
+		}
+		spec, _ := specs[0].(map[string]interface{})
+		if spec == nil || spec["type"] != "ExportNamespaceSpecifier" {
+			break
+		}
+		x["type"], x["exported"] = "ExportAllDeclaration", spec["exported"]
+		delete(x, "specifiers")
+		delete(x, "declaration")
+	}
+	return x
+}
+
+// literalToESTree moves Babel's extra.raw to the raw property of the literal
+// x.
+func literalToESTree(x map[string]interface{}) {
+	if extra, ok := x["extra"].(map[string]interface{}); ok {
+		if raw, ok := extra["raw"].(string); ok {
+			x["raw"] = raw
+		}
+		delete(x, "extra")
+	}
+}
+
+// methodToESTree moves the function properties of the ObjectMethod or
+// ClassMethod x to a new FunctionExpression, which is returned.  The
+// FunctionExpression takes the location of the method, since Babel does not
+// record where the function itself starts.
+func methodToESTree(x map[string]interface{}) map[string]interface{} {
+	fe := map[string]interface{}{
+		"type":       "FunctionExpression",
+		"id":         nil,
+		"expression": false,
+	}
+	copyLocation(fe, x)
+	for _, k := range functionProperties {
+		if v, ok := x[k]; ok {
+			fe[k] = v
+			delete(x, k)
+		}
+	}
+	delete(x, "id")
+	return fe
+}
+
+// directiveToESTree converts a Babel Directive, which wraps a
+// DirectiveLiteral, to an ESTree directive, i.e. an ExpressionStatement with a
+// directive property.
+func directiveToESTree(x interface{}) interface{} {
+	d, ok := x.(map[string]interface{})
+	if !ok {
+		return x
+	}
+	dl, ok := d["value"].(map[string]interface{})
+	if !ok || dl["type"] != "DirectiveLiteral" {
+		return x
+	}
+	// DirectiveLiteral.value is the raw text between the quotes.  Recent
+	// versions of Babel also record the value of the string, with escapes
+	// interpreted, as extra.expressionValue.
+	lit := map[string]interface{}{
+		"type":  "Literal",
+		"value": dl["value"],
+	}
+	copyLocation(lit, dl)
+	if extra, ok := dl["extra"].(map[string]interface{}); ok {
+		if v, ok := extra["expressionValue"].(string); ok {
+			lit["value"] = v
+		}
+		if raw, ok := extra["raw"].(string); ok {
+			lit["raw"] = raw
+		}
+	}
+	d["type"], d["expression"], d["directive"] = "ExpressionStatement", lit, dl["value"]
+	delete(d, "value")
+	return d
+}
+
+// isOptionalChain indicates whether x is an OptionalMemberExpression or
+// OptionalCallExpression.
+func isOptionalChain(x interface{}) bool {
+	m, ok := x.(map[string]interface{})
+	return ok && (m["type"] == "OptionalMemberExpression" || m["type"] == "OptionalCallExpression")
+}
+
+// chainToESTree converts the Babel optional chain x to the Expression of an
+// ESTree ChainExpression.  The Object or Callee is part of the same chain if
+// it is also an OptionalMemberExpression or OptionalCallExpression.
+func chainToESTree(x map[string]interface{}) map[string]interface{} {
+	for k, v := range x {
+		if (k == "object" || k == "callee") && isOptionalChain(v) {
+			x[k] = chainToESTree(v.(map[string]interface{}))
+		} else {
+			x[k] = toESTree(v)
+		}
+	}
+	x["type"] = strings.TrimPrefix(x["type"].(string), "Optional")
+	return x
+}
@@ -0,0 +1,249 @@
+package babel
+
+import "encoding/json"
+
+// toBabel converts the generic JSON value x from ESTree to Babel.  Objects
+// are modified in place.
+func toBabel(x interface{}) interface{} {
+	switch x := x.(type) {
+	case map[string]interface{}:
+		return nodeToBabel(x)
+	case []interface{}:
+		for i, v := range x {
+			x[i] = toBabel(v)
+		}
+	}
+	return x
+}
+
+// nodeToBabel converts the JSON object x from ESTree to Babel, after
+// converting its properties.
+func nodeToBabel(x map[string]interface{}) interface{} {
+	for k, v := range x {
+		x[k] = toBabel(v)
+	}
+	// Babel's tools expect arrays, where json.Marshal encodes a nil slice as
+	// null.
+	for _, k := range []string{"params", "arguments", "elements", "expressions"} {
+		if v, ok := x[k]; ok && v == nil {
+			x[k] = []interface{}{}
+		}
+	}
+
+	switch x["type"] {
+	case "Program", "BlockStatement":
+		body, _ := x["body"].([]interface{})
+		ds := []interface{}{}
+		for len(body) > 0 && isDirective(body[0]) {
+			ds = append(ds, directiveToBabel(body[0].(map[string]interface{})))
+			body = body[1:]
+		}
+		if body == nil {
+			body = []interface{}{}
+		}
+		x["body"], x["directives"] = body, ds
+
+	case "Literal":
+		literalToBabel(x)
+	case "Line", "Block":
+		x["type"] = "Comment" + x["type"].(string)
+
+	case "Property":
+		if x["method"] == true || x["kind"] == "get" || x["kind"] == "set" {
+			if x["method"] == true {
+				x["kind"] = "method"
+			}
+			x["type"] = "ObjectMethod"
+			methodToBabel(x)
+			delete(x, "shorthand")
+		} else {
+			x["type"] = "ObjectProperty"
+			delete(x, "kind")
+		}
+		delete(x, "method")
+
+	case "MethodDefinition":
+		x["type"] = "ClassMethod"
+		if isPrivateName(x["key"]) {
+			x["type"] = "ClassPrivateMethod"
+		}
+		methodToBabel(x)
+	case "PropertyDefinition":
+		x["type"] = "ClassProperty"
+		if isPrivateName(x["key"]) {
+			x["type"] = "ClassPrivateProperty"
+		}
+
+	case "ImportExpression":
+		args := []interface{}{x["source"]}
+		if x["options"] != nil {
+			args = append(args, x["options"])
+		}
+		x["type"], x["callee"], x["arguments"] = "CallExpression", map[string]interface{}{"type": "Import"}, args
+		x["optional"] = false
+		delete(x, "source")
+		delete(x, "options")
+	case "ExportAllDeclaration":
+		if x["exported"] == nil {
+			break
+		}
+		spec := map[string]interface{}{
+			"type":     "ExportNamespaceSpecifier",
+			"exported": x["exported"],
+		}
+		x["type"], x["specifiers"], x["declaration"] = "ExportNamedDeclaration", []interface{}{spec}, nil
+		delete(x, "exported")
+
+	case "PrivateIdentifier":
+		id := map[string]interface{}{
+			"type": "Identifier",
+			"name": x["name"],
+		}
+		copyLocation(id, x)
+		x["type"], x["id"] = "PrivateName", id
+		delete(x, "name")
+
+	case "ChainExpression":
+		// Babel attaches comments to the chain itself.
+		e, ok := x["expression"].(map[string]interface{})
+		if !ok {
+			break
+		}
+		for _, k := range []string{"leadingComments", "trailingComments"} {
+			if v, ok := x[k]; ok {
+				e[k] = v
+			}
+		}
+		chainToBabel(e)
+		return e
+	}
+	return x
+}
+
+// literalToBabel converts the ESTree Literal x to the Babel literal of the
+// appropriate type, moving raw to extra.raw.
+func literalToBabel(x map[string]interface{}) {
+	extra := map[string]interface{}{}
+	if regex, ok := x["regex"].(map[string]interface{}); ok {
+		x["type"], x["pattern"], x["flags"] = "RegExpLiteral", regex["pattern"], regex["flags"]
+		delete(x, "regex")
+		delete(x, "value")
+	} else if bigint, ok := x["bigint"]; ok {
+		x["type"], x["value"] = "BigIntLiteral", bigint
+		extra["rawValue"] = bigint
+		delete(x, "bigint")
+	} else {
+		extra["rawValue"] = x["value"]
+		switch x["value"].(type) {
+		case string:
+			x["type"] = "StringLiteral"
+		case json.Number:
+			x["type"] = "NumericLiteral"
+		case bool:
+			x["type"] = "BooleanLiteral"
+		case nil:
+			x["type"] = "NullLiteral"
+			delete(x, "value")
+		}
+	}
+	if raw, ok := x["raw"]; ok {
+		extra["raw"] = raw
+		x["extra"] = extra
+		delete(x, "raw")
+	}
+}
+
+// methodToBabel moves the function properties of the FunctionExpression value
+// of x, a Property or MethodDefinition, to x itself.
+func methodToBabel(x map[string]interface{}) {
+	if fe, ok := x["value"].(map[string]interface{}); ok {
+		for _, k := range functionProperties {
+			if v, ok := fe[k]; ok {
+				x[k] = v
+			}
+		}
+	}
+	x["id"] = nil
+	delete(x, "value")
+}
+
+// isDirective indicates whether x is an ESTree directive, i.e. an
+// ExpressionStatement with a directive property, or a Directive in the legacy
+// encoding of pifke.org/estree.
+func isDirective(x interface{}) bool {
+	m, ok := x.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, hasDirective := m["directive"].(string)
+	return m["type"] == "Directive" || (m["type"] == "ExpressionStatement" && hasDirective)
+}
+
+// directiveToBabel converts the ESTree Directive x to a Babel Directive,
+// which wraps a DirectiveLiteral.
+func directiveToBabel(x map[string]interface{}) map[string]interface{} {
+	dl := map[string]interface{}{
+		"type":  "DirectiveLiteral",
+		"value": x["directive"],
+	}
+	// The expression has already been converted to a StringLiteral.
+	if lit, ok := x["expression"].(map[string]interface{}); ok {
+		copyLocation(dl, lit)
+		extra, _ := lit["extra"].(map[string]interface{})
+		if extra == nil {
+			extra = map[string]interface{}{}
+		}
+		extra["rawValue"], extra["expressionValue"] = x["directive"], lit["value"]
+		dl["extra"] = extra
+	}
+	d := map[string]interface{}{
+		"type":  "Directive",
+		"value": dl,
+	}
+	copyLocation(d, x)
+	for _, k := range []string{"leadingComments", "trailingComments"} {
+		if v, ok := x[k]; ok {
+			d[k] = v
+		}
+	}
+	return d
+}
+
+// isPrivateName indicates whether the converted key x is a PrivateName.
+func isPrivateName(x interface{}) bool {
+	m, ok := x.(map[string]interface{})
+	return ok && m["type"] == "PrivateName"
+}
+
+// chainToBabel converts the Expression of an ESTree ChainExpression to a
+// Babel optional chain.  Babel uses OptionalMemberExpression and
+// OptionalCallExpression for every link from the outermost down to the last
+// optional one, so a.b?.c is an OptionalMemberExpression with a plain
+// MemberExpression as its Object.
+func chainToBabel(x map[string]interface{}) {
+	var links []map[string]interface{}
+	last := -1
+	for e := x; e != nil; {
+		var next interface{}
+		switch e["type"] {
+		case "MemberExpression":
+			next = e["object"]
+		case "CallExpression":
+			next = e["callee"]
+		}
+		if next == nil {
+			break
+		}
+		links = append(links, e)
+		if e["optional"] == true {
+			last = len(links) - 1
+		}
+		e, _ = next.(map[string]interface{})
+	}
+	for _, e := range links[:last+1] {
+		e["type"] = "Optional" + e["type"].(string)
+		if _, ok := e["optional"]; !ok {
+			e["optional"] = false
+		}
+	}
+}
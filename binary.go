@@ -31,6 +31,7 @@ var (
 	BitwiseAnd         BinaryOperator = "&"
 	In                 BinaryOperator = "in"
 	InstanceOf         BinaryOperator = "instanceof"
+	Exponentiate       BinaryOperator = "**"
 )
 
 func (bo BinaryOperator) GoString() string {
@@ -77,6 +78,8 @@ func (bo BinaryOperator) GoString() string {
 		return "In"
 	case InstanceOf:
 		return "InstanceOf"
+	case Exponentiate:
+		return "Exponentiate"
 	}
 	return fmt.Sprintf("%q", bo)
 }
@@ -86,26 +89,39 @@ func (bo BinaryOperator) IsValid() bool {
 	case Equal, NotEqual, StrictEqual, StrictNotEqual, LessThan,
 		LessThanOrEqual, GreaterThan, GreaterThanOrEqual, LeftShift,
 		SignedRightShift, UnsignedRightShift, Add, Subtract, Multiply, Divide,
-		Remainder, BitwiseOr, BitwiseXor, BitwiseAnd, In, InstanceOf:
+		Remainder, BitwiseOr, BitwiseXor, BitwiseAnd, In, InstanceOf,
+		Exponentiate:
 		return true
 	}
 	return false
 }
 
-func (bo BinaryOperator) MinVersion() Version { return ES5 }
+func (bo BinaryOperator) MinVersion() Version {
+	if bo == Exponentiate {
+		return ES2016
+	}
+	return ES5
+}
 
 // BinaryExpression is a binary (two operand) expression.
 type BinaryExpression struct {
 	baseExpression
-	Loc         SourceLocation
-	Operator    BinaryOperator
-	Left, Right Expression
+	Loc      SourceLocation
+	Operator BinaryOperator
+
+	// Left may only be a PrivateIdentifier when Operator is In, i.e. a brand
+	// check such as #x in obj.
+	Left  ExpressionOrPrivateIdentifier
+	Right Expression
 }
 
 func (BinaryExpression) Type() string                { return "BinaryExpression" }
 func (be BinaryExpression) Location() SourceLocation { return be.Loc }
 
 func (be BinaryExpression) MinVersion() Version {
+	if _, ok := be.Left.(PrivateIdentifier); ok {
+		return ES2022
+	}
 	return be.Operator.MinVersion()
 }
 
@@ -128,12 +144,40 @@ func (be BinaryExpression) Walk(v Visitor) {
 	}
 }
 
+// Errors checks the BinaryExpression.  A UnaryExpression on the left-hand side
+// of ** must be parenthesized, but ESTree does not record parentheses, so
+// -a ** b and (-a) ** b are the same tree; Validate reports it only with
+// ValidateOptions.PreserveParens.
 func (be BinaryExpression) Errors() []error {
+	return be.errors(false)
+}
+
+func (be BinaryExpression) contextErrors(ctx validationContext) []error {
+	return be.errors(ctx.PreserveParens)
+}
+
+// hasUnaryBase indicates whether the Operator is ** and the Left operand is a
+// UnaryExpression or AwaitExpression.
+func (be BinaryExpression) hasUnaryBase() bool {
+	switch be.Left.(type) {
+	case UnaryExpression, AwaitExpression:
+		return be.Operator == Exponentiate
+	}
+	return false
+}
+
+func (be BinaryExpression) errors(parens bool) []error {
 	c := nodeChecker{Node: be}
 	c.require(be.Left, "left-hand expression")
 	if !be.Operator.IsValid() {
 		c.appendf("%w binary operator %q", ErrWrongValue, be.Operator)
 	}
+	if parens && be.hasUnaryBase() {
+		c.appendf("unparenthesized unary expression on left-hand side of ** %w", ErrNotAllowed)
+	}
+	if _, ok := be.Left.(PrivateIdentifier); ok && be.Operator != In {
+		c.appendf("private name on left-hand side of %#v %w", be.Operator, ErrNotAllowed)
+	}
 	c.require(be.Right, "right-hand expression")
 	return c.errors()
 }
@@ -148,8 +192,9 @@ func (be BinaryExpression) MarshalJSON() ([]byte, error) {
 
 func (be *BinaryExpression) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type     string          `json:"type"`
-		Loc      SourceLocation  `json:"loc"`
 		Operator BinaryOperator  `json:"operator"`
 		Left     json.RawMessage `json:"left"`
 		Right    json.RawMessage `json:"right"`
@@ -159,14 +204,14 @@ func (be *BinaryExpression) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, be.Type(), x.Type)
 	}
 	if err == nil {
-		be.Loc = x.Loc
+		be.Loc = x.Location()
 		if x.Operator.IsValid() {
 			be.Operator = x.Operator
 		} else {
 			err = fmt.Errorf("%w BinaryExpression.Operator %q", ErrWrongValue, x.Operator)
 		}
 		var err2 error
-		if be.Left, _, err2 = unmarshalExpression(x.Left); err == nil && err2 != nil {
+		if be.Left, err2 = unmarshalExpressionOrPrivateIdentifier(x.Left); err == nil && err2 != nil {
 			err = err2
 		}
 		if be.Right, _, err = unmarshalExpression(x.Right); err == nil && err2 != nil {
@@ -193,6 +238,10 @@ var (
 	BitwiseOrAssign          AssignmentOperator = "|="
 	BitwiseXorAssign         AssignmentOperator = "^="
 	BitwiseAndAssign         AssignmentOperator = "&="
+	ExponentiateAssign       AssignmentOperator = "**="
+	OrAssign                 AssignmentOperator = "||="
+	AndAssign                AssignmentOperator = "&&="
+	NullishCoalesceAssign    AssignmentOperator = "??="
 )
 
 func (ao AssignmentOperator) GoString() string {
@@ -221,6 +270,14 @@ func (ao AssignmentOperator) GoString() string {
 		return "BitwiseXorAssign"
 	case BitwiseAndAssign:
 		return "BitwiseAndAssign"
+	case ExponentiateAssign:
+		return "ExponentiateAssign"
+	case OrAssign:
+		return "OrAssign"
+	case AndAssign:
+		return "AndAssign"
+	case NullishCoalesceAssign:
+		return "NullishCoalesceAssign"
 	}
 	return fmt.Sprintf("%q", ao)
 }
@@ -230,12 +287,23 @@ func (ao AssignmentOperator) IsValid() bool {
 	case Assign, AddAssign, SubtractAssign, MultiplyAssign, DivideAssign,
 		RemainderAssign, LeftShiftAssign, SignedRightShiftAssign,
 		UnsignedRightShiftAssign, BitwiseOrAssign, BitwiseXorAssign,
-		BitwiseAndAssign:
+		BitwiseAndAssign, ExponentiateAssign, OrAssign, AndAssign,
+		NullishCoalesceAssign:
 		return true
 	}
 	return false
 }
 
+func (ao AssignmentOperator) MinVersion() Version {
+	switch ao {
+	case ExponentiateAssign:
+		return ES2016
+	case OrAssign, AndAssign, NullishCoalesceAssign:
+		return ES2021
+	}
+	return ES5
+}
+
 // AssignmentExpression is an expression modifying the Left operand according
 // to the Right.
 type AssignmentExpression struct {
@@ -249,6 +317,10 @@ type AssignmentExpression struct {
 func (AssignmentExpression) Type() string                { return "AssignmentExpression" }
 func (ae AssignmentExpression) Location() SourceLocation { return ae.Loc }
 
+func (ae AssignmentExpression) MinVersion() Version {
+	return ae.Operator.MinVersion()
+}
+
 func (ae AssignmentExpression) IsZero() bool {
 	return ae.Loc.IsZero() &&
 		ae.Operator == "" &&
@@ -271,6 +343,9 @@ func (ae AssignmentExpression) Walk(v Visitor) {
 func (ae AssignmentExpression) Errors() []error {
 	c := nodeChecker{Node: ae}
 	c.require(ae.Left, "left-hand expression in assignment")
+	if _, ok := ae.Left.(ChainExpression); ok {
+		c.appendf("optional chain as assignment target %w", ErrNotAllowed)
+	}
 	if !ae.Operator.IsValid() {
 		c.appendf("%w assignment operator %q", ErrWrongValue, ae.Operator)
 	}
@@ -288,8 +363,9 @@ func (ae AssignmentExpression) MarshalJSON() ([]byte, error) {
 
 func (ae *AssignmentExpression) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type     string             `json:"type"`
-		Loc      SourceLocation     `json:"loc"`
 		Operator AssignmentOperator `json:"operator"`
 		Left     json.RawMessage    `json:"left"`
 		Right    json.RawMessage    `json:"right"`
@@ -299,7 +375,7 @@ func (ae *AssignmentExpression) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ae.Type(), x.Type)
 	}
 	if err == nil {
-		ae.Loc = x.Loc
+		ae.Loc = x.Location()
 		if x.Operator.IsValid() {
 			ae.Operator = x.Operator
 		} else {
@@ -321,8 +397,9 @@ func (ae *AssignmentExpression) UnmarshalJSON(b []byte) error {
 type LogicalOperator string
 
 var (
-	Or  LogicalOperator = "||"
-	And LogicalOperator = "&&"
+	Or              LogicalOperator = "||"
+	And             LogicalOperator = "&&"
+	NullishCoalesce LogicalOperator = "??"
 )
 
 func (lo LogicalOperator) GoString() string {
@@ -331,18 +408,27 @@ func (lo LogicalOperator) GoString() string {
 		return "Or"
 	case And:
 		return "And"
+	case NullishCoalesce:
+		return "NullishCoalesce"
 	}
 	return fmt.Sprintf("%q", lo)
 }
 
 func (lo LogicalOperator) IsValid() bool {
 	switch lo {
-	case Or, And:
+	case Or, And, NullishCoalesce:
 		return true
 	}
 	return false
 }
 
+func (lo LogicalOperator) MinVersion() Version {
+	if lo == NullishCoalesce {
+		return ES2020
+	}
+	return ES5
+}
+
 // LogicalExpression is an expression evaluating Boolean logic between two
 // operands.
 type LogicalExpression struct {
@@ -355,6 +441,10 @@ type LogicalExpression struct {
 func (LogicalExpression) Type() string                { return "LogicalExpression" }
 func (le LogicalExpression) Location() SourceLocation { return le.Loc }
 
+func (le LogicalExpression) MinVersion() Version {
+	return le.Operator.MinVersion()
+}
+
 func (le LogicalExpression) IsZero() bool {
 	return le.Loc.IsZero() &&
 		le.Operator == "" &&
@@ -374,12 +464,38 @@ func (le LogicalExpression) Walk(v Visitor) {
 	}
 }
 
+// Errors checks the LogicalExpression.  ?? may not be mixed with && or ||
+// without parentheses, but as for BinaryExpression, Validate reports this only
+// with ValidateOptions.PreserveParens.
 func (le LogicalExpression) Errors() []error {
+	return le.errors(false)
+}
+
+func (le LogicalExpression) contextErrors(ctx validationContext) []error {
+	return le.errors(ctx.PreserveParens)
+}
+
+// mixesNullish indicates whether an operand is a LogicalExpression which
+// differs from le in whether its Operator is ??.
+func (le LogicalExpression) mixesNullish() bool {
+	nullish := le.Operator == NullishCoalesce
+	for _, operand := range []Expression{le.Left, le.Right} {
+		if o, ok := operand.(LogicalExpression); ok && nullish != (o.Operator == NullishCoalesce) {
+			return true
+		}
+	}
+	return false
+}
+
+func (le LogicalExpression) errors(parens bool) []error {
 	c := nodeChecker{Node: le}
 	c.require(le.Left, "left-hand expression")
 	if !le.Operator.IsValid() {
 		c.appendf("%w logical operator %q", ErrWrongValue, le.Operator)
 	}
+	if parens && le.mixesNullish() {
+		c.appendf("unparenthesized ?? mixed with && or || %w", ErrNotAllowed)
+	}
 	c.require(le.Right, "right-hand expression")
 	return c.errors()
 }
@@ -394,8 +510,9 @@ func (le LogicalExpression) MarshalJSON() ([]byte, error) {
 
 func (le *LogicalExpression) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type     string          `json:"type"`
-		Loc      SourceLocation  `json:"loc"`
 		Operator LogicalOperator `json:"operator"`
 		Left     json.RawMessage `json:"left"`
 		Right    json.RawMessage `json:"right"`
@@ -405,7 +522,7 @@ func (le *LogicalExpression) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, le.Type(), x.Type)
 	}
 	if err == nil {
-		le.Loc = x.Loc
+		le.Loc = x.Location()
 		if x.Operator.IsValid() {
 			le.Operator = x.Operator
 		} else {
@@ -427,19 +544,33 @@ func (le *LogicalExpression) UnmarshalJSON(b []byte) error {
 type MemberExpression struct {
 	baseExpression
 	Loc      SourceLocation
-	Object   Expression
-	Property Expression
+	Object   ExpressionOrSuper
+	Property ExpressionOrPrivateIdentifier
 
 	// Computed indicates the node corresponds to a computed (a[b]) member
 	// expression, and Property is an Expression.  If Computed is false, the
 	// node corresponds to a static (a.b) member expression and Property is an
 	// Identifier.
 	Computed bool
+
+	// Optional indicates the node corresponds to an optional (a?.b) member
+	// expression.  It is only allowed within a ChainExpression.
+	Optional bool
 }
 
 func (MemberExpression) Type() string                { return "MemberExpression" }
 func (me MemberExpression) Location() SourceLocation { return me.Loc }
 
+func (me MemberExpression) MinVersion() Version {
+	if _, ok := me.Property.(PrivateIdentifier); ok {
+		return ES2022
+	}
+	if me.Optional {
+		return ES2020
+	}
+	return ES5
+}
+
 func (me MemberExpression) IsZero() bool {
 	return me.Loc.IsZero() &&
 		(me.Object == nil || me.Object.IsZero()) &&
@@ -458,10 +589,37 @@ func (me MemberExpression) Walk(v Visitor) {
 	}
 }
 
+// Errors checks the MemberExpression.  Optional is only allowed within a
+// ChainExpression; since that can't be determined without the ancestor Nodes,
+// use Validate to check optional member expressions.
 func (me MemberExpression) Errors() []error {
+	return me.errors(false)
+}
+
+func (me MemberExpression) contextErrors(ctx validationContext) []error {
+	return me.errors(me.Optional && ctx.inChain())
+}
+
+func (me MemberExpression) errors(inChain bool) []error {
 	c := nodeChecker{Node: me}
 	c.require(me.Object, "object in member expression")
 	c.require(me.Property, "property or index in member expression")
+	if me.Optional {
+		if !inChain {
+			c.appendf("optional member expression outside of ChainExpression %w", ErrNotAllowed)
+		}
+		if _, ok := me.Object.(Super); ok {
+			c.appendf("optional super member expression %w", ErrNotAllowed)
+		}
+	}
+	if _, ok := me.Property.(PrivateIdentifier); ok {
+		if me.Computed {
+			c.appendf("computed private name %w", ErrNotAllowed)
+		}
+		if _, ok := me.Object.(Super); ok {
+			c.appendf("private name of super %w", ErrNotAllowed)
+		}
+	}
 	return c.errors()
 }
 
@@ -470,26 +628,29 @@ func (me MemberExpression) MarshalJSON() ([]byte, error) {
 	x["object"] = me.Object
 	x["property"] = me.Property
 	x["computed"] = me.Computed
+	x["optional"] = me.Optional
 	return json.Marshal(x)
 }
 
 func (me *MemberExpression) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type     string          `json:"type"`
-		Loc      SourceLocation  `json:"loc"`
 		Object   json.RawMessage `json:"object"`
 		Property json.RawMessage `json:"property"`
 		Computed bool            `json:"computed"`
+		Optional bool            `json:"optional"`
 	}
 	err := json.Unmarshal(b, &x)
 	if err == nil && x.Type != me.Type() {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, me.Type(), x.Type)
 	}
 	if err == nil {
-		me.Loc, me.Computed = x.Loc, x.Computed
-		me.Object, _, err = unmarshalExpression(x.Object)
+		me.Loc, me.Computed, me.Optional = x.Location(), x.Computed, x.Optional
+		me.Object, err = unmarshalExpressionOrSuper(x.Object)
 		var err2 error
-		if me.Property, _, err2 = unmarshalExpression(x.Property); err == nil && err2 != nil {
+		if me.Property, err2 = unmarshalExpressionOrPrivateIdentifier(x.Property); err == nil && err2 != nil {
 			err = err2
 		}
 	}
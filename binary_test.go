@@ -181,3 +181,124 @@ func TestMemberExpression(t *testing.T) {
 		t.Error("expected ErrMissingNode for nil Right")
 	}
 }
+
+func TestExponentiation(t *testing.T) {
+	be := BinaryExpression{
+		Operator: Exponentiate,
+		Left:     Identifier{Name: "a"},
+		Right:    NumberLiteral{Value: 2.0},
+	}
+	if be.MinVersion() != ES2016 {
+		t.Errorf("expected ES2016, got %s", be.MinVersion())
+	}
+	testRoundtripJSON(t, be, new(BinaryExpression))
+	if errs := be.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	neg := UnaryExpression{
+		Operator: Minus,
+		Argument: Identifier{Name: "a"},
+	}
+	// -a ** 2 and (-a) ** 2 are the same tree unless parentheses were
+	// preserved.
+	be.Left = neg
+	if errs := Validate(be); len(errs) != 0 {
+		t.Fatalf("unexpected errors without PreserveParens: %v", errs)
+	}
+	vo := ValidateOptions{PreserveParens: true}
+	if !hasError(ErrNotAllowed, vo.Validate(be)...) {
+		t.Error("expected ErrNotAllowed for unparenthesized unary operand")
+	}
+	be.Left = ParenthesizedExpression{Expression: neg}
+	if errs := vo.Validate(be); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	testRoundtripJSON(t, be, new(BinaryExpression))
+
+	ae := AssignmentExpression{
+		Operator: ExponentiateAssign,
+		Left:     Identifier{Name: "a"},
+		Right:    NumberLiteral{Value: 2.0},
+	}
+	if ae.MinVersion() != ES2016 {
+		t.Errorf("expected ES2016, got %s", ae.MinVersion())
+	}
+	testRoundtripJSON(t, ae, new(AssignmentExpression))
+}
+
+func TestNullishCoalescing(t *testing.T) {
+	le := LogicalExpression{
+		Operator: NullishCoalesce,
+		Left:     Identifier{Name: "a"},
+		Right:    Identifier{Name: "b"},
+	}
+	if le.MinVersion() != ES2020 {
+		t.Errorf("expected ES2020, got %s", le.MinVersion())
+	}
+	testRoundtripJSON(t, le, new(LogicalExpression))
+	if errs := le.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	or := LogicalExpression{
+		Operator: Or,
+		Left:     Identifier{Name: "b"},
+		Right:    Identifier{Name: "c"},
+	}
+	le.Right = or
+	if errs := Validate(le); len(errs) != 0 {
+		t.Fatalf("unexpected errors without PreserveParens: %v", errs)
+	}
+	vo := ValidateOptions{PreserveParens: true}
+	if !hasError(ErrNotAllowed, vo.Validate(le)...) {
+		t.Error("expected ErrNotAllowed for ?? mixed with ||")
+	}
+	le.Right = ParenthesizedExpression{Expression: or}
+	if errs := vo.Validate(le); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	le.Left = Identifier{Name: "a"}
+	le.Right = LogicalExpression{
+		Operator: NullishCoalesce,
+		Left:     Identifier{Name: "b"},
+		Right:    Identifier{Name: "c"},
+	}
+	if errs := le.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	// (a ?? b) || c, as parsed without preserving parentheses.
+	or.Left = LogicalExpression{
+		Operator: NullishCoalesce,
+		Left:     Identifier{Name: "a"},
+		Right:    Identifier{Name: "b"},
+	}
+	if errs := Validate(or); len(errs) != 0 {
+		t.Fatalf("unexpected errors without PreserveParens: %v", errs)
+	}
+	if !hasError(ErrNotAllowed, vo.Validate(or)...) {
+		t.Error("expected ErrNotAllowed for || mixed with ??")
+	}
+	or.Left = ParenthesizedExpression{Expression: or.Left}
+	if errs := vo.Validate(or); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestLogicalAssignment(t *testing.T) {
+	for _, op := range []AssignmentOperator{OrAssign, AndAssign, NullishCoalesceAssign} {
+		ae := AssignmentExpression{
+			Operator: op,
+			Left:     Identifier{Name: "a"},
+			Right:    Identifier{Name: "b"},
+		}
+		if ae.MinVersion() != ES2021 {
+			t.Errorf("expected ES2021, got %s", ae.MinVersion())
+		}
+		testRoundtripJSON(t, ae, new(AssignmentExpression))
+		if errs := ae.Errors(); len(errs) != 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+	}
+}
@@ -44,7 +44,13 @@ func (is IfStatement) Errors() []error {
 	c := nodeChecker{Node: is}
 	c.require(is.Test, "if expression")
 	c.require(is.Consequent, "if statement block")
+	if isLexicalDeclaration(is.Consequent) {
+		c.appendf("lexical declaration as if statement block %w", ErrNotAllowed)
+	}
 	c.optional(is.Alternate)
+	if isLexicalDeclaration(is.Alternate) {
+		c.appendf("lexical declaration as else statement block %w", ErrNotAllowed)
+	}
 	return c.errors()
 }
 
@@ -60,8 +66,9 @@ func (is IfStatement) MarshalJSON() ([]byte, error) {
 
 func (is *IfStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type       string          `json:"type"`
-		Loc        SourceLocation  `json:"loc"`
 		Test       json.RawMessage `json:"test"`
 		Consequent json.RawMessage `json:"consequent"`
 		Alternate  json.RawMessage `json:"alternate"`
@@ -71,7 +78,7 @@ func (is *IfStatement) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, is.Type(), x.Type)
 	}
 	if err == nil {
-		is.Loc = x.Loc
+		is.Loc = x.Location()
 		is.Test, _, err = unmarshalExpression(x.Test)
 		var err2 error
 		if is.Consequent, _, err2 = unmarshalStatement(x.Consequent); err == nil && err2 != nil {
@@ -131,8 +138,9 @@ func (ss SwitchStatement) MarshalJSON() ([]byte, error) {
 
 func (ss *SwitchStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type         string          `json:"type"`
-		Loc          SourceLocation  `json:"loc"`
 		Discriminant json.RawMessage `json:"test"`
 		Cases        []SwitchCase    `json:"cases"`
 	}
@@ -141,7 +149,7 @@ func (ss *SwitchStatement) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ss.Type(), x.Type)
 	}
 	if err == nil {
-		ss.Loc, ss.Cases = x.Loc, x.Cases
+		ss.Loc, ss.Cases = x.Location(), x.Cases
 		ss.Discriminant, _, err = unmarshalExpression(x.Discriminant)
 	}
 	return err
@@ -199,8 +207,9 @@ func (sc SwitchCase) MarshalJSON() ([]byte, error) {
 
 func (sc *SwitchCase) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type       string            `json:"type"`
-		Loc        SourceLocation    `json:"loc"`
 		Test       json.RawMessage   `json:"test"`
 		Consequent []json.RawMessage `json:"consequent"`
 	}
@@ -209,7 +218,7 @@ func (sc *SwitchCase) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, sc.Type(), x.Type)
 	}
 	if err == nil {
-		sc.Loc = x.Loc
+		sc.Loc = x.Location()
 		sc.Test, _, err = unmarshalExpression(x.Test)
 		if len(x.Consequent) == 0 {
 			sc.Consequent = nil
@@ -43,6 +43,17 @@ func TestIfStatement(t *testing.T) {
 	if !hasError(ErrMissingNode, is.Errors()...) {
 		t.Error("expected ErrMissingNode for nil Consequent")
 	}
+	is.Consequent = VariableDeclaration{
+		Kind: Let,
+		Declarations: []VariableDeclarator{
+			VariableDeclarator{
+				ID: Identifier{Name: "foo"},
+			},
+		},
+	}
+	if !hasError(ErrNotAllowed, is.Errors()...) {
+		t.Error("expected ErrNotAllowed for let Consequent")
+	}
 }
 
 func TestSwitchStatement(t *testing.T) {
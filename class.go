@@ -0,0 +1,1329 @@
+package estree
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Class is a class declaration or expression.
+type Class interface {
+	Node
+	ClassID() Identifier // or nil
+	ClassSuperClass() Expression
+	ClassBody() ClassBody
+}
+
+func (cd ClassDeclaration) ClassID() Identifier         { return cd.ID }
+func (cd ClassDeclaration) ClassSuperClass() Expression { return cd.SuperClass }
+func (cd ClassDeclaration) ClassBody() ClassBody        { return cd.Body }
+
+func (ce ClassExpression) ClassID() Identifier         { return ce.ID }
+func (ce ClassExpression) ClassSuperClass() Expression { return ce.SuperClass }
+func (ce ClassExpression) ClassBody() ClassBody        { return ce.Body }
+
+// ClassDeclaration declares a class.  Note that unlike in the parent
+// interface Class, ID cannot be zero, except when the ClassDeclaration is the
+// Declaration of an ExportDefaultDeclaration.
+type ClassDeclaration struct {
+	baseDeclaration
+	Loc        SourceLocation
+	ID         Identifier
+	SuperClass Expression // or nil
+	Body       ClassBody
+	Decorators []Decorator
+
+	TypeParameters     TypeParameterDeclaration   // or nil
+	SuperTypeArguments TypeParameterInstantiation // or nil
+	Implements         []ClassImplements
+	Abstract           bool
+	Declare            bool
+}
+
+func (ClassDeclaration) Type() string                { return "ClassDeclaration" }
+func (cd ClassDeclaration) Location() SourceLocation { return cd.Loc }
+func (ClassDeclaration) MinVersion() Version         { return ES2015 }
+
+func (cd ClassDeclaration) IsZero() bool {
+	return cd.Loc.IsZero() &&
+		cd.ID.IsZero() &&
+		(cd.SuperClass == nil || cd.SuperClass.IsZero()) &&
+		len(cd.Body.Body) == 0 &&
+		len(cd.Decorators) == 0 &&
+		cd.TypeParameters == nil &&
+		cd.SuperTypeArguments == nil &&
+		len(cd.Implements) == 0 &&
+		!cd.Abstract &&
+		!cd.Declare
+}
+
+func (cd ClassDeclaration) Walk(v Visitor) {
+	if v = v.Visit(cd); v != nil {
+		defer v.Visit(nil)
+		for _, d := range cd.Decorators {
+			d.Walk(v)
+		}
+		if !cd.ID.IsZero() {
+			cd.ID.Walk(v)
+		}
+		if cd.TypeParameters != nil {
+			cd.TypeParameters.Walk(v)
+		}
+		if cd.SuperClass != nil {
+			cd.SuperClass.Walk(v)
+		}
+		if cd.SuperTypeArguments != nil {
+			cd.SuperTypeArguments.Walk(v)
+		}
+		for _, ci := range cd.Implements {
+			if ci != nil {
+				ci.Walk(v)
+			}
+		}
+		cd.Body.Walk(v)
+	}
+}
+
+// Errors checks the ClassDeclaration.  ID is required unless the class is
+// exported as a module's default; since that can't be determined without the
+// parent Node, use Validate to check declarations in that position.
+func (cd ClassDeclaration) Errors() []error {
+	return cd.errors(true)
+}
+
+func (cd ClassDeclaration) contextErrors(ctx validationContext) []error {
+	_, isDefault := parentNode(ctx.ancestors).(ExportDefaultDeclaration)
+	return cd.errors(!isDefault)
+}
+
+func (cd ClassDeclaration) errors(requireID bool) []error {
+	c := nodeChecker{Node: cd}
+	c.checkDecorators(cd.Decorators)
+	if requireID {
+		c.require(cd.ID, "class name")
+	} else {
+		c.optional(cd.ID)
+	}
+	c.optional(cd.TypeParameters)
+	c.optional(cd.SuperClass)
+	c.checkHeritage(cd.SuperClass, cd.SuperTypeArguments, cd.Implements)
+	c.require(cd.Body, "class body")
+	return c.errors()
+}
+
+func (cd ClassDeclaration) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(cd)
+	if cd.ID.IsZero() {
+		x["id"] = nil
+	} else {
+		x["id"] = cd.ID
+	}
+	x["superClass"] = cd.SuperClass
+	x["body"] = cd.Body
+	if len(cd.Decorators) > 0 {
+		x["decorators"] = cd.Decorators
+	}
+	if cd.TypeParameters != nil {
+		x["typeParameters"] = cd.TypeParameters
+	}
+	if cd.SuperTypeArguments != nil {
+		x["superTypeArguments"] = cd.SuperTypeArguments
+	}
+	if len(cd.Implements) > 0 {
+		x["implements"] = cd.Implements
+	}
+	if cd.Abstract {
+		x["abstract"] = true
+	}
+	if cd.Declare {
+		x["declare"] = true
+	}
+	return json.Marshal(x)
+}
+
+func (cd *ClassDeclaration) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type       string          `json:"type"`
+		ID         Identifier      `json:"id"`
+		SuperClass json.RawMessage `json:"superClass"`
+		Body       ClassBody       `json:"body"`
+		Decorators []Decorator     `json:"decorators"`
+
+		TypeParameters      json.RawMessage   `json:"typeParameters"`
+		SuperTypeArguments  json.RawMessage   `json:"superTypeArguments"`
+		SuperTypeParameters json.RawMessage   `json:"superTypeParameters"`
+		Implements          []json.RawMessage `json:"implements"`
+		Abstract            bool              `json:"abstract"`
+		Declare             bool              `json:"declare"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != cd.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, cd.Type(), x.Type)
+	}
+	if err == nil {
+		cd.Loc, cd.ID, cd.Body = x.Location(), x.ID, x.Body
+		cd.Abstract, cd.Declare = x.Abstract, x.Declare
+		cd.Decorators = nonEmptyDecorators(x.Decorators)
+		cd.SuperClass, _, err = unmarshalExpression(x.SuperClass)
+		var err2 error
+		if cd.TypeParameters, err2 = unmarshalTypeParameterDeclaration(x.TypeParameters); err == nil && err2 != nil {
+			err = err2
+		}
+		if cd.SuperTypeArguments, err2 = unmarshalTypeArguments(x.SuperTypeArguments, x.SuperTypeParameters); err == nil && err2 != nil {
+			err = err2
+		}
+		if cd.Implements, err2 = unmarshalImplements(x.Implements); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// ClassExpression is a class expression.
+type ClassExpression struct {
+	baseExpression
+	Loc        SourceLocation
+	ID         Identifier // possibly zero
+	SuperClass Expression // or nil
+	Body       ClassBody
+	Decorators []Decorator
+
+	TypeParameters     TypeParameterDeclaration   // or nil
+	SuperTypeArguments TypeParameterInstantiation // or nil
+	Implements         []ClassImplements
+}
+
+func (ClassExpression) Type() string                { return "ClassExpression" }
+func (ce ClassExpression) Location() SourceLocation { return ce.Loc }
+func (ClassExpression) MinVersion() Version         { return ES2015 }
+
+func (ce ClassExpression) IsZero() bool {
+	return ce.Loc.IsZero() &&
+		ce.ID.IsZero() &&
+		(ce.SuperClass == nil || ce.SuperClass.IsZero()) &&
+		len(ce.Body.Body) == 0 &&
+		len(ce.Decorators) == 0 &&
+		ce.TypeParameters == nil &&
+		ce.SuperTypeArguments == nil &&
+		len(ce.Implements) == 0
+}
+
+func (ce ClassExpression) Walk(v Visitor) {
+	if v = v.Visit(ce); v != nil {
+		defer v.Visit(nil)
+		for _, d := range ce.Decorators {
+			d.Walk(v)
+		}
+		if !ce.ID.IsZero() {
+			ce.ID.Walk(v)
+		}
+		if ce.TypeParameters != nil {
+			ce.TypeParameters.Walk(v)
+		}
+		if ce.SuperClass != nil {
+			ce.SuperClass.Walk(v)
+		}
+		if ce.SuperTypeArguments != nil {
+			ce.SuperTypeArguments.Walk(v)
+		}
+		for _, ci := range ce.Implements {
+			if ci != nil {
+				ci.Walk(v)
+			}
+		}
+		ce.Body.Walk(v)
+	}
+}
+
+func (ce ClassExpression) Errors() []error {
+	c := nodeChecker{Node: ce}
+	c.checkDecorators(ce.Decorators)
+	c.optional(ce.ID)
+	c.optional(ce.TypeParameters)
+	c.optional(ce.SuperClass)
+	c.checkHeritage(ce.SuperClass, ce.SuperTypeArguments, ce.Implements)
+	c.require(ce.Body, "class body")
+	return c.errors()
+}
+
+func (ce ClassExpression) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(ce)
+	if ce.ID.IsZero() {
+		x["id"] = nil
+	} else {
+		x["id"] = ce.ID
+	}
+	x["superClass"] = ce.SuperClass
+	x["body"] = ce.Body
+	if len(ce.Decorators) > 0 {
+		x["decorators"] = ce.Decorators
+	}
+	if ce.TypeParameters != nil {
+		x["typeParameters"] = ce.TypeParameters
+	}
+	if ce.SuperTypeArguments != nil {
+		x["superTypeArguments"] = ce.SuperTypeArguments
+	}
+	if len(ce.Implements) > 0 {
+		x["implements"] = ce.Implements
+	}
+	return json.Marshal(x)
+}
+
+func (ce *ClassExpression) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type       string          `json:"type"`
+		ID         Identifier      `json:"id"`
+		SuperClass json.RawMessage `json:"superClass"`
+		Body       ClassBody       `json:"body"`
+		Decorators []Decorator     `json:"decorators"`
+
+		TypeParameters      json.RawMessage   `json:"typeParameters"`
+		SuperTypeArguments  json.RawMessage   `json:"superTypeArguments"`
+		SuperTypeParameters json.RawMessage   `json:"superTypeParameters"`
+		Implements          []json.RawMessage `json:"implements"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ce.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ce.Type(), x.Type)
+	}
+	if err == nil {
+		ce.Loc, ce.ID, ce.Body = x.Location(), x.ID, x.Body
+		ce.Decorators = nonEmptyDecorators(x.Decorators)
+		ce.SuperClass, _, err = unmarshalExpression(x.SuperClass)
+		var err2 error
+		if ce.TypeParameters, err2 = unmarshalTypeParameterDeclaration(x.TypeParameters); err == nil && err2 != nil {
+			err = err2
+		}
+		if ce.SuperTypeArguments, err2 = unmarshalTypeArguments(x.SuperTypeArguments, x.SuperTypeParameters); err == nil && err2 != nil {
+			err = err2
+		}
+		if ce.Implements, err2 = unmarshalImplements(x.Implements); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// checkHeritage checks the superclass type arguments and implements clause
+// of a class.
+func (c *nodeChecker) checkHeritage(superClass Expression, superTypeArguments TypeParameterInstantiation, implements []ClassImplements) {
+	c.optional(superTypeArguments)
+	if superTypeArguments != nil && superClass == nil {
+		c.appendf("superclass type arguments without superclass %w", ErrNotAllowed)
+	}
+	c.requireEach(nodeSlice{
+		Index: func(i int) Node { return implements[i] },
+		Len:   len(implements),
+	}, "implemented interface")
+}
+
+// Decorator is a decorator applied to a class or class element, e.g. @foo or
+// @foo.bar(baz).  Decorators are a proposal, not yet part of the standard.
+//
+// Decorators are not part of ESTree, but are produced by Babel and
+// typescript-estree, in the Decorators field of ClassDeclaration,
+// ClassExpression, MethodDefinition, and PropertyDefinition.  Since a Visitor
+// must see a Node before its children, Walk visits the Decorators immediately
+// after the decorated Node, ahead of all its other children, matching their
+// position in the source.
+type Decorator struct {
+	Loc        SourceLocation
+	Expression Expression
+}
+
+func (Decorator) Type() string               { return "Decorator" }
+func (d Decorator) Location() SourceLocation { return d.Loc }
+func (Decorator) MinVersion() Version        { return Proposal }
+
+func (d Decorator) IsZero() bool {
+	return d.Loc.IsZero() && (d.Expression == nil || d.Expression.IsZero())
+}
+
+func (d Decorator) Walk(v Visitor) {
+	if v = v.Visit(d); v != nil {
+		defer v.Visit(nil)
+		if d.Expression != nil {
+			d.Expression.Walk(v)
+		}
+	}
+}
+
+// Errors checks the Decorator.  The grammar only allows a dotted name (@a.b),
+// optionally followed by a single call (@a.b(c)), or a parenthesized
+// expression; the latter is only accepted when represented by a
+// ParenthesizedExpression.
+func (d Decorator) Errors() []error {
+	c := nodeChecker{Node: d}
+	c.require(d.Expression, "decorator expression")
+	switch e := d.Expression.(type) {
+	case nil, ParenthesizedExpression:
+	case CallExpression:
+		if e.Optional {
+			c.appendf("optional call in decorator %w", ErrNotAllowed)
+		} else if e.Callee != nil && !isDecoratorMemberExpression(e.Callee) {
+			c.appendf("%s callee in decorator %w", e.Callee.Type(), ErrNotAllowed)
+		}
+	default:
+		if !isDecoratorMemberExpression(e) {
+			c.appendf("%s in decorator %w", e.Type(), ErrNotAllowed)
+		}
+	}
+	return c.errors()
+}
+
+// isDecoratorMemberExpression indicates e is an Identifier, or a chain of
+// non-computed, non-optional MemberExpressions starting with an Identifier.
+func isDecoratorMemberExpression(e ExpressionOrSuper) bool {
+	switch e := e.(type) {
+	case Identifier:
+		return true
+	case MemberExpression:
+		return !e.Computed && !e.Optional && isDecoratorMemberExpression(e.Object)
+	}
+	return false
+}
+
+func (d Decorator) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(d)
+	x["expression"] = d.Expression
+	return json.Marshal(x)
+}
+
+func (d *Decorator) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type       string          `json:"type"`
+		Expression json.RawMessage `json:"expression"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != d.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, d.Type(), x.Type)
+	}
+	if err == nil {
+		d.Loc = x.Location()
+		d.Expression, _, err = unmarshalExpression(x.Expression)
+	}
+	return err
+}
+
+// checkDecorators checks the Decorators of a class or class element.
+func (c *nodeChecker) checkDecorators(ds []Decorator) {
+	c.requireEach(nodeSlice{
+		Index: func(i int) Node { return ds[i] },
+		Len:   len(ds),
+	}, "decorator")
+}
+
+// nonEmptyDecorators returns ds, or nil if ds is empty, so that decoding an
+// empty decorators array yields the same Node as omitting it.
+func nonEmptyDecorators(ds []Decorator) []Decorator {
+	if len(ds) == 0 {
+		return nil
+	}
+	return ds
+}
+
+// ClassElement is a member of a ClassBody.
+type ClassElement interface {
+	Node
+	isClassElement()
+}
+
+func (MethodDefinition) isClassElement()   {}
+func (PropertyDefinition) isClassElement() {}
+func (StaticBlock) isClassElement()        {}
+
+func unmarshalClassElement(m json.RawMessage) (ce ClassElement, err error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err = json.Unmarshal(m, &x); err == nil {
+		switch x.Type {
+		case MethodDefinition{}.Type():
+			var md MethodDefinition
+			err, ce = json.Unmarshal(m, &md), md
+		case PropertyDefinition{}.Type():
+			var pd PropertyDefinition
+			err, ce = json.Unmarshal(m, &pd), pd
+		case StaticBlock{}.Type():
+			var sb StaticBlock
+			err, ce = json.Unmarshal(m, &sb), sb
+		default:
+			if unmarshal, ok := extensionClassElements[x.Type]; ok {
+				ce, err = unmarshal(m)
+			} else {
+				err = fmt.Errorf("%w ClassElement, got %v", ErrWrongType, string(m))
+			}
+		}
+		if err != nil {
+			ce = nil
+		}
+	}
+	return
+}
+
+// privateName returns the name of the PrivateIdentifier declared by ce, or
+// the empty string if ce does not declare a private name.
+func privateName(ce ClassElement) string {
+	var key ExpressionOrPrivateIdentifier
+	switch e := ce.(type) {
+	case MethodDefinition:
+		key = e.Key
+	case PropertyDefinition:
+		key = e.Key
+	}
+	if pi, ok := key.(PrivateIdentifier); ok {
+		return pi.Name
+	}
+	return ""
+}
+
+// ClassBody is the body of a class, containing its methods, fields, and
+// static initialization blocks.
+type ClassBody struct {
+	Loc  SourceLocation
+	Body []ClassElement
+}
+
+func (ClassBody) Type() string                { return "ClassBody" }
+func (cb ClassBody) Location() SourceLocation { return cb.Loc }
+func (ClassBody) MinVersion() Version         { return ES2015 }
+func (ClassBody) IsZero() bool                { return false }
+
+func (cb ClassBody) Walk(v Visitor) {
+	if v = v.Visit(cb); v != nil {
+		defer v.Visit(nil)
+		for _, ce := range cb.Body {
+			ce.Walk(v)
+		}
+	}
+}
+
+func (cb ClassBody) Errors() []error {
+	c := nodeChecker{Node: cb}
+	c.requireEach(nodeSlice{
+		Index: func(i int) Node { return cb.Body[i] },
+		Len:   len(cb.Body),
+	}, "class element")
+	constructors := 0
+	private := make(map[string]privateDeclaration)
+	for _, ce := range cb.Body {
+		var kind MethodDefinitionKind
+		var static bool
+		switch e := ce.(type) {
+		case MethodDefinition:
+			kind, static = e.Kind, e.Static
+		case PropertyDefinition:
+			static = e.Static
+		}
+		if kind == Constructor {
+			if constructors++; constructors == 2 {
+				c.appendf("duplicate constructor %w", ErrNotAllowed)
+			}
+		}
+		name := privateName(ce)
+		if name == "" {
+			continue
+		}
+		// A private getter and setter may share the same name, as long as
+		// both or neither are static.
+		prev, dup := private[name]
+		pair := (kind == Getter && prev.setter && !prev.getter) ||
+			(kind == Setter && prev.getter && !prev.setter)
+		if dup && !pair {
+			c.appendf("duplicate private name #%s %w", name, ErrNotAllowed)
+		} else if dup && prev.static != static {
+			c.appendf("static and non-static private accessors #%s %w", name, ErrNotAllowed)
+		}
+		private[name] = privateDeclaration{
+			getter: prev.getter || kind == Getter,
+			setter: prev.setter || kind == Setter,
+			static: static,
+		}
+	}
+	return c.errors()
+}
+
+// privateDeclaration records the declarations of a private name seen by
+// ClassBody.Errors.
+type privateDeclaration struct {
+	getter, setter bool
+	static         bool
+}
+
+func (cb ClassBody) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(cb)
+	x["body"] = cb.Body
+	return json.Marshal(x)
+}
+
+func (cb *ClassBody) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type string            `json:"type"`
+		Body []json.RawMessage `json:"body"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != cb.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, cb.Type(), x.Type)
+	}
+	if err == nil {
+		cb.Loc = x.Location()
+		if len(x.Body) == 0 {
+			cb.Body = nil
+		} else {
+			cb.Body = make([]ClassElement, len(x.Body))
+			for i := range x.Body {
+				var err2 error
+				cb.Body[i], err2 = unmarshalClassElement(x.Body[i])
+				if err == nil && err2 != nil {
+					err = err2
+				}
+			}
+		}
+	}
+	return err
+}
+
+// MethodDefinitionKind is a value for MethodDefinition.Kind, indicating
+// whether the method is the class constructor, an ordinary method, a getter,
+// or a setter.
+type MethodDefinitionKind string
+
+var (
+	Constructor MethodDefinitionKind = "constructor"
+	Method      MethodDefinitionKind = "method"
+	Getter      MethodDefinitionKind = "get"
+	Setter      MethodDefinitionKind = "set"
+)
+
+func (mdk MethodDefinitionKind) GoString() string {
+	switch mdk {
+	case Constructor:
+		return "Constructor"
+	case Method:
+		return "Method"
+	case Getter:
+		return "Getter"
+	case Setter:
+		return "Setter"
+	}
+	return fmt.Sprintf("%q", mdk)
+}
+
+func (mdk MethodDefinitionKind) IsValid() bool {
+	switch mdk {
+	case Constructor, Method, Getter, Setter:
+		return true
+	}
+	return false
+}
+
+// Accessibility is a TypeScript accessibility modifier, i.e. public, private,
+// or protected.
+//
+// The Accessibility, Abstract, Declare, Definite, Optional, Override, and
+// Readonly fields of ClassDeclaration, MethodDefinition, and
+// PropertyDefinition are TypeScript modifiers, as produced by
+// typescript-estree.  Like TypeAnnotation, they are zero for plain ESTree.
+type Accessibility string
+
+var (
+	Public    Accessibility = "public"
+	Private   Accessibility = "private"
+	Protected Accessibility = "protected"
+)
+
+func (a Accessibility) GoString() string {
+	switch a {
+	case Public:
+		return "Public"
+	case Private:
+		return "Private"
+	case Protected:
+		return "Protected"
+	}
+	return fmt.Sprintf("%q", a)
+}
+
+func (a Accessibility) IsValid() bool {
+	switch a {
+	case Public, Private, Protected:
+		return true
+	}
+	return false
+}
+
+// checkAccessibility reports ErrWrongValue for a non-empty, invalid
+// Accessibility, and ErrNotAllowed for an Accessibility on a private name.
+func (c *nodeChecker) checkAccessibility(a Accessibility, key ExpressionOrPrivateIdentifier) {
+	if a == "" {
+		return
+	}
+	if !a.IsValid() {
+		c.appendf("%w Accessibility %q", ErrWrongValue, a)
+	}
+	if _, ok := key.(PrivateIdentifier); ok {
+		c.appendf("accessibility modifier on private name %w", ErrNotAllowed)
+	}
+}
+
+// MethodValue is the Value of a MethodDefinition.  This package only
+// provides FunctionExpression; extensions may provide others, e.g. a method
+// signature without a body.
+type MethodValue interface {
+	Function
+	isMethodValue()
+}
+
+func (FunctionExpression) isMethodValue() {}
+
+func unmarshalMethodValue(m json.RawMessage) (mv MethodValue, err error) {
+	if isNullOrEmptyRawMessage(m) {
+		return nil, nil
+	}
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err = json.Unmarshal(m, &x); err == nil {
+		if x.Type == (FunctionExpression{}).Type() {
+			var fe FunctionExpression
+			err, mv = json.Unmarshal(m, &fe), fe
+		} else if unmarshal, ok := extensionMethodValues[x.Type]; ok {
+			mv, err = unmarshal(m)
+		} else {
+			err = fmt.Errorf("%w FunctionExpression, got %v", ErrWrongType, string(m))
+		}
+		if err != nil {
+			mv = nil
+		}
+	}
+	return
+}
+
+// MethodDefinition is a method in a ClassBody.
+type MethodDefinition struct {
+	Loc   SourceLocation
+	Key   ExpressionOrPrivateIdentifier
+	Value MethodValue
+	Kind  MethodDefinitionKind
+
+	// Computed indicates Key is an arbitrary Expression ([key]() {...}).  If
+	// Computed is false, Key is an Identifier, Literal, or PrivateIdentifier.
+	Computed bool
+
+	// Static indicates the method belongs to the class, rather than its
+	// prototype.
+	Static bool
+
+	Decorators    []Decorator
+	Accessibility Accessibility // possibly empty
+	Optional      bool
+	Override      bool
+}
+
+func (MethodDefinition) Type() string                { return "MethodDefinition" }
+func (md MethodDefinition) Location() SourceLocation { return md.Loc }
+
+func (md MethodDefinition) MinVersion() Version {
+	if _, ok := md.Key.(PrivateIdentifier); ok {
+		return ES2022
+	}
+	return ES2015
+}
+
+func (md MethodDefinition) IsZero() bool {
+	return md.Loc.IsZero() &&
+		(md.Key == nil || md.Key.IsZero()) &&
+		(md.Value == nil || md.Value.IsZero()) &&
+		md.Kind == "" &&
+		!md.Computed &&
+		!md.Static &&
+		len(md.Decorators) == 0 &&
+		md.Accessibility == "" &&
+		!md.Optional &&
+		!md.Override
+}
+
+func (md MethodDefinition) Walk(v Visitor) {
+	if v = v.Visit(md); v != nil {
+		defer v.Visit(nil)
+		for _, d := range md.Decorators {
+			d.Walk(v)
+		}
+		if md.Key != nil {
+			md.Key.Walk(v)
+		}
+		if md.Value != nil {
+			md.Value.Walk(v)
+		}
+	}
+}
+
+func (md MethodDefinition) Errors() []error {
+	c := nodeChecker{Node: md}
+	c.checkDecorators(md.Decorators)
+	c.require(md.Key, "method name")
+	c.checkClassElementKey(md.Key, md.Computed, "method")
+	c.checkAccessibility(md.Accessibility, md.Key)
+	if !md.Kind.IsValid() {
+		c.appendf("%w MethodDefinitionKind %q", ErrWrongValue, md.Kind)
+	} else if md.Kind == Constructor && md.Static {
+		c.appendf("static constructor %w", ErrNotAllowed)
+	} else if _, ok := md.Key.(PrivateIdentifier); ok && md.Kind == Constructor {
+		c.appendf("private constructor %w", ErrNotAllowed)
+	}
+	if md.Kind != Method && md.Value != nil && (md.Value.FunctionGenerator() || md.Value.FunctionAsync()) {
+		c.appendf("generator or async %#v %w", md.Kind, ErrNotAllowed)
+	}
+	return c.errors()
+}
+
+func (md MethodDefinition) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(md)
+	x["key"] = md.Key
+	x["value"] = md.Value
+	x["kind"] = md.Kind
+	x["computed"] = md.Computed
+	x["static"] = md.Static
+	if len(md.Decorators) > 0 {
+		x["decorators"] = md.Decorators
+	}
+	if md.Accessibility != "" {
+		x["accessibility"] = md.Accessibility
+	}
+	if md.Optional {
+		x["optional"] = true
+	}
+	if md.Override {
+		x["override"] = true
+	}
+	return json.Marshal(x)
+}
+
+func (md *MethodDefinition) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type     string               `json:"type"`
+		Key      json.RawMessage      `json:"key"`
+		Value    json.RawMessage      `json:"value"`
+		Kind     MethodDefinitionKind `json:"kind"`
+		Computed bool                 `json:"computed"`
+		Static   bool                 `json:"static"`
+
+		Decorators    []Decorator   `json:"decorators"`
+		Accessibility Accessibility `json:"accessibility"`
+		Optional      bool          `json:"optional"`
+		Override      bool          `json:"override"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != md.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, md.Type(), x.Type)
+	}
+	if err == nil {
+		md.Loc, md.Computed, md.Static = x.Location(), x.Computed, x.Static
+		md.Accessibility, md.Optional, md.Override =
+			x.Accessibility, x.Optional, x.Override
+		md.Decorators = nonEmptyDecorators(x.Decorators)
+		if x.Kind.IsValid() {
+			md.Kind = x.Kind
+		} else {
+			err = fmt.Errorf("%w MethodDefinition.Kind %q", ErrWrongValue, x.Kind)
+		}
+		var err2 error
+		if md.Key, err2 = unmarshalExpressionOrPrivateIdentifier(x.Key); err == nil && err2 != nil {
+			err = err2
+		}
+		if md.Value, err2 = unmarshalMethodValue(x.Value); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// checkClassElementKey checks the Key of a MethodDefinition or
+// PropertyDefinition.
+func (c *nodeChecker) checkClassElementKey(key ExpressionOrPrivateIdentifier, computed bool, what string) {
+	switch key.(type) {
+	case nil, LiteralOrIdentifier:
+	case PrivateIdentifier:
+		if computed {
+			c.appendf("computed private %s name %w", what, ErrNotAllowed)
+		}
+	default:
+		if !computed {
+			c.appendf("%w %s name %s without Computed", ErrWrongValue, what, key.Type())
+		}
+	}
+}
+
+// PropertyDefinition is a field in a ClassBody, e.g. x = 1 or static #y.
+type PropertyDefinition struct {
+	Loc   SourceLocation
+	Key   ExpressionOrPrivateIdentifier
+	Value Expression // or nil
+
+	// Computed indicates Key is an arbitrary Expression ([key] = ...).  If
+	// Computed is false, Key is an Identifier, Literal, or PrivateIdentifier.
+	Computed bool
+
+	// Static indicates the field belongs to the class, rather than its
+	// instances.
+	Static bool
+
+	TypeAnnotation TypeAnnotation // or nil
+	Decorators     []Decorator
+	Accessibility  Accessibility // possibly empty
+	Declare        bool
+	Definite       bool
+	Optional       bool
+	Override       bool
+	Readonly       bool
+}
+
+func (PropertyDefinition) Type() string                { return "PropertyDefinition" }
+func (pd PropertyDefinition) Location() SourceLocation { return pd.Loc }
+func (PropertyDefinition) MinVersion() Version         { return ES2022 }
+
+func (pd PropertyDefinition) IsZero() bool {
+	return pd.Loc.IsZero() &&
+		(pd.Key == nil || pd.Key.IsZero()) &&
+		(pd.Value == nil || pd.Value.IsZero()) &&
+		!pd.Computed &&
+		!pd.Static &&
+		pd.TypeAnnotation == nil &&
+		len(pd.Decorators) == 0 &&
+		pd.Accessibility == "" &&
+		!pd.Declare &&
+		!pd.Definite &&
+		!pd.Optional &&
+		!pd.Override &&
+		!pd.Readonly
+}
+
+func (pd PropertyDefinition) Walk(v Visitor) {
+	if v = v.Visit(pd); v != nil {
+		defer v.Visit(nil)
+		for _, d := range pd.Decorators {
+			d.Walk(v)
+		}
+		if pd.Key != nil {
+			pd.Key.Walk(v)
+		}
+		if pd.TypeAnnotation != nil {
+			pd.TypeAnnotation.Walk(v)
+		}
+		if pd.Value != nil {
+			pd.Value.Walk(v)
+		}
+	}
+}
+
+func (pd PropertyDefinition) Errors() []error {
+	c := nodeChecker{Node: pd}
+	c.checkDecorators(pd.Decorators)
+	c.require(pd.Key, "field name")
+	c.checkClassElementKey(pd.Key, pd.Computed, "field")
+	c.checkAccessibility(pd.Accessibility, pd.Key)
+	if pd.Declare && pd.Value != nil {
+		c.appendf("initializer of declare field %w", ErrNotAllowed)
+	}
+	if pd.Definite && pd.Optional {
+		c.appendf("definite assertion on optional field %w", ErrNotAllowed)
+	}
+	if !pd.Computed {
+		var name string
+		switch k := pd.Key.(type) {
+		case Identifier:
+			name = k.Name
+		case StringLiteral:
+			name = k.Value
+		}
+		if name == "constructor" || (pd.Static && name == "prototype") {
+			c.appendf("field named %q %w", name, ErrNotAllowed)
+		}
+	}
+	c.optional(pd.TypeAnnotation)
+	c.optional(pd.Value)
+	return c.errors()
+}
+
+func (pd PropertyDefinition) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(pd)
+	x["key"] = pd.Key
+	x["value"] = pd.Value
+	x["computed"] = pd.Computed
+	x["static"] = pd.Static
+	if pd.TypeAnnotation != nil {
+		x["typeAnnotation"] = pd.TypeAnnotation
+	}
+	if len(pd.Decorators) > 0 {
+		x["decorators"] = pd.Decorators
+	}
+	if pd.Accessibility != "" {
+		x["accessibility"] = pd.Accessibility
+	}
+	if pd.Declare {
+		x["declare"] = true
+	}
+	if pd.Definite {
+		x["definite"] = true
+	}
+	if pd.Optional {
+		x["optional"] = true
+	}
+	if pd.Override {
+		x["override"] = true
+	}
+	if pd.Readonly {
+		x["readonly"] = true
+	}
+	return json.Marshal(x)
+}
+
+func (pd *PropertyDefinition) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type     string          `json:"type"`
+		Key      json.RawMessage `json:"key"`
+		Value    json.RawMessage `json:"value"`
+		Computed bool            `json:"computed"`
+		Static   bool            `json:"static"`
+
+		TypeAnnotation json.RawMessage `json:"typeAnnotation"`
+		Decorators     []Decorator     `json:"decorators"`
+		Accessibility  Accessibility   `json:"accessibility"`
+		Declare        bool            `json:"declare"`
+		Definite       bool            `json:"definite"`
+		Optional       bool            `json:"optional"`
+		Override       bool            `json:"override"`
+		Readonly       bool            `json:"readonly"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != pd.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, pd.Type(), x.Type)
+	}
+	if err == nil {
+		pd.Loc, pd.Computed, pd.Static = x.Location(), x.Computed, x.Static
+		pd.Accessibility, pd.Declare, pd.Definite = x.Accessibility, x.Declare, x.Definite
+		pd.Optional, pd.Override, pd.Readonly = x.Optional, x.Override, x.Readonly
+		pd.Decorators = nonEmptyDecorators(x.Decorators)
+		pd.Key, err = unmarshalExpressionOrPrivateIdentifier(x.Key)
+		var err2 error
+		if pd.Value, _, err2 = unmarshalExpression(x.Value); err == nil && err2 != nil {
+			err = err2
+		}
+		if pd.TypeAnnotation, err2 = unmarshalTypeAnnotation(x.TypeAnnotation); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// StaticBlock is a static initialization block in a ClassBody, e.g.
+// static { ... }.
+type StaticBlock struct {
+	Loc  SourceLocation
+	Body []Statement
+}
+
+func (StaticBlock) Type() string                { return "StaticBlock" }
+func (sb StaticBlock) Location() SourceLocation { return sb.Loc }
+func (StaticBlock) MinVersion() Version         { return ES2022 }
+func (StaticBlock) IsZero() bool                { return false }
+
+func (sb StaticBlock) Walk(v Visitor) {
+	if v = v.Visit(sb); v != nil {
+		defer v.Visit(nil)
+		for _, s := range sb.Body {
+			s.Walk(v)
+		}
+	}
+}
+
+func (sb StaticBlock) Errors() []error {
+	c := nodeChecker{Node: sb}
+	c.requireEach(nodeSlice{
+		Index: func(i int) Node { return sb.Body[i] },
+		Len:   len(sb.Body),
+	}, "statement")
+	return c.errors()
+}
+
+func (sb StaticBlock) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(sb)
+	x["body"] = sb.Body
+	return json.Marshal(x)
+}
+
+func (sb *StaticBlock) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type string            `json:"type"`
+		Body []json.RawMessage `json:"body"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != sb.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, sb.Type(), x.Type)
+	}
+	if err == nil {
+		sb.Loc = x.Location()
+		if len(x.Body) == 0 {
+			sb.Body = nil
+		} else {
+			sb.Body = make([]Statement, len(x.Body))
+			for i := range x.Body {
+				var err2 error
+				sb.Body[i], _, err2 = unmarshalStatement(x.Body[i])
+				if err == nil && err2 != nil {
+					err = err2
+				}
+			}
+		}
+	}
+	return err
+}
+
+// ExpressionOrPrivateIdentifier is used where a Node can be either a
+// PrivateIdentifier, or any implementation of Expression.
+type ExpressionOrPrivateIdentifier interface {
+	Node
+	isExpressionOrPrivateIdentifier()
+}
+
+func unmarshalExpressionOrPrivateIdentifier(m json.RawMessage) (ExpressionOrPrivateIdentifier, error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(m, &x); err == nil && x.Type == (PrivateIdentifier{}).Type() {
+		var pi PrivateIdentifier
+		if err = json.Unmarshal(m, &pi); err != nil {
+			return nil, err
+		}
+		return pi, nil
+	}
+	e, _, err := unmarshalExpression(m)
+	if err != nil || e == nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// PrivateIdentifier is a private name, e.g. #x, which may appear as the Key
+// of a class element, the Property of a MemberExpression, or the Left operand
+// of an in BinaryExpression.  Name does not include the leading #.
+type PrivateIdentifier struct {
+	Loc  SourceLocation
+	Name string
+}
+
+func (PrivateIdentifier) Type() string                     { return "PrivateIdentifier" }
+func (pi PrivateIdentifier) Location() SourceLocation      { return pi.Loc }
+func (PrivateIdentifier) MinVersion() Version              { return ES2022 }
+func (PrivateIdentifier) isExpressionOrPrivateIdentifier() {}
+
+func (pi PrivateIdentifier) IsZero() bool {
+	return pi.Loc.IsZero() && pi.Name == ""
+}
+
+func (pi PrivateIdentifier) Walk(v Visitor) {
+	if v = v.Visit(pi); v != nil {
+		v.Visit(nil)
+	}
+}
+
+// Errors checks the PrivateIdentifier.  Every private name must be declared by
+// an enclosing class; since that can't be determined without the ancestor
+// Nodes, use Validate to check references to undeclared names.
+func (pi PrivateIdentifier) Errors() []error {
+	return pi.errors(true)
+}
+
+func (pi PrivateIdentifier) contextErrors(ctx validationContext) []error {
+	declared := false
+	for _, a := range ctx.ancestors {
+		if cb, ok := a.(ClassBody); ok {
+			for _, ce := range cb.Body {
+				declared = declared || privateName(ce) == pi.Name
+			}
+		}
+	}
+	return pi.errors(declared)
+}
+
+func (pi PrivateIdentifier) errors(declared bool) []error {
+	c := nodeChecker{Node: pi}
+	if pi.Name == "" {
+		c.appendf("%w private name", ErrMissingNode)
+	} else if pi.Name == "constructor" {
+		c.appendf("#constructor %w", ErrNotAllowed)
+	} else if !declared {
+		c.appendf("undeclared private name #%s %w", pi.Name, ErrNotAllowed)
+	}
+	return c.errors()
+}
+
+func (pi PrivateIdentifier) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(pi)
+	x["name"] = pi.Name
+	return json.Marshal(x)
+}
+
+func (pi *PrivateIdentifier) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type string `json:"type"`
+		Name string `json:"name"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != pi.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, pi.Type(), x.Type)
+	}
+	if err == nil {
+		pi.Loc, pi.Name = x.Location(), x.Name
+	}
+	return err
+}
+
+// ExpressionOrSuper is used where a Node can be either Super, or any
+// implementation of Expression.
+type ExpressionOrSuper interface {
+	Node
+	isExpressionOrSuper()
+}
+
+func unmarshalExpressionOrSuper(m json.RawMessage) (ExpressionOrSuper, error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(m, &x); err == nil && x.Type == (Super{}).Type() {
+		var s Super
+		if err = json.Unmarshal(m, &s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	e, _, err := unmarshalExpression(m)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Super is the super keyword, which may appear as the callee of a
+// CallExpression inside of a derived class's constructor, or the object of a
+// MemberExpression inside of a method.
+type Super struct {
+	Loc SourceLocation
+}
+
+func (Super) Type() string               { return "Super" }
+func (s Super) Location() SourceLocation { return s.Loc }
+func (Super) MinVersion() Version        { return ES2015 }
+func (Super) IsZero() bool               { return false }
+func (Super) Errors() []error            { return nil }
+func (Super) isExpressionOrSuper()       {}
+
+func (s Super) contextErrors(ctx validationContext) []error {
+	c := nodeChecker{Node: s}
+	_, isCall := parentNode(ctx.ancestors).(CallExpression)
+	md, class := enclosingMethod(ctx.ancestors)
+	if isCall {
+		if md.Kind != Constructor || class == nil || class.ClassSuperClass() == nil {
+			c.appendf("super call outside of derived class constructor %w", ErrNotAllowed)
+		}
+	} else if class == nil && !enclosingObjectMethod(ctx.ancestors) {
+		c.appendf("super outside of method %w", ErrNotAllowed)
+	}
+	return c.errors()
+}
+
+// enclosingMethod returns the MethodDefinition of the innermost non-arrow
+// function containing the current Node, and the Class it belongs to.  If the
+// innermost function is not a method, the Class is nil.  Within a field
+// initializer or static block, the MethodDefinition is zero but the Class is
+// returned.
+func enclosingMethod(ancestors []Node) (MethodDefinition, Class) {
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		switch ancestors[i].(type) {
+		case ArrowFunctionExpression:
+			// Arrow functions inherit super from their enclosing scope.
+		case PropertyDefinition, StaticBlock:
+			if i >= 2 {
+				if class, isClass := ancestors[i-2].(Class); isClass {
+					return MethodDefinition{}, class
+				}
+			}
+			return MethodDefinition{}, nil
+		case FunctionExpression:
+			if i >= 3 {
+				md, isMethod := ancestors[i-1].(MethodDefinition)
+				class, isClass := ancestors[i-3].(Class)
+				if isMethod && isClass {
+					return md, class
+				}
+			}
+			return MethodDefinition{}, nil
+		case Function:
+			return MethodDefinition{}, nil
+		}
+	}
+	return MethodDefinition{}, nil
+}
+
+// enclosingObjectMethod indicates whether the innermost non-arrow function
+// containing the current Node is a method, getter, or setter of an
+// ObjectExpression.
+func enclosingObjectMethod(ancestors []Node) bool {
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		switch ancestors[i].(type) {
+		case ArrowFunctionExpression:
+			// Arrow functions inherit super from their enclosing scope.
+		case FunctionExpression:
+			if i >= 1 {
+				p, isProperty := ancestors[i-1].(Property)
+				return isProperty && (p.Method || p.Kind == Get || p.Kind == Set)
+			}
+			return false
+		case Function, PropertyDefinition, StaticBlock:
+			return false
+		}
+	}
+	return false
+}
+
+func (s Super) Walk(v Visitor) {
+	if v = v.Visit(s); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (s Super) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nodeToMap(s))
+}
+
+func (s *Super) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != s.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, s.Type(), x.Type)
+	}
+	if err == nil {
+		s.Loc = x.Location()
+	}
+	return err
+}
@@ -0,0 +1,661 @@
+package estree
+
+import (
+	"testing"
+)
+
+func TestClassDeclaration(t *testing.T) {
+	var cd ClassDeclaration
+	if !cd.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	cd.ID = Identifier{Name: "Foo"}
+	cd.SuperClass = Identifier{Name: "Bar"}
+	cd.Body.Body = []ClassElement{
+		MethodDefinition{
+			Key:   Identifier{Name: "constructor"},
+			Value: FunctionExpression{},
+			Kind:  Constructor,
+		},
+	}
+	if cd.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if cd.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", cd.MinVersion())
+	}
+
+	var v mockVisitor
+	cd.Walk(&v)
+	v.expect(t, cd,
+		cd.ID, nil,
+		cd.SuperClass, nil,
+		cd.Body,
+		cd.Body.Body[0],
+		cd.Body.Body[0].(MethodDefinition).Key, nil,
+		cd.Body.Body[0].(MethodDefinition).Value,
+		cd.Body.Body[0].(MethodDefinition).Value.(FunctionExpression).Body, nil, nil, nil, nil, nil)
+
+	testRoundtripJSON(t, cd, new(ClassDeclaration))
+
+	if errs := cd.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	cd.Abstract, cd.Declare = true, true
+	testRoundtripJSON(t, cd, new(ClassDeclaration))
+	cd.ID = Identifier{}
+	if !hasError(ErrMissingNode, cd.Errors()...) {
+		t.Error("expected ErrMissingNode for zero ID")
+	}
+}
+
+func TestClassExpression(t *testing.T) {
+	var ce ClassExpression
+	if !ce.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	ce.Body.Body = []ClassElement{
+		MethodDefinition{
+			Key:    Identifier{Name: "foo"},
+			Value:  FunctionExpression{},
+			Kind:   Method,
+			Static: true,
+		},
+	}
+	if ce.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if ce.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", ce.MinVersion())
+	}
+
+	var v mockVisitor
+	ce.Walk(&v)
+	v.expect(t, ce,
+		ce.Body,
+		ce.Body.Body[0],
+		ce.Body.Body[0].(MethodDefinition).Key, nil,
+		ce.Body.Body[0].(MethodDefinition).Value,
+		ce.Body.Body[0].(MethodDefinition).Value.(FunctionExpression).Body, nil, nil, nil, nil, nil)
+
+	testRoundtripJSON(t, ce, new(ClassExpression))
+
+	if errs := ce.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestClassBody(t *testing.T) {
+	var cb ClassBody
+	if cb.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	cb.Body = []ClassElement{
+		MethodDefinition{
+			Key:   Identifier{Name: "constructor"},
+			Value: FunctionExpression{},
+			Kind:  Constructor,
+		},
+		MethodDefinition{
+			Key:   StringLiteral{Value: "foo"},
+			Value: FunctionExpression{},
+			Kind:  Getter,
+		},
+	}
+	if cb.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", cb.MinVersion())
+	}
+
+	testRoundtripJSON(t, cb, new(ClassBody))
+
+	if errs := cb.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	cb.Body[1] = MethodDefinition{
+		Key:   StringLiteral{Value: "foo"},
+		Value: FunctionExpression{},
+		Kind:  Constructor,
+	}
+	if !hasError(ErrNotAllowed, cb.Errors()...) {
+		t.Error("expected ErrNotAllowed for duplicate constructor")
+	}
+	cb.Body[1] = MethodDefinition{}
+	if !hasError(ErrMissingNode, cb.Errors()...) {
+		t.Error("expected ErrMissingNode for zero MethodDefinition")
+	}
+}
+
+func TestMethodDefinition(t *testing.T) {
+	var md MethodDefinition
+	if !md.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	md.Key = BinaryExpression{
+		Operator: Add,
+		Left:     StringLiteral{Value: "foo"},
+		Right:    StringLiteral{Value: "bar"},
+	}
+	md.Computed = true
+	md.Kind = Setter
+	fe := FunctionExpression{
+		Params: []Pattern{
+			Identifier{Name: "baz"},
+		},
+	}
+	md.Value = fe
+	if md.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if md.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", md.MinVersion())
+	}
+
+	var v mockVisitor
+	md.Walk(&v)
+	v.expect(t, md,
+		md.Key,
+		md.Key.(BinaryExpression).Left, nil,
+		md.Key.(BinaryExpression).Right, nil, nil,
+		md.Value,
+		fe.Params[0], nil,
+		fe.Body, nil, nil, nil)
+
+	testRoundtripJSON(t, md, new(MethodDefinition))
+
+	if errs := md.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	md.Computed = false
+	if !hasError(ErrWrongValue, md.Errors()...) {
+		t.Error("expected ErrWrongValue for Expression Key without Computed")
+	}
+	md.Key = Identifier{Name: "constructor"}
+	md.Kind = "not valid"
+	if !hasError(ErrWrongValue, md.Errors()...) {
+		t.Error("expected ErrWrongValue for invalid Kind")
+	}
+	md.Kind = Constructor
+	md.Static = true
+	if !hasError(ErrNotAllowed, md.Errors()...) {
+		t.Error("expected ErrNotAllowed for static constructor")
+	}
+	md.Static = false
+	fe.Async = true
+	md.Value = fe
+	if !hasError(ErrNotAllowed, md.Errors()...) {
+		t.Error("expected ErrNotAllowed for async constructor")
+	}
+	fe.Async = false
+	md.Value = fe
+	md.Accessibility, md.Override = Protected, true
+	testRoundtripJSON(t, md, new(MethodDefinition))
+	if errs := md.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	md.Accessibility = "internal"
+	if !hasError(ErrWrongValue, md.Errors()...) {
+		t.Error("expected ErrWrongValue for invalid Accessibility")
+	}
+	md.Key = nil
+	if !hasError(ErrMissingNode, md.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Key")
+	}
+}
+
+func TestSuper(t *testing.T) {
+	var s Super
+	if s.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if s.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", s.MinVersion())
+	}
+
+	var v mockVisitor
+	s.Walk(&v)
+	v.expect(t, s, nil)
+
+	testRoundtripJSON(t, s, new(Super))
+
+	if errs := s.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	superCall := FunctionExpression{
+		Body: FunctionBody{
+			Body: []DirectiveOrStatement{
+				ExpressionStatement{
+					Expression: CallExpression{Callee: s},
+				},
+			},
+		},
+	}
+	cd := ClassDeclaration{
+		ID:         Identifier{Name: "Foo"},
+		SuperClass: Identifier{Name: "Bar"},
+		Body: ClassBody{
+			Body: []ClassElement{
+				MethodDefinition{
+					Key:   Identifier{Name: "constructor"},
+					Value: superCall,
+					Kind:  Constructor,
+				},
+			},
+		},
+	}
+	testRoundtripJSON(t, cd, new(ClassDeclaration))
+	if errs := Validate(cd); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	md := cd.Body.Body[0].(MethodDefinition)
+	md.Key = Identifier{Name: "foo"}
+	md.Kind = Method
+	cd.Body.Body[0] = md
+	if !hasError(ErrNotAllowed, Validate(cd)...) {
+		t.Error("expected ErrNotAllowed for super call outside of constructor")
+	}
+	md.Value.(FunctionExpression).Body.Body[0] = ExpressionStatement{
+		Expression: MemberExpression{
+			Object:   s,
+			Property: Identifier{Name: "foo"},
+		},
+	}
+	if errs := Validate(cd); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !hasError(ErrNotAllowed, Validate(superCall)...) {
+		t.Error("expected ErrNotAllowed for super outside of method")
+	}
+}
+
+func TestPropertyDefinition(t *testing.T) {
+	var pd PropertyDefinition
+	if !pd.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	pd.Key = Identifier{Name: "foo"}
+	pd.Value = NumberLiteral{Value: 1}
+	if pd.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if pd.MinVersion() != ES2022 {
+		t.Errorf("expected ES2022, got %s", pd.MinVersion())
+	}
+
+	var v mockVisitor
+	pd.Walk(&v)
+	v.expect(t, pd, pd.Key, nil, pd.Value, nil, nil)
+
+	testRoundtripJSON(t, pd, new(PropertyDefinition))
+
+	if errs := pd.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	pd.Value = nil
+	testRoundtripJSON(t, pd, new(PropertyDefinition))
+	if errs := pd.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	pd.Key = Identifier{Name: "constructor"}
+	if !hasError(ErrNotAllowed, pd.Errors()...) {
+		t.Error("expected ErrNotAllowed for field named constructor")
+	}
+	pd.Key, pd.Static = StringLiteral{Value: "prototype"}, true
+	if !hasError(ErrNotAllowed, pd.Errors()...) {
+		t.Error("expected ErrNotAllowed for static field named prototype")
+	}
+	pd.Computed = true
+	if errs := pd.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	pd.Key = PrivateIdentifier{Name: "foo"}
+	if !hasError(ErrNotAllowed, pd.Errors()...) {
+		t.Error("expected ErrNotAllowed for computed PrivateIdentifier")
+	}
+	pd.Key = nil
+	if !hasError(ErrMissingNode, pd.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Key")
+	}
+
+	// private readonly foo!: number
+	pd = PropertyDefinition{
+		Key:           Identifier{Name: "foo"},
+		Accessibility: Private,
+		Readonly:      true,
+		Definite:      true,
+	}
+	testRoundtripJSON(t, pd, new(PropertyDefinition))
+	if errs := pd.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	pd.Optional = true
+	if !hasError(ErrNotAllowed, pd.Errors()...) {
+		t.Error("expected ErrNotAllowed for definite optional field")
+	}
+	pd.Optional = false
+	pd.Declare, pd.Value = true, NumberLiteral{Value: 1}
+	if !hasError(ErrNotAllowed, pd.Errors()...) {
+		t.Error("expected ErrNotAllowed for declare field with initializer")
+	}
+	pd.Declare, pd.Value = false, nil
+	pd.Key = PrivateIdentifier{Name: "foo"}
+	if !hasError(ErrNotAllowed, pd.Errors()...) {
+		t.Error("expected ErrNotAllowed for accessibility of private name")
+	}
+}
+
+func TestStaticBlock(t *testing.T) {
+	var sb StaticBlock
+	if sb.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	sb.Body = []Statement{
+		ExpressionStatement{
+			Expression: AwaitExpression{Argument: Identifier{Name: "foo"}},
+		},
+	}
+	if sb.MinVersion() != ES2022 {
+		t.Errorf("expected ES2022, got %s", sb.MinVersion())
+	}
+
+	var v mockVisitor
+	sb.Walk(&v)
+	v.expect(t, sb,
+		sb.Body[0],
+		sb.Body[0].(ExpressionStatement).Expression,
+		sb.Body[0].(ExpressionStatement).Expression.(AwaitExpression).Argument,
+		nil, nil, nil, nil)
+
+	testRoundtripJSON(t, sb, new(StaticBlock))
+
+	if errs := sb.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	p := Program{
+		SourceType: Module,
+		Body: []DirectiveOrStatement{
+			ClassDeclaration{
+				ID:   Identifier{Name: "Foo"},
+				Body: ClassBody{Body: []ClassElement{sb}},
+			},
+		},
+	}
+	if !hasError(ErrNotAllowed, Validate(p)...) {
+		t.Error("expected ErrNotAllowed for await in static block")
+	}
+	sb.Body[0] = ExpressionStatement{
+		Expression: MemberExpression{
+			Object:   Super{},
+			Property: Identifier{Name: "foo"},
+		},
+	}
+	if errs := Validate(p); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	sb.Body[0] = nil
+	if !hasError(ErrMissingNode, sb.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Body")
+	}
+}
+
+func TestPrivateIdentifier(t *testing.T) {
+	var pi PrivateIdentifier
+	if !pi.IsZero() {
+		t.Error("expected IsZero()")
+	}
+	if !hasError(ErrMissingNode, pi.Errors()...) {
+		t.Error("expected ErrMissingNode for empty Name")
+	}
+
+	pi.Name = "foo"
+	if pi.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if pi.MinVersion() != ES2022 {
+		t.Errorf("expected ES2022, got %s", pi.MinVersion())
+	}
+
+	var v mockVisitor
+	pi.Walk(&v)
+	v.expect(t, pi, nil)
+
+	testRoundtripJSON(t, pi, new(PrivateIdentifier))
+
+	if errs := pi.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !hasError(ErrNotAllowed, Validate(pi)...) {
+		t.Error("expected ErrNotAllowed for undeclared private name")
+	}
+
+	// class Foo { #foo; static check(o) { return #foo in o && o.#foo; } }
+	check := BinaryExpression{
+		Operator: In,
+		Left:     pi,
+		Right:    Identifier{Name: "o"},
+	}
+	access := MemberExpression{
+		Object:   Identifier{Name: "o"},
+		Property: pi,
+	}
+	if check.MinVersion() != ES2022 {
+		t.Errorf("expected ES2022, got %s", check.MinVersion())
+	}
+	if access.MinVersion() != ES2022 {
+		t.Errorf("expected ES2022, got %s", access.MinVersion())
+	}
+	testRoundtripJSON(t, check, new(BinaryExpression))
+	testRoundtripJSON(t, access, new(MemberExpression))
+	cd := ClassDeclaration{
+		ID: Identifier{Name: "Foo"},
+		Body: ClassBody{
+			Body: []ClassElement{
+				PropertyDefinition{Key: pi},
+				MethodDefinition{
+					Key:  Identifier{Name: "check"},
+					Kind: Method,
+					Value: FunctionExpression{
+						Params: []Pattern{Identifier{Name: "o"}},
+						Body: FunctionBody{
+							Body: []DirectiveOrStatement{
+								ReturnStatement{
+									Argument: LogicalExpression{
+										Operator: And,
+										Left:     check,
+										Right:    access,
+									},
+								},
+							},
+						},
+					},
+					Static: true,
+				},
+			},
+		},
+	}
+	testRoundtripJSON(t, cd, new(ClassDeclaration))
+	if errs := Validate(cd); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	cd.Body.Body[0] = PropertyDefinition{Key: PrivateIdentifier{Name: "bar"}}
+	if !hasError(ErrNotAllowed, Validate(cd)...) {
+		t.Error("expected ErrNotAllowed for undeclared private name")
+	}
+	cd.Body.Body[0] = MethodDefinition{
+		Key:   pi,
+		Value: FunctionExpression{},
+		Kind:  Getter,
+	}
+	cd.Body.Body = append(cd.Body.Body, MethodDefinition{
+		Key:   pi,
+		Value: FunctionExpression{},
+		Kind:  Setter,
+	})
+	if errs := Validate(cd); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	cd.Body.Body = append(cd.Body.Body, MethodDefinition{
+		Key:   pi,
+		Value: FunctionExpression{},
+		Kind:  Getter,
+	})
+	if !hasError(ErrNotAllowed, cd.Body.Errors()...) {
+		t.Error("expected ErrNotAllowed for get, set and get of the same private name")
+	}
+	cd.Body.Body = cd.Body.Body[:3]
+	cd.Body.Body[0] = MethodDefinition{
+		Key:    pi,
+		Value:  FunctionExpression{},
+		Kind:   Getter,
+		Static: true,
+	}
+	if !hasError(ErrNotAllowed, cd.Body.Errors()...) {
+		t.Error("expected ErrNotAllowed for static getter with non-static setter")
+	}
+	cd.Body.Body[0] = MethodDefinition{
+		Key:   pi,
+		Value: FunctionExpression{},
+		Kind:  Getter,
+	}
+	cd.Body.Body[2] = PropertyDefinition{Key: pi}
+	if !hasError(ErrNotAllowed, cd.Body.Errors()...) {
+		t.Error("expected ErrNotAllowed for duplicate private name")
+	}
+
+	check.Operator = InstanceOf
+	if !hasError(ErrNotAllowed, check.Errors()...) {
+		t.Error("expected ErrNotAllowed for PrivateIdentifier with instanceof")
+	}
+	access.Computed = true
+	if !hasError(ErrNotAllowed, access.Errors()...) {
+		t.Error("expected ErrNotAllowed for computed private member")
+	}
+	del := UnaryExpression{
+		Operator: Delete,
+		Prefix:   true,
+		Argument: MemberExpression{Object: ThisExpression{}, Property: pi},
+	}
+	if !hasError(ErrNotAllowed, del.Errors()...) {
+		t.Error("expected ErrNotAllowed for delete of private member")
+	}
+	pi.Name = "constructor"
+	if !hasError(ErrNotAllowed, pi.Errors()...) {
+		t.Error("expected ErrNotAllowed for #constructor")
+	}
+}
+
+func TestDecorator(t *testing.T) {
+	var d Decorator
+	if !d.IsZero() {
+		t.Error("expected IsZero()")
+	}
+	if !hasError(ErrMissingNode, d.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Expression")
+	}
+
+	// @foo.#bar(baz)
+	d.Expression = CallExpression{
+		Callee: MemberExpression{
+			Object:   Identifier{Name: "foo"},
+			Property: PrivateIdentifier{Name: "bar"},
+		},
+		Arguments: []ExpressionOrSpread{Identifier{Name: "baz"}},
+	}
+	if d.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if d.MinVersion() != Proposal || d.MinVersion() <= ES2022 {
+		t.Errorf("expected Proposal, got %s", d.MinVersion())
+	}
+
+	call := d.Expression.(CallExpression)
+	member := call.Callee.(MemberExpression)
+	var v mockVisitor
+	d.Walk(&v)
+	v.expect(t, d,
+		call, member, member.Object, nil, member.Property, nil, nil,
+		call.Arguments[0], nil, nil, nil)
+
+	testRoundtripJSON(t, d, new(Decorator))
+
+	if errs := d.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	for _, e := range []Expression{
+		Identifier{Name: "foo"},
+		ParenthesizedExpression{Expression: CallExpression{Callee: call}},
+	} {
+		if errs := (Decorator{Expression: e}).Errors(); len(errs) != 0 {
+			t.Errorf("unexpected errors for %T: %v", e, errs)
+		}
+	}
+	for _, e := range []Expression{
+		CallExpression{Callee: call},
+		CallExpression{Callee: member, Optional: true},
+		MemberExpression{Object: Identifier{Name: "foo"}, Property: Identifier{Name: "bar"}, Computed: true},
+		MemberExpression{Object: call, Property: Identifier{Name: "bar"}},
+		ArrowFunctionExpression{Body: FunctionBody{}},
+	} {
+		if !hasError(ErrNotAllowed, Decorator{Expression: e}.Errors()...) {
+			t.Errorf("expected ErrNotAllowed for %#v", e)
+		}
+	}
+}
+
+func TestDecorators(t *testing.T) {
+	// @foo class Bar { @baz qux() {} @quux x; }
+	cd := ClassDeclaration{
+		ID:         Identifier{Name: "Bar"},
+		Decorators: []Decorator{Decorator{Expression: Identifier{Name: "foo"}}},
+		Body: ClassBody{Body: []ClassElement{
+			MethodDefinition{
+				Key:        Identifier{Name: "qux"},
+				Value:      FunctionExpression{},
+				Kind:       Method,
+				Decorators: []Decorator{Decorator{Expression: Identifier{Name: "baz"}}},
+			},
+			PropertyDefinition{
+				Key:        Identifier{Name: "x"},
+				Decorators: []Decorator{Decorator{Expression: Identifier{Name: "quux"}}},
+			},
+		}},
+	}
+	if errs := Validate(cd); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	md := cd.Body.Body[0].(MethodDefinition)
+	pd := cd.Body.Body[1].(PropertyDefinition)
+	var v mockVisitor
+	cd.Walk(&v)
+	v.expect(t, cd,
+		cd.Decorators[0], cd.Decorators[0].Expression, nil, nil,
+		cd.ID, nil,
+		cd.Body,
+		md, md.Decorators[0], md.Decorators[0].Expression, nil, nil,
+		md.Key, nil, md.Value, md.Value.(FunctionExpression).Body, nil, nil, nil,
+		pd, pd.Decorators[0], pd.Decorators[0].Expression, nil, nil,
+		pd.Key, nil, nil, nil, nil)
+
+	testRoundtripJSON(t, cd, new(ClassDeclaration))
+	testRoundtripJSON(t, ClassExpression{Decorators: cd.Decorators}, new(ClassExpression))
+	if (ClassExpression{Decorators: cd.Decorators}).IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	cd.Decorators[0] = Decorator{}
+	if !hasError(ErrMissingNode, cd.Errors()...) {
+		t.Error("expected ErrMissingNode for zero decorator")
+	}
+	md.Decorators[0].Expression = ThisExpression{}
+	if !hasError(ErrNotAllowed, Validate(md)...) {
+		t.Error("expected ErrNotAllowed for this decorator")
+	}
+}
@@ -0,0 +1,267 @@
+package estree
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// CommentKind is a value for Comment.Kind, indicating whether the comment is
+// a line (// ...) or block (/* ... */) comment.
+type CommentKind string
+
+var (
+	Line  CommentKind = "Line"
+	Block CommentKind = "Block"
+)
+
+func (ck CommentKind) IsValid() bool {
+	switch ck {
+	case Line, Block:
+		return true
+	}
+	return false
+}
+
+// Comment is a source code comment, as found in Program.Comments or attached
+// to a Node via SourceLocation.Comments.  Unlike most Nodes, its Type is determined
+// by Kind.  Value does not include the comment delimiters.
+//
+// Comments are not part of ESTree, but are produced by Acorn, Espree, and
+// Babel.  Babel's CommentLine and CommentBlock types are accepted when
+// decoding.
+type Comment struct {
+	Loc   SourceLocation
+	Kind  CommentKind
+	Value string
+}
+
+func (c Comment) Type() string             { return string(c.Kind) }
+func (c Comment) Location() SourceLocation { return c.Loc }
+func (Comment) MinVersion() Version        { return ES5 }
+
+func (c Comment) IsZero() bool {
+	return c.Loc.IsZero() && c.Kind == "" && c.Value == ""
+}
+
+func (c Comment) Walk(v Visitor) {
+	if v = v.Visit(c); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (c Comment) Errors() []error {
+	ck := nodeChecker{Node: c}
+	if !c.Kind.IsValid() {
+		ck.appendf("%w CommentKind %q", ErrWrongValue, c.Kind)
+	} else if c.Kind == Line && strings.ContainsAny(c.Value, "\n\r\u2028\u2029") {
+		ck.appendf("line terminator in line comment %w", ErrNotAllowed)
+	} else if c.Kind == Block && strings.Contains(c.Value, "*/") {
+		ck.appendf("*/ in block comment %w", ErrNotAllowed)
+	}
+	return ck.errors()
+}
+
+func (c Comment) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(c)
+	x["value"] = c.Value
+	return json.Marshal(x)
+}
+
+func (c *Comment) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil {
+		switch x.Type {
+		case string(Line), "CommentLine":
+			c.Kind = Line
+		case string(Block), "CommentBlock":
+			c.Kind = Block
+		default:
+			err = fmt.Errorf("%w Comment, got %q", ErrWrongType, x.Type)
+		}
+	}
+	if err == nil {
+		c.Loc, c.Value = x.Location(), x.Value
+	}
+	return err
+}
+
+// NodeComments holds the comments attached to a Node, which precede or follow
+// it in the source.
+type NodeComments struct {
+	LeadingComments  []Comment
+	TrailingComments []Comment
+}
+
+// nonEmptyComments returns cs, or nil if cs is empty, so that decoding an
+// empty JSON array matches the zero value.
+func nonEmptyComments(cs []Comment) []Comment {
+	if len(cs) == 0 {
+		return nil
+	}
+	return cs
+}
+
+// AttachComments returns a copy of p, with each of p.Comments attached to
+// the nearest Node by location.  A comment is attached as a leading comment
+// of the first Node which follows it within the innermost enclosing Node, or
+// failing that, as a trailing comment of the last Node which precedes it.
+// Comments inside a Node with no children (such as an empty BlockStatement)
+// are left unattached.
+//
+// Nodes and comments without a location are ignored.  Comments already
+// attached to a Node are kept, so AttachComments should not be called twice on
+// the same Program.
+func AttachComments(p Program) Program {
+	attachComments(reflect.ValueOf(&p).Elem(), p.Comments)
+	return p
+}
+
+var (
+	nodeType           = reflect.TypeOf((*Node)(nil)).Elem()
+	commentType        = reflect.TypeOf(Comment{})
+	sourceLocationType = reflect.TypeOf(SourceLocation{})
+)
+
+// commentTarget is a child Node which a comment may be attached to.
+type commentTarget struct {
+	node  reflect.Value // addressable
+	loc   SourceLocation
+	store func() // copies node back into an interface, or nil
+}
+
+// attachComments attaches each of cs to a child of the Node held by v, which
+// must be addressable, or to one of its descendants.
+func attachComments(v reflect.Value, cs []Comment) {
+	var targets []commentTarget
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).PkgPath == "" { // exported
+			targets = findCommentTargets(v.Field(i), targets)
+		}
+	}
+	sort.SliceStable(targets, func(i, j int) bool {
+		return positionLess(targets[i].loc.Start, targets[j].loc.Start)
+	})
+
+	inner := make([][]Comment, len(targets))
+	leading := make([][]Comment, len(targets))
+	trailing := make([][]Comment, len(targets))
+	for _, c := range cs {
+		if c.Loc.isPositionZero() {
+			continue
+		}
+		preceding, following := -1, -1
+		for i, t := range targets {
+			if !positionLess(c.Loc.Start, t.loc.Start) && !positionLess(t.loc.End, c.Loc.End) {
+				preceding, following = -1, -1
+				inner[i] = append(inner[i], c)
+				break
+			}
+			if !positionLess(c.Loc.Start, t.loc.End) {
+				preceding = i
+			}
+			if following < 0 && !positionLess(t.loc.Start, c.Loc.End) {
+				following = i
+			}
+		}
+		if following >= 0 {
+			leading[following] = append(leading[following], c)
+		} else if preceding >= 0 {
+			trailing[preceding] = append(trailing[preceding], c)
+		}
+	}
+
+	for i, t := range targets {
+		if len(inner[i]) == 0 && len(leading[i]) == 0 && len(trailing[i]) == 0 {
+			continue
+		}
+		if len(inner[i]) > 0 {
+			attachComments(t.node, inner[i])
+		}
+		if len(leading[i]) > 0 || len(trailing[i]) > 0 {
+			// Copy, rather than modify, any existing NodeComments.
+			loc := t.node.FieldByName("Loc").Addr().Interface().(*SourceLocation)
+			var nc NodeComments
+			if loc.Comments != nil {
+				nc.LeadingComments = append(nc.LeadingComments, loc.Comments.LeadingComments...)
+				nc.TrailingComments = append(nc.TrailingComments, loc.Comments.TrailingComments...)
+			}
+			nc.LeadingComments = append(nc.LeadingComments, leading[i]...)
+			nc.TrailingComments = append(nc.TrailingComments, trailing[i]...)
+			loc.Comments = &nc
+		}
+		if t.store != nil {
+			t.store()
+		}
+	}
+}
+
+// findCommentTargets appends to targets each Node with a location reachable
+// from the addressable value v, without descending into those Nodes.  Slices
+// are copied, so that attaching comments does not modify the original tree.
+func findCommentTargets(v reflect.Value, targets []commentTarget) []commentTarget {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() || v.Elem().Kind() != reflect.Struct || !isCommentTarget(v.Elem().Type()) {
+			break
+		}
+		node := reflect.New(v.Elem().Type()).Elem()
+		node.Set(v.Elem())
+		if loc := node.FieldByName("Loc").Interface().(SourceLocation); !loc.isPositionZero() {
+			targets = append(targets, commentTarget{
+				node:  node,
+				loc:   loc,
+				store: func() { v.Set(node) },
+			})
+		}
+	case reflect.Slice:
+		if v.Len() == 0 || v.Type().Elem() == commentType {
+			break
+		}
+		s := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(s, v)
+		v.Set(s)
+		for i := 0; i < s.Len(); i++ {
+			targets = findCommentTargets(s.Index(i), targets)
+		}
+	case reflect.Struct:
+		if v.Type() == sourceLocationType || v.Type() == commentType {
+			break
+		}
+		if isCommentTarget(v.Type()) {
+			if loc := v.FieldByName("Loc").Interface().(SourceLocation); !loc.isPositionZero() {
+				targets = append(targets, commentTarget{node: v, loc: loc})
+			}
+			break
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" { // exported
+				targets = findCommentTargets(v.Field(i), targets)
+			}
+		}
+	}
+	return targets
+}
+
+// isCommentTarget indicates t is a Node with a Loc field, which comments can
+// be attached to.
+func isCommentTarget(t reflect.Type) bool {
+	if t == commentType || !t.Implements(nodeType) {
+		return false
+	}
+	f, ok := t.FieldByName("Loc")
+	return ok && f.Type == sourceLocationType
+}
+
+// positionLess indicates a is before b in the source.
+func positionLess(a, b Position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+}
@@ -0,0 +1,176 @@
+package estree
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// loc is a test helper returning a SourceLocation on a single line.
+func loc(line, start, end int) SourceLocation {
+	return SourceLocation{
+		Start: Position{Line: line, Column: start},
+		End:   Position{Line: line, Column: end},
+	}
+}
+
+func TestComment(t *testing.T) {
+	var c Comment
+	if !c.IsZero() {
+		t.Error("expected IsZero()")
+	}
+	if !hasError(ErrWrongValue, c.Errors()...) {
+		t.Error("expected ErrWrongValue for empty Kind")
+	}
+
+	c = Comment{Loc: loc(1, 0, 12), Kind: Line, Value: " license"}
+	if c.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if c.Type() != "Line" {
+		t.Errorf("expected Line, got %s", c.Type())
+	}
+	if c.MinVersion() != ES5 {
+		t.Errorf("expected ES5, got %s", c.MinVersion())
+	}
+
+	var v mockVisitor
+	c.Walk(&v)
+	v.expect(t, c, nil)
+
+	testRoundtripJSON(t, c, new(Comment))
+	testRoundtripJSON(t, Comment{Kind: Block, Value: "*\n * @ngInject\n "}, new(Comment))
+
+	if errs := c.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	c.Value = "foo\nbar"
+	if !hasError(ErrNotAllowed, c.Errors()...) {
+		t.Error("expected ErrNotAllowed for line terminator in line comment")
+	}
+	c = Comment{Kind: Block, Value: "foo */ bar"}
+	if !hasError(ErrNotAllowed, c.Errors()...) {
+		t.Error("expected ErrNotAllowed for */ in block comment")
+	}
+
+	if err := json.Unmarshal([]byte(`{"type":"CommentBlock","value":" foo "}`), &c); err != nil {
+		t.Fatal(err)
+	} else if c.Kind != Block || c.Value != " foo " {
+		t.Errorf("expected Block comment, got %#v", c)
+	}
+}
+
+func TestNodeComments(t *testing.T) {
+	// /* @ngInject */
+	// foo(); // bar
+	b := []byte(`{"type":"Program","sourceType":"script","body":[` +
+		`{"type":"ExpressionStatement","expression":{"type":"CallExpression",` +
+		`"callee":{"type":"Identifier","name":"foo"},"arguments":[],"optional":false},` +
+		`"leadingComments":[{"type":"CommentBlock","value":" @ngInject "}],` +
+		`"trailingComments":[{"type":"CommentLine","value":" bar"}]}],` +
+		`"comments":[{"type":"CommentBlock","value":" @ngInject "},{"type":"CommentLine","value":" bar"}]}`)
+	var p Program
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Comments) != 2 {
+		t.Errorf("expected 2 comments, got %v", p.Comments)
+	}
+	es := p.Body[0].(ExpressionStatement)
+	expect := &NodeComments{
+		LeadingComments:  []Comment{Comment{Kind: Block, Value: " @ngInject "}},
+		TrailingComments: []Comment{Comment{Kind: Line, Value: " bar"}},
+	}
+	if !reflect.DeepEqual(es.Loc.Comments, expect) {
+		t.Errorf("expected %#v, got %#v", expect, es.Loc.Comments)
+	}
+	if es.IsZero() || es.Loc.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	testRoundtripJSON(t, p, new(Program))
+	testRoundtripJSON(t, Identifier{
+		Name: "foo",
+		Loc:  SourceLocation{Comments: &NodeComments{TrailingComments: expect.TrailingComments}},
+	}, new(Identifier))
+
+	if errs := Validate(p); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	p.Comments[0] = Comment{}
+	if !hasError(ErrMissingNode, p.Errors()...) {
+		t.Error("expected ErrMissingNode for zero comment")
+	}
+}
+
+func TestAttachComments(t *testing.T) {
+	// /* license */
+	// /* @ngInject */ function foo(a) {
+	//   bar(); // baz
+	// }
+	// // end
+	license := Comment{Loc: loc(1, 0, 13), Kind: Block, Value: " license "}
+	inject := Comment{Loc: loc(2, 0, 15), Kind: Block, Value: " @ngInject "}
+	baz := Comment{Loc: loc(3, 9, 15), Kind: Line, Value: " baz"}
+	end := Comment{Loc: loc(5, 0, 6), Kind: Line, Value: " end"}
+	unplaced := Comment{Kind: Line, Value: " no location"}
+
+	body := SourceLocation{
+		Start: Position{Line: 2, Column: 32},
+		End:   Position{Line: 4, Column: 1},
+	}
+	p := Program{
+		Loc: SourceLocation{
+			Start: Position{Line: 1, Column: 0},
+			End:   Position{Line: 5, Column: 6},
+		},
+		Body: []DirectiveOrStatement{
+			FunctionDeclaration{
+				Loc: SourceLocation{
+					Start: Position{Line: 2, Column: 16},
+					End:   Position{Line: 4, Column: 1},
+				},
+				ID:     Identifier{Loc: loc(2, 25, 28), Name: "foo"},
+				Params: []Pattern{Identifier{Loc: loc(2, 29, 30), Name: "a"}},
+				Body: FunctionBody{
+					Loc: body,
+					Body: []DirectiveOrStatement{
+						ExpressionStatement{
+							Loc: loc(3, 2, 8),
+							Expression: CallExpression{
+								Loc:    loc(3, 2, 7),
+								Callee: Identifier{Loc: loc(3, 2, 5), Name: "bar"},
+							},
+						},
+					},
+				},
+			},
+		},
+		Comments: []Comment{license, inject, baz, end, unplaced},
+	}
+
+	attached := AttachComments(p)
+	fd := attached.Body[0].(FunctionDeclaration)
+	expect := &NodeComments{
+		LeadingComments:  []Comment{license, inject},
+		TrailingComments: []Comment{end},
+	}
+	if !reflect.DeepEqual(fd.Loc.Comments, expect) {
+		t.Errorf("expected %#v, got %#v", expect, fd.Loc.Comments)
+	}
+	es := fd.Body.Body[0].(ExpressionStatement)
+	expect = &NodeComments{TrailingComments: []Comment{baz}}
+	if !reflect.DeepEqual(es.Loc.Comments, expect) {
+		t.Errorf("expected %#v, got %#v", expect, es.Loc.Comments)
+	}
+	if !reflect.DeepEqual(attached.Comments, p.Comments) {
+		t.Error("expected Program.Comments to be unchanged")
+	}
+
+	orig := p.Body[0].(FunctionDeclaration)
+	if orig.Loc.Comments != nil || orig.Body.Body[0].(ExpressionStatement).Loc.Comments != nil {
+		t.Error("expected original Program to be unmodified")
+	}
+
+	testRoundtripJSON(t, attached, new(Program))
+}
@@ -34,8 +34,9 @@ func (rs ReturnStatement) MarshalJSON() ([]byte, error) {
 
 func (rs *ReturnStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type     string          `json:"type"`
-		Loc      SourceLocation  `json:"loc"`
 		Argument json.RawMessage `json:"argument"`
 	}
 	err := json.Unmarshal(b, &x)
@@ -43,7 +44,7 @@ func (rs *ReturnStatement) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, rs.Type(), x.Type)
 	}
 	if err == nil {
-		rs.Loc = x.Loc
+		rs.Loc = x.Location()
 		rs.Argument, _, err = unmarshalExpression(x.Argument)
 	}
 	return err
@@ -81,6 +82,9 @@ func (ls LabeledStatement) Errors() []error {
 	c := nodeChecker{Node: ls}
 	c.require(ls.Label, "statement label")
 	c.require(ls.Body, "labeled statement")
+	if isLexicalDeclaration(ls.Body) {
+		c.appendf("lexical declaration as labeled statement %w", ErrNotAllowed)
+	}
 	return c.errors()
 }
 
@@ -93,8 +97,9 @@ func (ls LabeledStatement) MarshalJSON() ([]byte, error) {
 
 func (ls *LabeledStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type  string          `json:"type"`
-		Loc   SourceLocation  `json:"loc"`
 		Label Identifier      `json:"label"`
 		Body  json.RawMessage `json:"body"`
 	}
@@ -103,7 +108,7 @@ func (ls *LabeledStatement) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ls.Type(), x.Type)
 	}
 	if err == nil {
-		ls.Loc, ls.Label = x.Loc, x.Label
+		ls.Loc, ls.Label = x.Location(), x.Label
 		ls.Body, _, err = unmarshalStatement(x.Body)
 	}
 	return err
@@ -140,16 +145,17 @@ func (bs BreakStatement) MarshalJSON() ([]byte, error) {
 
 func (bs *BreakStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
-		Type  string         `json:"type"`
-		Loc   SourceLocation `json:"loc"`
-		Label Identifier     `json:"label"`
+		NodeFields
+
+		Type  string     `json:"type"`
+		Label Identifier `json:"label"`
 	}
 	err := json.Unmarshal(b, &x)
 	if err == nil && x.Type != bs.Type() {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, bs.Type(), x.Type)
 	}
 	if err == nil {
-		bs.Loc, bs.Label = x.Loc, x.Label
+		bs.Loc, bs.Label = x.Location(), x.Label
 	}
 	return err
 }
@@ -185,16 +191,17 @@ func (cs ContinueStatement) MarshalJSON() ([]byte, error) {
 
 func (cs *ContinueStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
-		Type  string         `json:"type"`
-		Loc   SourceLocation `json:"loc"`
-		Label Identifier     `json:"label"`
+		NodeFields
+
+		Type  string     `json:"type"`
+		Label Identifier `json:"label"`
 	}
 	err := json.Unmarshal(b, &x)
 	if err == nil && x.Type != cs.Type() {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, cs.Type(), x.Type)
 	}
 	if err == nil {
-		cs.Loc, cs.Label = x.Loc, x.Label
+		cs.Loc, cs.Label = x.Location(), x.Label
 	}
 	return err
 }
@@ -11,72 +11,134 @@ import (
 type Declaration interface {
 	Statement
 	isDeclaration()
+	isDeclarationOrExpression()
 }
 
 type baseDeclaration struct {
 	baseStatement
 }
 
-func (baseDeclaration) MinVersion() Version { return ES5 }
-func (baseDeclaration) isDeclaration()      {}
+func (baseDeclaration) MinVersion() Version        { return ES5 }
+func (baseDeclaration) isDeclaration()             {}
+func (baseDeclaration) isDeclarationOrExpression() {}
 
 // FunctionDeclaration declares a function. Note that unlike in the parent
-// interface Function, ID cannot be nil.
+// interface Function, ID cannot be zero, except when the FunctionDeclaration
+// is the Declaration of an ExportDefaultDeclaration.
 type FunctionDeclaration struct {
 	baseDeclaration
-	Loc    SourceLocation
-	ID     Identifier
-	Params []Pattern
-	Body   FunctionBody
+	Loc       SourceLocation
+	ID        Identifier
+	Params    []Pattern
+	Body      FunctionBody
+	Generator bool
+	Async     bool
+
+	TypeParameters TypeParameterDeclaration // or nil
+	ReturnType     TypeAnnotation           // or nil
 }
 
-func (FunctionDeclaration) Type() string                { return "FunctionDelaration" }
+func (FunctionDeclaration) Type() string                { return "FunctionDeclaration" }
 func (fd FunctionDeclaration) Location() SourceLocation { return fd.Loc }
 
+func (fd FunctionDeclaration) MinVersion() Version {
+	return functionMinVersion(fd.Generator, fd.Async)
+}
+
 func (fd FunctionDeclaration) IsZero() bool {
 	return fd.Loc.IsZero() &&
 		fd.ID.IsZero() &&
 		len(fd.Params) == 0 &&
-		len(fd.Body.Body) == 0
+		len(fd.Body.Body) == 0 &&
+		!fd.Generator &&
+		!fd.Async &&
+		fd.TypeParameters == nil &&
+		fd.ReturnType == nil
 }
 
 func (fd FunctionDeclaration) Walk(v Visitor) {
 	if v = v.Visit(fd); v != nil {
 		defer v.Visit(nil)
-		fd.ID.Walk(v)
+		if !fd.ID.IsZero() {
+			fd.ID.Walk(v)
+		}
+		if fd.TypeParameters != nil {
+			fd.TypeParameters.Walk(v)
+		}
 		for _, p := range fd.Params {
 			p.Walk(v)
 		}
+		if fd.ReturnType != nil {
+			fd.ReturnType.Walk(v)
+		}
 		fd.Body.Walk(v)
 	}
 }
 
+// Errors checks the FunctionDeclaration.  ID is required unless the function
+// is exported as a module's default; since that can't be determined without
+// the parent Node, use Validate to check declarations in that position.
 func (fd FunctionDeclaration) Errors() []error {
+	return fd.errors(true)
+}
+
+func (fd FunctionDeclaration) contextErrors(ctx validationContext) []error {
+	_, isDefault := parentNode(ctx.ancestors).(ExportDefaultDeclaration)
+	return fd.errors(!isDefault)
+}
+
+func (fd FunctionDeclaration) errors(requireID bool) []error {
 	c := nodeChecker{Node: fd}
-	c.require(fd.ID, "function name")
-	c.requireEach(nodeSlice{
+	if requireID {
+		c.require(fd.ID, "function name")
+	} else {
+		c.optional(fd.ID)
+	}
+	params := nodeSlice{
 		Index: func(i int) Node { return fd.Params[i] },
 		Len:   len(fd.Params),
-	}, "function parameter")
+	}
+	c.requireEach(params, "function parameter")
+	c.requireRestLast(params, "function parameters")
 	c.require(fd.Body, "function body")
+	c.optional(fd.TypeParameters)
+	c.optional(fd.ReturnType)
 	return c.errors()
 }
 
 func (fd FunctionDeclaration) MarshalJSON() ([]byte, error) {
 	x := nodeToMap(fd)
-	x["id"] = fd.ID
+	if fd.ID.IsZero() {
+		x["id"] = nil
+	} else {
+		x["id"] = fd.ID
+	}
 	x["params"] = fd.Params
 	x["body"] = fd.Body
+	x["generator"] = fd.Generator
+	x["async"] = fd.Async
+	if fd.TypeParameters != nil {
+		x["typeParameters"] = fd.TypeParameters
+	}
+	if fd.ReturnType != nil {
+		x["returnType"] = fd.ReturnType
+	}
 	return json.Marshal(x)
 }
 
 func (fd *FunctionDeclaration) UnmarshalJSON(b []byte) error {
 	var x struct {
-		Type   string            `json:"type"`
-		Loc    SourceLocation    `json:"loc"`
-		ID     Identifier        `json:"id"`
-		Params []json.RawMessage `json:"params"`
-		Body   FunctionBody      `json:"body"`
+		NodeFields
+
+		Type      string            `json:"type"`
+		ID        Identifier        `json:"id"`
+		Params    []json.RawMessage `json:"params"`
+		Body      FunctionBody      `json:"body"`
+		Generator bool              `json:"generator"`
+		Async     bool              `json:"async"`
+
+		TypeParameters json.RawMessage `json:"typeParameters"`
+		ReturnType     json.RawMessage `json:"returnType"`
 	}
 	err := json.Unmarshal(b, &x)
 	if err == nil && x.Type != fd.Type() {
@@ -91,7 +153,13 @@ func (fd *FunctionDeclaration) UnmarshalJSON(b []byte) error {
 		}
 	}
 	if err == nil {
-		fd.Loc, fd.ID, fd.Body = x.Loc, x.ID, x.Body
+		fd.Loc, fd.ID, fd.Body = x.Location(), x.ID, x.Body
+		fd.Generator, fd.Async = x.Generator, x.Async
+		fd.ReturnType, err = unmarshalTypeAnnotation(x.ReturnType)
+		var err2 error
+		if fd.TypeParameters, err2 = unmarshalTypeParameterDeclaration(x.TypeParameters); err == nil && err2 != nil {
+			err = err2
+		}
 		if len(x.Params) == 0 {
 			fd.Params = nil
 		} else {
@@ -170,18 +238,44 @@ func unmarshalVariableDeclarationOrPattern(m json.RawMessage) (VariableDeclarati
 type VariableDeclarationKind string
 
 var (
-	Var VariableDeclarationKind = "var"
+	Var   VariableDeclarationKind = "var"
+	Let   VariableDeclarationKind = "let"
+	Const VariableDeclarationKind = "const"
 )
 
 func (vdk VariableDeclarationKind) GoString() string {
-	if vdk == Var {
+	switch vdk {
+	case Var:
 		return "Var"
+	case Let:
+		return "Let"
+	case Const:
+		return "Const"
 	}
 	return fmt.Sprintf("%q", vdk)
 }
 
 func (vdk VariableDeclarationKind) IsValid() bool {
-	return vdk == Var
+	switch vdk {
+	case Var, Let, Const:
+		return true
+	}
+	return false
+}
+
+func (vdk VariableDeclarationKind) MinVersion() Version {
+	switch vdk {
+	case Let, Const:
+		return ES2015
+	}
+	return ES5
+}
+
+// isLexicalDeclaration indicates s is a let or const VariableDeclaration,
+// which may not appear as the body of a statement such as IfStatement.
+func isLexicalDeclaration(s Statement) bool {
+	vd, ok := s.(VariableDeclaration)
+	return ok && (vd.Kind == Let || vd.Kind == Const)
 }
 
 // VariableDeclaration is a group of VariableDeclarators.
@@ -196,11 +290,15 @@ func (vd VariableDeclaration) IsZero() bool {
 	return vd.Loc.IsZero() && len(vd.Declarations) == 0 && vd.Kind == ""
 }
 
-func (VariableDeclaration) Type() string                       { return "VariableDelaration" }
+func (VariableDeclaration) Type() string                       { return "VariableDeclaration" }
 func (vd VariableDeclaration) Location() SourceLocation        { return vd.Loc }
 func (VariableDeclaration) isVariableDeclarationOrExpression() {}
 func (VariableDeclaration) isVariableDeclarationOrPattern()    {}
 
+func (vd VariableDeclaration) MinVersion() Version {
+	return vd.Kind.MinVersion()
+}
+
 func (vd VariableDeclaration) Walk(v Visitor) {
 	if v = v.Visit(vd); v != nil {
 		defer v.Visit(nil)
@@ -210,7 +308,23 @@ func (vd VariableDeclaration) Walk(v Visitor) {
 	}
 }
 
+// Errors checks the VariableDeclaration.  A const declaration must
+// initialize each of its variables, unless it is the left-hand side of a
+// ForInStatement or ForOfStatement; since that can't be determined without
+// the parent Node, use Validate to check declarations in that position.
 func (vd VariableDeclaration) Errors() []error {
+	return vd.errors(true)
+}
+
+func (vd VariableDeclaration) contextErrors(ctx validationContext) []error {
+	switch parentNode(ctx.ancestors).(type) {
+	case ForInStatement, ForOfStatement:
+		return vd.errors(false)
+	}
+	return vd.errors(true)
+}
+
+func (vd VariableDeclaration) errors(requireConstInit bool) []error {
 	c := nodeChecker{Node: vd}
 	if !vd.Kind.IsValid() {
 		c.appendf("%w VariableDeclarationKind %q", ErrWrongValue, vd.Kind)
@@ -219,6 +333,13 @@ func (vd VariableDeclaration) Errors() []error {
 		Index: func(i int) Node { return vd.Declarations[i] },
 		Len:   len(vd.Declarations),
 	}, "variable declaration")
+	if requireConstInit && vd.Kind == Const {
+		for i, d := range vd.Declarations {
+			if d.Init == nil || d.Init.IsZero() {
+				c.appendf("%w initializer in const declaration at index %d", ErrMissingNode, i)
+			}
+		}
+	}
 	return c.errors()
 }
 
@@ -231,8 +352,9 @@ func (vd VariableDeclaration) MarshalJSON() ([]byte, error) {
 
 func (vd *VariableDeclaration) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type         string                  `json:"type"`
-		Loc          SourceLocation          `json:"loc"`
 		Declarations []VariableDeclarator    `json:"declarations"`
 		Kind         VariableDeclarationKind `json:"kind"`
 	}
@@ -241,7 +363,7 @@ func (vd *VariableDeclaration) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, vd.Type(), x.Type)
 	}
 	if err == nil {
-		vd.Loc, vd.Declarations = x.Loc, x.Declarations
+		vd.Loc, vd.Declarations = x.Location(), x.Declarations
 		if x.Kind.IsValid() {
 			vd.Kind = x.Kind
 		} else {
@@ -259,7 +381,7 @@ type VariableDeclarator struct {
 	Init Expression // or nil
 }
 
-func (VariableDeclarator) Type() string                { return "VariableDelarator" }
+func (VariableDeclarator) Type() string                { return "VariableDeclarator" }
 func (vd VariableDeclarator) Location() SourceLocation { return vd.Loc }
 func (VariableDeclarator) MinVersion() Version         { return ES5 }
 
@@ -297,8 +419,9 @@ func (vd VariableDeclarator) MarshalJSON() ([]byte, error) {
 
 func (vd *VariableDeclarator) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type string          `json:"type"`
-		Loc  SourceLocation  `json:"loc"`
 		ID   json.RawMessage `json:"id"`
 		Init json.RawMessage `json:"init"`
 	}
@@ -307,7 +430,7 @@ func (vd *VariableDeclarator) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, vd.Type(), x.Type)
 	}
 	if err == nil {
-		vd.Loc = x.Loc
+		vd.Loc = x.Location()
 		vd.ID, _, err = unmarshalPattern(x.ID)
 		var err2 error
 		vd.Init, _, err2 = unmarshalExpression(x.Init)
@@ -91,6 +91,37 @@ func TestVariableDeclaration(t *testing.T) {
 	}
 }
 
+func TestLexicalDeclaration(t *testing.T) {
+	vd := VariableDeclaration{
+		Kind: Let,
+		Declarations: []VariableDeclarator{
+			VariableDeclarator{
+				ID: Identifier{Name: "blah"},
+			},
+		},
+	}
+	if vd.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", vd.MinVersion())
+	}
+
+	testRoundtripJSON(t, vd, new(VariableDeclaration))
+
+	if errs := vd.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	vd.Kind = Const
+	if vd.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", vd.MinVersion())
+	}
+	if !hasError(ErrMissingNode, vd.Errors()...) {
+		t.Error("expected ErrMissingNode for const without Init")
+	}
+	vd.Declarations[0].Init = NumberLiteral{Value: 1}
+	if errs := vd.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
 func TestVariableDeclarator(t *testing.T) {
 	var vd VariableDeclarator
 	if !vd.IsZero() {
@@ -6,6 +6,11 @@ import (
 )
 
 // Directive is a directive from the prologue of a script or function.
+//
+// As in the ESTree specification, a Directive is encoded as an
+// ExpressionStatement with a directive property.  Earlier versions of this
+// package used a "Directive" type instead, which is still accepted when
+// decoding, and can be produced with MarshalOptions.LegacyDirectives.
 type Directive struct {
 	Loc        SourceLocation
 	Expression Literal
@@ -35,11 +40,15 @@ func (d Directive) Walk(v Visitor) {
 func (d Directive) Errors() []error {
 	c := nodeChecker{Node: d}
 	c.require(d.Expression, "directive expression")
+	if sl, ok := d.Expression.(StringLiteral); ok && len(sl.Raw) >= 2 && sl.Raw[1:len(sl.Raw)-1] != d.Directive {
+		c.appendf("%w directive %q for raw %s", ErrWrongValue, d.Directive, sl.Raw)
+	}
 	return c.errors()
 }
 
 func (d Directive) MarshalJSON() ([]byte, error) {
 	x := nodeToMap(d)
+	x["type"] = ExpressionStatement{}.Type()
 	x["expression"] = d.Expression
 	x["directive"] = d.Directive
 	return json.Marshal(x)
@@ -47,17 +56,21 @@ func (d Directive) MarshalJSON() ([]byte, error) {
 
 func (d *Directive) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type       string          `json:"type"`
-		Loc        SourceLocation  `json:"loc"`
 		Expression json.RawMessage `json:"expression"`
-		Directive  string          `json:"directive"`
+		Directive  *string         `json:"directive"`
 	}
 	err := json.Unmarshal(b, &x)
-	if err == nil && x.Type != d.Type() {
+	if err == nil && x.Type != d.Type() && (x.Type != ExpressionStatement{}.Type() || x.Directive == nil) {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, d.Type(), x.Type)
 	}
 	if err == nil {
-		d.Loc, d.Directive = x.Loc, x.Directive
+		d.Loc, d.Directive = x.Location(), ""
+		if x.Directive != nil {
+			d.Directive = *x.Directive
+		}
 		d.Expression, _, err = unmarshalLiteral(x.Expression)
 	}
 	return err
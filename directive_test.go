@@ -1,6 +1,10 @@
 package estree
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -33,3 +37,54 @@ func TestDirective(t *testing.T) {
 		t.Error("expected ErrMissingNode for nil Expression")
 	}
 }
+
+func TestDirectiveJSON(t *testing.T) {
+	// Acorn: "use strict"; x
+	acorn := []byte(`{"type":"Program","body":[` +
+		`{"type":"ExpressionStatement","expression":{"type":"Literal","value":"use strict","raw":"\"use strict\""},"directive":"use strict"},` +
+		`{"type":"ExpressionStatement","expression":{"type":"Identifier","name":"x"}}]}`)
+	legacy := []byte(`{"type":"Program","body":[` +
+		`{"type":"Directive","expression":{"type":"Literal","value":"use strict","raw":"\"use strict\""},"directive":"use strict"},` +
+		`{"type":"ExpressionStatement","expression":{"type":"Identifier","name":"x"}}]}`)
+	expect := Program{
+		Body: []DirectiveOrStatement{
+			Directive{
+				Expression: StringLiteral{Value: "use strict", Raw: `"use strict"`},
+				Directive:  "use strict",
+			},
+			ExpressionStatement{Expression: Identifier{Name: "x"}},
+		},
+	}
+	for _, b := range [][]byte{acorn, legacy} {
+		var p Program
+		if err := json.Unmarshal(b, &p); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(p, expect) {
+			t.Errorf("expected %+v, got %+v", expect, p)
+		}
+	}
+
+	b, err := json.Marshal(expect.Body[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b, []byte(`"type":"ExpressionStatement"`)) {
+		t.Errorf("expected ExpressionStatement, got %s", b)
+	}
+	b, err = MarshalOptions{LegacyDirectives: true}.Marshal(expect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b, []byte(`"type":"Directive"`)) || bytes.Count(b, []byte(`"type":"ExpressionStatement"`)) != 1 {
+		t.Errorf("expected legacy Directive, got %s", b)
+	}
+
+	// A directive property is required to decode an ExpressionStatement as
+	// a Directive.
+	var d Directive
+	err = json.Unmarshal([]byte(`{"type":"ExpressionStatement","expression":{"type":"Literal","value":"use strict"}}`), &d)
+	if !errors.Is(err, ErrWrongType) {
+		t.Errorf("expected ErrWrongType, got %v", err)
+	}
+}
@@ -15,5 +15,9 @@
 // Comments
 //
 // See https://github.com/estree/estree/issues/201.  In short, there isn't a
-// standard way to represent comments, so I haven't (yet) done so.
+// standard way to represent comments.  This package follows Acorn, Espree, and
+// Babel: every comment in the source is listed in Program.Comments, and
+// comments may also be attached to individual Nodes (see NodeComments), where
+// they are encoded as the Node's leadingComments and trailingComments.  Use
+// AttachComments to attach free-floating comments to the nearest Nodes.
 package estree
@@ -24,6 +24,11 @@ var (
 	// ErrMissingNode is wrapped when a required field is nil or zero.  The
 	// wrapping error will contain more information.
 	ErrMissingNode = errors.New("missing")
+
+	// ErrNotAllowed is wrapped when a Node is not permitted where it appears
+	// in the AST, e.g. a let declaration as the body of an IfStatement.  The
+	// wrapping error will contain more information.
+	ErrNotAllowed = errors.New("not allowed")
 )
 
 // SyntaxError wraps an error related to a Node.
@@ -144,6 +149,9 @@ func (c *nodeChecker) findPosition(i int) Position {
 
 // errors converts the nodeChecker results to an error slice.
 func (c *nodeChecker) errors() []error {
+	if r := c.Node.Location().Range; r.Start < 0 || r.End < r.Start {
+		c.appendf("%w Range [%d, %d]", ErrWrongValue, r.Start, r.End)
+	}
 	var errs []error
 	for i, err := range c.checked {
 		if err.Err != nil {
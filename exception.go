@@ -42,8 +42,9 @@ func (ts ThrowStatement) MarshalJSON() ([]byte, error) {
 
 func (ts *ThrowStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type     string          `json:"type"`
-		Loc      SourceLocation  `json:"loc"`
 		Argument json.RawMessage `json:"argument"`
 	}
 	err := json.Unmarshal(b, &x)
@@ -51,7 +52,7 @@ func (ts *ThrowStatement) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ts.Type(), x.Type)
 	}
 	if err == nil {
-		ts.Loc = x.Loc
+		ts.Loc = x.Location()
 		ts.Argument, _, err = unmarshalExpression(x.Argument)
 	}
 	return err
@@ -59,12 +60,13 @@ func (ts *ThrowStatement) UnmarshalJSON(b []byte) error {
 
 // TryStatement executes Block, optionally with a Handler to execute if an
 // exception is caught, and optionally with a Finalizer to execute afterwards.
+// At least one of Handler and Finalizer is required.
 type TryStatement struct {
 	baseStatement
 	Loc       SourceLocation
 	Block     BlockStatement
-	Handler   CatchClause    // possibly zero
-	Finalizer BlockStatement // possibly zero, unless handler is zero
+	Handler   *CatchClause    // or nil
+	Finalizer *BlockStatement // or nil
 }
 
 func (TryStatement) Type() string                { return "TryStatement" }
@@ -73,49 +75,54 @@ func (ts TryStatement) Location() SourceLocation { return ts.Loc }
 func (ts TryStatement) IsZero() bool {
 	return ts.Loc.IsZero() &&
 		(ts.Block.Loc.IsZero() && len(ts.Block.Body) == 0) &&
-		ts.Handler.IsZero() &&
-		(ts.Finalizer.Loc.IsZero() && len(ts.Finalizer.Body) == 0)
+		ts.Handler == nil &&
+		ts.Finalizer == nil
 }
 
 func (ts TryStatement) Walk(v Visitor) {
 	if v = v.Visit(ts); v != nil {
 		defer v.Visit(nil)
 		ts.Block.Walk(v)
-		ts.Handler.Walk(v)
-		ts.Finalizer.Walk(v)
+		if ts.Handler != nil {
+			ts.Handler.Walk(v)
+		}
+		if ts.Finalizer != nil {
+			ts.Finalizer.Walk(v)
+		}
 	}
 }
 
 func (ts TryStatement) Errors() []error {
 	c := nodeChecker{Node: ts}
 	c.optional(ts.Block)
-	c.optional(ts.Handler)
-	if ts.Handler.IsZero() && ts.Finalizer.Loc.IsZero() && len(ts.Finalizer.Body) == 0 {
+	if ts.Handler != nil {
+		c.optional(*ts.Handler)
+	}
+	if ts.Handler == nil && ts.Finalizer == nil {
 		c.appendf("%w catch or final block", ErrMissingNode)
 	}
-	c.optional(ts.Finalizer)
+	if ts.Finalizer != nil {
+		c.optional(*ts.Finalizer)
+	}
 	return c.errors()
 }
 
 func (ts TryStatement) MarshalJSON() ([]byte, error) {
 	x := nodeToMap(ts)
 	x["block"] = ts.Block
-	if !ts.Handler.IsZero() {
-		x["handler"] = ts.Handler
-	}
-	if !ts.Finalizer.Loc.IsZero() || len(ts.Finalizer.Body) > 0 {
-		x["finalizer"] = ts.Finalizer
-	}
+	x["handler"] = ts.Handler
+	x["finalizer"] = ts.Finalizer
 	return json.Marshal(x)
 }
 
 func (ts *TryStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
-		Type      string         `json:"type"`
-		Loc       SourceLocation `json:"loc"`
-		Block     BlockStatement `json:"block"`
-		Handler   CatchClause    `json:"handler"`
-		Finalizer BlockStatement `json:"finalizer"`
+		NodeFields
+
+		Type      string          `json:"type"`
+		Block     BlockStatement  `json:"block"`
+		Handler   *CatchClause    `json:"handler"`
+		Finalizer *BlockStatement `json:"finalizer"`
 	}
 	err := json.Unmarshal(b, &x)
 	if err == nil && x.Type != ts.Type() {
@@ -123,7 +130,7 @@ func (ts *TryStatement) UnmarshalJSON(b []byte) error {
 	}
 	if err == nil {
 		ts.Loc, ts.Block, ts.Handler, ts.Finalizer =
-			x.Loc, x.Block, x.Handler, x.Finalizer
+			x.Location(), x.Block, x.Handler, x.Finalizer
 	}
 	return err
 }
@@ -131,13 +138,20 @@ func (ts *TryStatement) UnmarshalJSON(b []byte) error {
 // CatchClause is the catch clause following a try block.
 type CatchClause struct {
 	Loc   SourceLocation
-	Param Pattern
+	Param Pattern // or nil
 	Body  BlockStatement
 }
 
 func (CatchClause) Type() string                { return "CatchClause" }
 func (cc CatchClause) Location() SourceLocation { return cc.Loc }
-func (CatchClause) MinVersion() Version         { return ES5 }
+
+func (cc CatchClause) MinVersion() Version {
+	if cc.Param == nil {
+		// Optional catch binding, e.g. try {...} catch {...}
+		return ES2019
+	}
+	return ES5
+}
 
 func (cc CatchClause) IsZero() bool {
 	return cc.Loc.IsZero() &&
@@ -157,7 +171,9 @@ func (cc CatchClause) Walk(v Visitor) {
 
 func (cc CatchClause) Errors() []error {
 	c := nodeChecker{Node: cc}
-	c.require(cc.Param, "catch expression")
+	if cc.Param != nil {
+		c.require(cc.Param, "catch expression")
+	}
 	c.require(cc.Body, "catch block")
 	return c.errors()
 }
@@ -171,8 +187,9 @@ func (cc CatchClause) MarshalJSON() ([]byte, error) {
 
 func (cc *CatchClause) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type  string          `json:"type"`
-		Loc   SourceLocation  `json:"loc"`
 		Param json.RawMessage `json:"param"`
 		Body  BlockStatement  `json:"body"`
 	}
@@ -181,7 +198,7 @@ func (cc *CatchClause) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, cc.Type(), x.Type)
 	}
 	if err == nil {
-		cc.Loc, cc.Body = x.Loc, x.Body
+		cc.Loc, cc.Body = x.Location(), x.Body
 		cc.Param, _, err = unmarshalPattern(x.Param)
 	}
 	return err
@@ -55,7 +55,7 @@ func TestTryStatement(t *testing.T) {
 			},
 		},
 	}
-	ts.Handler = CatchClause{
+	ts.Handler = &CatchClause{
 		Param: Identifier{Name: "bar"},
 		Body: BlockStatement{
 			Body: []Statement{
@@ -63,7 +63,7 @@ func TestTryStatement(t *testing.T) {
 			},
 		},
 	}
-	ts.Finalizer = BlockStatement{
+	ts.Finalizer = &BlockStatement{
 		Body: []Statement{
 			EmptyStatement{},
 		},
@@ -81,11 +81,11 @@ func TestTryStatement(t *testing.T) {
 		ts.Block,
 		ts.Block.Body[0],
 		ts.Block.Body[0].(ThrowStatement).Argument, nil, nil, nil,
-		ts.Handler,
+		*ts.Handler,
 		ts.Handler.Param, nil,
 		ts.Handler.Body,
 		ts.Handler.Body.Body[0], nil, nil, nil,
-		ts.Finalizer,
+		*ts.Finalizer,
 		ts.Finalizer.Body[0], nil, nil, nil)
 
 	testRoundtripJSON(t, ts, new(TryStatement))
@@ -93,11 +93,47 @@ func TestTryStatement(t *testing.T) {
 	if errs := ts.Errors(); len(errs) != 0 {
 		t.Fatalf("unexpected errors: %v", errs)
 	}
-	ts.Handler = CatchClause{}
-	ts.Finalizer = BlockStatement{}
+	ts.Handler = nil
+	ts.Finalizer = nil
 	if !hasError(ErrMissingNode, ts.Errors()...) {
-		t.Error("expected ErrMissingNode for zero Handler and Finalizer")
+		t.Error("expected ErrMissingNode for nil Handler and Finalizer")
 	}
+
+	// try {} catch {}
+	ts = TryStatement{Handler: &CatchClause{}}
+	if ts.IsZero() {
+		t.Error("expected !IsZero() with empty Handler")
+	}
+	testRoundtripJSON(t, ts, new(TryStatement))
+	if errs := Validate(Program{Body: []DirectiveOrStatement{ts}}); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	// try {} finally {}, as encoded by Acorn.
+	b := []byte(`{"type":"TryStatement","block":{"type":"BlockStatement","body":[]},"handler":null,"finalizer":{"type":"BlockStatement","body":[]}}`)
+	if err := ts.UnmarshalJSON(b); err != nil {
+		t.Fatal(err)
+	}
+	if ts.Handler != nil || ts.Finalizer == nil {
+		t.Errorf("expected nil Handler and non-nil Finalizer, got %+v", ts)
+	}
+	testRoundtripJSON(t, ts, new(TryStatement))
+
+	// try {} catch {}, as encoded by Acorn.
+	b = []byte(`{"type":"TryStatement","block":{"type":"BlockStatement","body":[]},"handler":{"type":"CatchClause","param":null,"body":{"type":"BlockStatement","body":[]}},"finalizer":null}`)
+	if err := ts.UnmarshalJSON(b); err != nil {
+		t.Fatal(err)
+	}
+	if ts.Handler == nil || ts.Finalizer != nil {
+		t.Errorf("expected non-nil Handler and nil Finalizer, got %+v", ts)
+	}
+	if ts.Handler.MinVersion() != ES2019 {
+		t.Errorf("expected ES2019, got %s", ts.Handler.MinVersion())
+	}
+	if errs := Validate(ts); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	testRoundtripJSON(t, ts, new(TryStatement))
 }
 
 func TestCatchClause(t *testing.T) {
@@ -133,7 +169,11 @@ func TestCatchClause(t *testing.T) {
 		t.Error("expected ErrMissingNode for zero Param")
 	}
 	cc.Param = nil
-	if !hasError(ErrMissingNode, cc.Errors()...) {
-		t.Error("expected ErrMissingNode for nil Param")
+	if errs := cc.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
 	}
+	if cc.MinVersion() != ES2019 {
+		t.Errorf("expected ES2019, got %s", cc.MinVersion())
+	}
+	testRoundtripJSON(t, cc, new(CatchClause))
 }
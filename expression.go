@@ -2,6 +2,7 @@ package estree
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
@@ -15,6 +16,12 @@ type Expression interface {
 	isExpressionOrArrayHole()
 	isVariableDeclarationOrExpression()
 	isPatternOrExpression()
+	isFunctionBodyOrExpression()
+	isExpressionOrSuper()
+	isDeclarationOrExpression()
+	isExpressionOrSpread()
+	isExpressionOrPrivateIdentifier()
+	isExpressionOrJSXEmptyExpression()
 }
 
 func unmarshalExpression(m json.RawMessage) (e Expression, match bool, err error) {
@@ -37,12 +44,24 @@ func unmarshalExpression(m json.RawMessage) (e Expression, match bool, err error
 		case FunctionExpression{}.Type():
 			var fe FunctionExpression
 			err, match, e = json.Unmarshal(m, &fe), true, fe
+		case ArrowFunctionExpression{}.Type():
+			var afe ArrowFunctionExpression
+			err, match, e = json.Unmarshal(m, &afe), true, afe
+		case ClassExpression{}.Type():
+			var ce ClassExpression
+			err, match, e = json.Unmarshal(m, &ce), true, ce
 		case UnaryExpression{}.Type():
 			var ue UnaryExpression
 			err, match, e = json.Unmarshal(m, &ue), true, ue
 		case UpdateExpression{}.Type():
 			var ue UpdateExpression
 			err, match, e = json.Unmarshal(m, &ue), true, ue
+		case YieldExpression{}.Type():
+			var ye YieldExpression
+			err, match, e = json.Unmarshal(m, &ye), true, ye
+		case AwaitExpression{}.Type():
+			var ae AwaitExpression
+			err, match, e = json.Unmarshal(m, &ae), true, ae
 		case BinaryExpression{}.Type():
 			var be BinaryExpression
 			err, match, e = json.Unmarshal(m, &be), true, be
@@ -64,14 +83,41 @@ func unmarshalExpression(m json.RawMessage) (e Expression, match bool, err error
 		case NewExpression{}.Type():
 			var ne NewExpression
 			err, match, e = json.Unmarshal(m, &ne), true, ne
+		case ChainExpression{}.Type():
+			var ce ChainExpression
+			err, match, e = json.Unmarshal(m, &ce), true, ce
 		case SequenceExpression{}.Type():
 			var se SequenceExpression
 			err, match, e = json.Unmarshal(m, &se), true, se
+		case ParenthesizedExpression{}.Type():
+			var pe ParenthesizedExpression
+			err, match, e = json.Unmarshal(m, &pe), true, pe
+		case JSXElement{}.Type():
+			var je JSXElement
+			err, match, e = json.Unmarshal(m, &je), true, je
+		case JSXFragment{}.Type():
+			var jf JSXFragment
+			err, match, e = json.Unmarshal(m, &jf), true, jf
+		case MetaProperty{}.Type():
+			var mp MetaProperty
+			err, match, e = json.Unmarshal(m, &mp), true, mp
+		case ImportExpression{}.Type():
+			var ie ImportExpression
+			err, match, e = json.Unmarshal(m, &ie), true, ie
+		case TemplateLiteral{}.Type():
+			var tl TemplateLiteral
+			err, match, e = json.Unmarshal(m, &tl), true, tl
+		case TaggedTemplateExpression{}.Type():
+			var tte TaggedTemplateExpression
+			err, match, e = json.Unmarshal(m, &tte), true, tte
 		case Identifier{}.Type():
 			var i Identifier
 			err, match, e = json.Unmarshal(m, &i), true, i
 		default:
-			if e, match, err = unmarshalLiteral(m); !match {
+			if unmarshal, ok := extensionExpressions[x.Type]; ok {
+				e, err = unmarshal(m)
+				match = true
+			} else if e, match, err = unmarshalLiteral(m); !match {
 				err = fmt.Errorf("%w Expression, got %v", ErrWrongType, string(m))
 			}
 		}
@@ -89,6 +135,12 @@ func (baseExpression) isExpression()                      {}
 func (baseExpression) isExpressionOrArrayHole()           {}
 func (baseExpression) isVariableDeclarationOrExpression() {}
 func (baseExpression) isPatternOrExpression()             {}
+func (baseExpression) isFunctionBodyOrExpression()        {}
+func (baseExpression) isExpressionOrSuper()               {}
+func (baseExpression) isDeclarationOrExpression()         {}
+func (baseExpression) isExpressionOrSpread()              {}
+func (baseExpression) isExpressionOrPrivateIdentifier()   {}
+func (baseExpression) isExpressionOrJSXEmptyExpression()  {}
 
 // ThisExpression represents the "this" keyword.
 type ThisExpression struct {
@@ -113,19 +165,22 @@ func (te ThisExpression) MarshalJSON() ([]byte, error) {
 
 func (te *ThisExpression) UnmarshalJSON(b []byte) error {
 	var x struct {
-		Type string         `json:"type"`
-		Loc  SourceLocation `json:"loc"`
+		NodeFields
+
+		Type string `json:"type"`
 	}
 	err := json.Unmarshal(b, &x)
 	if err == nil && x.Type != te.Type() {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, te.Type(), x.Type)
 	}
 	if err == nil {
-		te.Loc = x.Loc
+		te.Loc = x.Location()
 	}
 	return err
 }
 
+// ExpressionOrArrayHole is an element of an ArrayExpression: any
+// implementation of Expression, a SpreadElement, or an ArrayHole.
 type ExpressionOrArrayHole interface {
 	Node
 	isExpressionOrArrayHole()
@@ -166,6 +221,16 @@ func (ArrayExpression) Type() string                { return "ArrayExpression" }
 func (ae ArrayExpression) Location() SourceLocation { return ae.Loc }
 func (ArrayExpression) IsZero() bool                { return false }
 
+func (ae ArrayExpression) MinVersion() Version {
+	if hasSpread(nodeSlice{
+		Index: func(i int) Node { return ae.Elements[i] },
+		Len:   len(ae.Elements),
+	}) {
+		return ES2015
+	}
+	return ES5
+}
+
 func (ae ArrayExpression) Walk(v Visitor) {
 	if v = v.Visit(ae); v != nil {
 		defer v.Visit(nil)
@@ -194,8 +259,9 @@ func (ae ArrayExpression) MarshalJSON() ([]byte, error) {
 
 func (ae *ArrayExpression) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type     string            `json:"type"`
-		Loc      SourceLocation    `json:"loc"`
 		Elements []json.RawMessage `json:"elements"`
 	}
 	err := json.Unmarshal(b, &x)
@@ -203,7 +269,7 @@ func (ae *ArrayExpression) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ae.Type(), x.Type)
 	}
 	if err == nil {
-		ae.Loc = x.Loc
+		ae.Loc = x.Location()
 		if len(x.Elements) == 0 {
 			ae.Elements = nil
 		} else {
@@ -213,7 +279,7 @@ func (ae *ArrayExpression) UnmarshalJSON(b []byte) error {
 					ae.Elements[i] = ArrayHole{}
 				} else {
 					var err2 error
-					ae.Elements[i], _, err2 = unmarshalExpression(x.Elements[i])
+					ae.Elements[i], err2 = unmarshalExpressionOrSpread(x.Elements[i])
 					if err == nil && err2 != nil {
 						err = err2
 					}
@@ -224,17 +290,147 @@ func (ae *ArrayExpression) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// ExpressionOrSpread is an array element or function argument, which is
+// either any implementation of Expression or a SpreadElement.
+type ExpressionOrSpread interface {
+	ExpressionOrArrayHole
+	isExpressionOrSpread()
+}
+
+func unmarshalExpressionOrSpread(m json.RawMessage) (ExpressionOrSpread, error) {
+	if e, match, err := unmarshalExpression(m); match {
+		return e, err
+	}
+	var se SpreadElement
+	if err := json.Unmarshal(m, &se); !errors.Is(err, ErrWrongType) {
+		if err != nil {
+			return nil, err
+		}
+		return se, nil
+	}
+	return nil, fmt.Errorf("%w Expression or SpreadElement, got %v", ErrWrongType, string(m))
+}
+
+// hasSpread indicates whether any element of a nodeSlice is a SpreadElement.
+func hasSpread(ns nodeSlice) bool {
+	for i := 0; i < ns.Len; i++ {
+		if _, ok := ns.Index(i).(SpreadElement); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// SpreadElement expands an iterable in an ArrayExpression, the arguments of
+// a CallExpression or NewExpression, or the properties of an
+// ObjectExpression, e.g. [...foo].
+type SpreadElement struct {
+	Loc      SourceLocation
+	Argument Expression
+}
+
+func (SpreadElement) Type() string                { return "SpreadElement" }
+func (se SpreadElement) Location() SourceLocation { return se.Loc }
+func (SpreadElement) MinVersion() Version         { return ES2015 }
+func (SpreadElement) isExpressionOrArrayHole()    {}
+func (SpreadElement) isExpressionOrSpread()       {}
+func (SpreadElement) isPropertyOrSpread()         {}
+
+func (se SpreadElement) IsZero() bool {
+	return se.Loc.IsZero() && (se.Argument == nil || se.Argument.IsZero())
+}
+
+func (se SpreadElement) Walk(v Visitor) {
+	if v = v.Visit(se); v != nil {
+		defer v.Visit(nil)
+		if se.Argument != nil {
+			se.Argument.Walk(v)
+		}
+	}
+}
+
+func (se SpreadElement) Errors() []error {
+	c := nodeChecker{Node: se}
+	c.require(se.Argument, "spread argument")
+	return c.errors()
+}
+
+func (se SpreadElement) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(se)
+	x["argument"] = se.Argument
+	return json.Marshal(x)
+}
+
+func (se *SpreadElement) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type     string          `json:"type"`
+		Argument json.RawMessage `json:"argument"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != se.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, se.Type(), x.Type)
+	}
+	if err == nil {
+		se.Loc = x.Location()
+		se.Argument, _, err = unmarshalExpression(x.Argument)
+	}
+	return err
+}
+
+// PropertyOrSpread is a member of an ObjectExpression, which is either a
+// Property or a SpreadElement.
+type PropertyOrSpread interface {
+	Node
+	isPropertyOrSpread()
+}
+
+func unmarshalPropertyOrSpread(m json.RawMessage) (PropertyOrSpread, error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(m, &x)
+	if err == nil {
+		switch x.Type {
+		case Property{}.Type():
+			var p Property
+			if err = json.Unmarshal(m, &p); err == nil {
+				return p, nil
+			}
+		case SpreadElement{}.Type():
+			var se SpreadElement
+			if err = json.Unmarshal(m, &se); err == nil {
+				return se, nil
+			}
+		default:
+			err = fmt.Errorf("%w Property or SpreadElement, got %v", ErrWrongType, string(m))
+		}
+	}
+	return nil, err
+}
+
 // ObjectExpression is an object expression.
 type ObjectExpression struct {
 	baseExpression
 	Loc        SourceLocation
-	Properties []Property
+	Properties []PropertyOrSpread
 }
 
 func (ObjectExpression) Type() string                { return "ObjectExpression" }
 func (oe ObjectExpression) Location() SourceLocation { return oe.Loc }
 func (ObjectExpression) IsZero() bool                { return false }
 
+func (oe ObjectExpression) MinVersion() Version {
+	if hasSpread(nodeSlice{
+		Index: func(i int) Node { return oe.Properties[i] },
+		Len:   len(oe.Properties),
+	}) {
+		return ES2018
+	}
+	return ES5
+}
+
 func (oe ObjectExpression) Walk(v Visitor) {
 	if v = v.Visit(oe); v != nil {
 		defer v.Visit(nil)
@@ -261,16 +457,29 @@ func (oe ObjectExpression) MarshalJSON() ([]byte, error) {
 
 func (oe *ObjectExpression) UnmarshalJSON(b []byte) error {
 	var x struct {
-		Type       string         `json:"type"`
-		Loc        SourceLocation `json:"loc"`
-		Properties []Property     `json:"properties"`
+		NodeFields
+
+		Type       string            `json:"type"`
+		Properties []json.RawMessage `json:"properties"`
 	}
 	err := json.Unmarshal(b, &x)
 	if err == nil && x.Type != oe.Type() {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, oe.Type(), x.Type)
 	}
 	if err == nil {
-		oe.Loc, oe.Properties = x.Loc, x.Properties
+		oe.Loc = x.Location()
+		if len(x.Properties) == 0 {
+			oe.Properties = nil
+		} else {
+			oe.Properties = make([]PropertyOrSpread, len(x.Properties))
+			for i := range x.Properties {
+				var err2 error
+				oe.Properties[i], err2 = unmarshalPropertyOrSpread(x.Properties[i])
+				if err == nil && err2 != nil {
+					err = err2
+				}
+			}
+		}
 	}
 	return err
 }
@@ -308,20 +517,42 @@ func (pk PropertyKind) IsValid() bool {
 // Property is a literal property in an ObjectExpression.
 type Property struct {
 	Loc   SourceLocation
-	Key   LiteralOrIdentifier
+	Key   Expression
 	Value Expression
 	Kind  PropertyKind
+
+	// Method indicates the property was written as a method ({key() {...}}),
+	// in which case Kind is Init and Value is a FunctionExpression.
+	Method bool
+
+	// Shorthand indicates the property was written as {key}, in which case
+	// Value is the Identifier Key.
+	Shorthand bool
+
+	// Computed indicates Key is an arbitrary Expression ({[key]: value}).  If
+	// Computed is false, Key is an Identifier or Literal.
+	Computed bool
 }
 
 func (Property) Type() string               { return "Property" }
 func (p Property) Location() SourceLocation { return p.Loc }
-func (Property) MinVersion() Version        { return ES5 }
+func (Property) isPropertyOrSpread()        {}
+
+func (p Property) MinVersion() Version {
+	if p.Method || p.Shorthand || p.Computed {
+		return ES2015
+	}
+	return ES5
+}
 
 func (p Property) IsZero() bool {
 	return p.Loc.IsZero() &&
 		(p.Key == nil || p.Key.IsZero()) &&
 		(p.Value == nil || p.Value.IsZero()) &&
-		p.Kind == ""
+		p.Kind == "" &&
+		!p.Method &&
+		!p.Shorthand &&
+		!p.Computed
 }
 
 func (p Property) Walk(v Visitor) {
@@ -339,9 +570,28 @@ func (p Property) Walk(v Visitor) {
 func (p Property) Errors() []error {
 	c := nodeChecker{Node: p}
 	c.require(p.Key, "property name")
+	if _, ok := p.Key.(LiteralOrIdentifier); p.Key != nil && !ok && !p.Computed {
+		c.appendf("%w property name %s without Computed", ErrWrongValue, p.Key.Type())
+	}
 	c.require(p.Value, "property expression")
 	if !p.Kind.IsValid() {
 		c.appendf("%w PropertyKind %q", ErrWrongValue, p.Kind)
+	} else if p.Method && p.Kind != Init {
+		c.appendf("%w method with PropertyKind %#v", ErrWrongValue, p.Kind)
+	}
+	isMethod := p.Method || p.Kind == Get || p.Kind == Set
+	if _, ok := p.Value.(FunctionExpression); isMethod && p.Value != nil && !ok {
+		c.appendf("method %s %w", p.Value.Type(), ErrNotAllowed)
+	}
+	if p.Shorthand {
+		if p.Method || p.Computed {
+			c.appendf("shorthand method or computed property %w", ErrNotAllowed)
+		}
+		key, _ := p.Key.(Identifier)
+		value, _ := p.Value.(Identifier)
+		if key.Name == "" || key.Name != value.Name {
+			c.appendf("%w shorthand property with different key and value", ErrWrongValue)
+		}
 	}
 	return c.errors()
 }
@@ -351,34 +601,41 @@ func (p Property) MarshalJSON() ([]byte, error) {
 	x["key"] = p.Key
 	x["value"] = p.Value
 	x["kind"] = p.Kind
+	x["method"] = p.Method
+	x["shorthand"] = p.Shorthand
+	x["computed"] = p.Computed
 	return json.Marshal(x)
 }
 
 func (p *Property) UnmarshalJSON(b []byte) error {
 	var x struct {
-		Type  string          `json:"type"`
-		Loc   SourceLocation  `json:"loc"`
-		Key   json.RawMessage `json:"key"`
-		Value json.RawMessage `json:"value"`
-		Kind  PropertyKind    `json:"kind"`
+		NodeFields
+
+		Type      string          `json:"type"`
+		Key       json.RawMessage `json:"key"`
+		Value     json.RawMessage `json:"value"`
+		Kind      PropertyKind    `json:"kind"`
+		Method    bool            `json:"method"`
+		Shorthand bool            `json:"shorthand"`
+		Computed  bool            `json:"computed"`
 	}
 	err := json.Unmarshal(b, &x)
 	if err == nil && x.Type != p.Type() {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, p.Type(), x.Type)
 	}
 	if err == nil {
-		p.Loc = x.Loc
+		p.Loc, p.Method, p.Shorthand, p.Computed = x.Location(), x.Method, x.Shorthand, x.Computed
 		if x.Kind.IsValid() {
 			p.Kind = x.Kind
 		} else {
 			err = fmt.Errorf("%w Property.Kind %q", ErrWrongValue, x.Kind)
 		}
-		var err2 error
-		if p.Key, _, err2 = unmarshalLiteralOrIdentifier(x.Key); err == nil && err2 != nil {
+		var err2, err3 error
+		if p.Key, _, err2 = unmarshalExpression(x.Key); err == nil && err2 != nil {
 			err = err2
 		}
-		if p.Value, _, err = unmarshalExpression(x.Value); err == nil && err2 != nil {
-			err = err2
+		if p.Value, _, err3 = unmarshalExpression(x.Value); err == nil && err3 != nil {
+			err = err3
 		}
 	}
 	return err
@@ -387,30 +644,49 @@ func (p *Property) UnmarshalJSON(b []byte) error {
 // FunctionExpression is a function expression (closure).
 type FunctionExpression struct {
 	baseExpression
-	Loc    SourceLocation
-	ID     Identifier // possibly zero
-	Params []Pattern
-	Body   FunctionBody
+	Loc       SourceLocation
+	ID        Identifier // possibly zero
+	Params    []Pattern
+	Body      FunctionBody
+	Generator bool
+	Async     bool
+
+	TypeParameters TypeParameterDeclaration // or nil
+	ReturnType     TypeAnnotation           // or nil
 }
 
 func (FunctionExpression) Type() string                { return "FunctionExpression" }
 func (fe FunctionExpression) Location() SourceLocation { return fe.Loc }
 
+func (fe FunctionExpression) MinVersion() Version {
+	return functionMinVersion(fe.Generator, fe.Async)
+}
+
 func (fe FunctionExpression) IsZero() bool {
 	return fe.Loc.IsZero() &&
 		fe.ID.IsZero() &&
 		len(fe.Params) == 0 &&
-		len(fe.Body.Body) == 0
+		len(fe.Body.Body) == 0 &&
+		!fe.Generator &&
+		!fe.Async &&
+		fe.TypeParameters == nil &&
+		fe.ReturnType == nil
 }
 
 func (fe FunctionExpression) Walk(v Visitor) {
 	if v = v.Visit(fe); v != nil {
 		defer v.Visit(nil)
+		if fe.TypeParameters != nil {
+			fe.TypeParameters.Walk(v)
+		}
 		for _, p := range fe.Params {
 			if p != nil {
 				p.Walk(v)
 			}
 		}
+		if fe.ReturnType != nil {
+			fe.ReturnType.Walk(v)
+		}
 		fe.Body.Walk(v)
 	}
 }
@@ -418,11 +694,15 @@ func (fe FunctionExpression) Walk(v Visitor) {
 func (fe FunctionExpression) Errors() []error {
 	c := nodeChecker{Node: fe}
 	c.optional(fe.ID)
-	c.requireEach(nodeSlice{
+	params := nodeSlice{
 		Index: func(i int) Node { return fe.Params[i] },
 		Len:   len(fe.Params),
-	}, "function parameter")
+	}
+	c.requireEach(params, "function parameter")
+	c.requireRestLast(params, "function parameters")
 	c.require(fe.Body, "function body")
+	c.optional(fe.TypeParameters)
+	c.optional(fe.ReturnType)
 	return c.errors()
 }
 
@@ -431,16 +711,30 @@ func (fe FunctionExpression) MarshalJSON() ([]byte, error) {
 	x["id"] = fe.ID
 	x["params"] = fe.Params
 	x["body"] = fe.Body
+	x["generator"] = fe.Generator
+	x["async"] = fe.Async
+	if fe.TypeParameters != nil {
+		x["typeParameters"] = fe.TypeParameters
+	}
+	if fe.ReturnType != nil {
+		x["returnType"] = fe.ReturnType
+	}
 	return json.Marshal(x)
 }
 
 func (fe *FunctionExpression) UnmarshalJSON(b []byte) error {
 	var x struct {
-		Type   string            `json:"type"`
-		Loc    SourceLocation    `json:"loc"`
-		ID     Identifier        `json:"id"`
-		Params []json.RawMessage `json:"params"`
-		Body   FunctionBody      `json:"body"`
+		NodeFields
+
+		Type      string            `json:"type"`
+		ID        Identifier        `json:"id"`
+		Params    []json.RawMessage `json:"params"`
+		Body      FunctionBody      `json:"body"`
+		Generator bool              `json:"generator"`
+		Async     bool              `json:"async"`
+
+		TypeParameters json.RawMessage `json:"typeParameters"`
+		ReturnType     json.RawMessage `json:"returnType"`
 	}
 	err := json.Unmarshal(b, &x)
 	if err == nil && x.Type != fe.Type() {
@@ -455,9 +749,14 @@ func (fe *FunctionExpression) UnmarshalJSON(b []byte) error {
 		}
 	}
 	if err == nil {
-		fe.Loc, fe.ID, fe.Body = x.Loc, x.ID, x.Body
+		fe.Loc, fe.ID, fe.Body = x.Location(), x.ID, x.Body
+		fe.Generator, fe.Async = x.Generator, x.Async
+		fe.ReturnType, err = unmarshalTypeAnnotation(x.ReturnType)
+		var err2 error
+		if fe.TypeParameters, err2 = unmarshalTypeParameterDeclaration(x.TypeParameters); err == nil && err2 != nil {
+			err = err2
+		}
 		for i := range x.Params {
-			var err2 error
 			fe.Params[i], _, err2 = unmarshalPattern(x.Params[i])
 			if err == nil && err2 != nil {
 				err = err2
@@ -467,6 +766,179 @@ func (fe *FunctionExpression) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// FunctionBodyOrExpression is the body of an ArrowFunctionExpression, which
+// is either a FunctionBody or any implementation of Expression.
+type FunctionBodyOrExpression interface {
+	Node
+	isFunctionBodyOrExpression()
+}
+
+func unmarshalFunctionBodyOrExpression(m json.RawMessage) (FunctionBodyOrExpression, error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(m, &x)
+	if err == nil && x.Type == (FunctionBody{}).Type() {
+		var fb FunctionBody
+		if err = json.Unmarshal(m, &fb); err == nil {
+			return fb, nil
+		}
+	} else if err == nil {
+		var e Expression
+		if e, _, err = unmarshalExpression(m); err == nil {
+			return e, nil
+		}
+	}
+	return nil, err
+}
+
+// ArrowFunctionExpression is a fat arrow function expression, e.g. (x) => x.
+type ArrowFunctionExpression struct {
+	baseExpression
+	Loc    SourceLocation
+	Params []Pattern
+	Body   FunctionBodyOrExpression
+
+	// Expression indicates Body is an Expression, rather than a
+	// FunctionBody.
+	Expression bool
+
+	// Generator is always false, since arrow functions cannot be
+	// generators.  It is present for consistency with other Functions.
+	Generator bool
+
+	Async bool
+
+	TypeParameters TypeParameterDeclaration // or nil
+	ReturnType     TypeAnnotation           // or nil
+}
+
+func (ArrowFunctionExpression) Type() string                 { return "ArrowFunctionExpression" }
+func (afe ArrowFunctionExpression) Location() SourceLocation { return afe.Loc }
+
+func (afe ArrowFunctionExpression) MinVersion() Version {
+	if v := functionMinVersion(afe.Generator, afe.Async); v > ES2015 {
+		return v
+	}
+	return ES2015
+}
+
+func (afe ArrowFunctionExpression) IsZero() bool {
+	fb, isBlock := afe.Body.(FunctionBody)
+	return afe.Loc.IsZero() &&
+		len(afe.Params) == 0 &&
+		(afe.Body == nil || afe.Body.IsZero() || (isBlock && len(fb.Body) == 0)) &&
+		!afe.Expression &&
+		!afe.Generator &&
+		!afe.Async &&
+		afe.TypeParameters == nil &&
+		afe.ReturnType == nil
+}
+
+func (afe ArrowFunctionExpression) Walk(v Visitor) {
+	if v = v.Visit(afe); v != nil {
+		defer v.Visit(nil)
+		if afe.TypeParameters != nil {
+			afe.TypeParameters.Walk(v)
+		}
+		for _, p := range afe.Params {
+			if p != nil {
+				p.Walk(v)
+			}
+		}
+		if afe.ReturnType != nil {
+			afe.ReturnType.Walk(v)
+		}
+		if afe.Body != nil {
+			afe.Body.Walk(v)
+		}
+	}
+}
+
+func (afe ArrowFunctionExpression) Errors() []error {
+	c := nodeChecker{Node: afe}
+	params := nodeSlice{
+		Index: func(i int) Node { return afe.Params[i] },
+		Len:   len(afe.Params),
+	}
+	c.requireEach(params, "function parameter")
+	c.requireRestLast(params, "function parameters")
+	c.require(afe.Body, "function body")
+	if _, isBlock := afe.Body.(FunctionBody); afe.Body != nil && isBlock == afe.Expression {
+		c.appendf("%w ArrowFunctionExpression.Expression %t for %s body", ErrWrongValue, afe.Expression, afe.Body.Type())
+	}
+	if afe.Generator {
+		c.appendf("generator arrow function %w", ErrNotAllowed)
+	}
+	c.optional(afe.TypeParameters)
+	c.optional(afe.ReturnType)
+	return c.errors()
+}
+
+func (afe ArrowFunctionExpression) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(afe)
+	x["id"] = nil
+	x["params"] = afe.Params
+	x["body"] = afe.Body
+	x["expression"] = afe.Expression
+	x["generator"] = afe.Generator
+	x["async"] = afe.Async
+	if afe.TypeParameters != nil {
+		x["typeParameters"] = afe.TypeParameters
+	}
+	if afe.ReturnType != nil {
+		x["returnType"] = afe.ReturnType
+	}
+	return json.Marshal(x)
+}
+
+func (afe *ArrowFunctionExpression) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type       string            `json:"type"`
+		Params     []json.RawMessage `json:"params"`
+		Body       json.RawMessage   `json:"body"`
+		Expression bool              `json:"expression"`
+		Generator  bool              `json:"generator"`
+		Async      bool              `json:"async"`
+
+		TypeParameters json.RawMessage `json:"typeParameters"`
+		ReturnType     json.RawMessage `json:"returnType"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != afe.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, afe.Type(), x.Type)
+	}
+	if err == nil {
+		afe.Loc, afe.Expression = x.Location(), x.Expression
+		afe.Generator, afe.Async = x.Generator, x.Async
+		afe.Body, err = unmarshalFunctionBodyOrExpression(x.Body)
+		var err2 error
+		afe.ReturnType, err2 = unmarshalTypeAnnotation(x.ReturnType)
+		if err == nil && err2 != nil {
+			err = err2
+		}
+		afe.TypeParameters, err2 = unmarshalTypeParameterDeclaration(x.TypeParameters)
+		if err == nil && err2 != nil {
+			err = err2
+		}
+		if len(x.Params) == 0 {
+			afe.Params = nil
+		} else {
+			afe.Params = make([]Pattern, len(x.Params))
+			for i := range x.Params {
+				var err2 error
+				afe.Params[i], _, err2 = unmarshalPattern(x.Params[i])
+				if err == nil && err2 != nil {
+					err = err2
+				}
+			}
+		}
+	}
+	return err
+}
+
 // ConditionalExpression is a ternary (x ? y : z) expression.
 type ConditionalExpression struct {
 	baseExpression
@@ -517,8 +989,9 @@ func (ce ConditionalExpression) MarshalJSON() ([]byte, error) {
 
 func (ce *ConditionalExpression) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type       string          `json:"type"`
-		Loc        SourceLocation  `json:"loc"`
 		Test       json.RawMessage `json:"test"`
 		Consequent json.RawMessage `json:"consequent"`
 		Alternate  json.RawMessage `json:"alternate"`
@@ -528,7 +1001,7 @@ func (ce *ConditionalExpression) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ce.Type(), x.Type)
 	}
 	if err == nil {
-		ce.Loc = x.Loc
+		ce.Loc = x.Location()
 		ce.Test, _, err = unmarshalExpression(x.Test)
 		var err2 error
 		if ce.Consequent, _, err = unmarshalExpression(x.Consequent); err == nil && err2 != nil {
@@ -546,17 +1019,37 @@ func (ce *ConditionalExpression) UnmarshalJSON(b []byte) error {
 type CallExpression struct {
 	baseExpression
 	Loc       SourceLocation
-	Callee    Expression
-	Arguments []Expression
+	Callee    ExpressionOrSuper
+	Arguments []ExpressionOrSpread
+
+	// Optional indicates the node corresponds to an optional (a?.()) call.
+	// It is only allowed within a ChainExpression.
+	Optional bool
+
+	TypeArguments TypeParameterInstantiation // or nil
 }
 
 func (CallExpression) Type() string                { return "CallExpression" }
 func (ce CallExpression) Location() SourceLocation { return ce.Loc }
 
+func (ce CallExpression) MinVersion() Version {
+	if ce.Optional {
+		return ES2020
+	}
+	if hasSpread(nodeSlice{
+		Index: func(i int) Node { return ce.Arguments[i] },
+		Len:   len(ce.Arguments),
+	}) {
+		return ES2015
+	}
+	return ES5
+}
+
 func (ce CallExpression) IsZero() bool {
 	return ce.Loc.IsZero() &&
 		(ce.Callee == nil || ce.Callee.IsZero()) &&
-		len(ce.Arguments) == 0
+		len(ce.Arguments) == 0 &&
+		ce.TypeArguments == nil
 }
 
 func (ce CallExpression) Walk(v Visitor) {
@@ -565,19 +1058,42 @@ func (ce CallExpression) Walk(v Visitor) {
 		if ce.Callee != nil {
 			ce.Callee.Walk(v)
 		}
+		if ce.TypeArguments != nil {
+			ce.TypeArguments.Walk(v)
+		}
 		for _, a := range ce.Arguments {
 			a.Walk(v)
 		}
 	}
 }
 
+// Errors checks the CallExpression.  Optional is only allowed within a
+// ChainExpression; since that can't be determined without the ancestor Nodes,
+// use Validate to check optional calls.
 func (ce CallExpression) Errors() []error {
+	return ce.errors(false)
+}
+
+func (ce CallExpression) contextErrors(ctx validationContext) []error {
+	return ce.errors(ce.Optional && ctx.inChain())
+}
+
+func (ce CallExpression) errors(inChain bool) []error {
 	c := nodeChecker{Node: ce}
 	c.require(ce.Callee, "callee")
 	c.requireEach(nodeSlice{
 		Index: func(i int) Node { return ce.Arguments[i] },
 		Len:   len(ce.Arguments),
 	}, "argument")
+	c.optional(ce.TypeArguments)
+	if ce.Optional {
+		if !inChain {
+			c.appendf("optional call outside of ChainExpression %w", ErrNotAllowed)
+		}
+		if _, ok := ce.Callee.(Super); ok {
+			c.appendf("optional super call %w", ErrNotAllowed)
+		}
+	}
 	return c.errors()
 }
 
@@ -587,30 +1103,42 @@ func (ce CallExpression) MarshalJSON() ([]byte, error) {
 	if len(ce.Arguments) > 0 {
 		x["arguments"] = ce.Arguments
 	}
+	x["optional"] = ce.Optional
+	if ce.TypeArguments != nil {
+		x["typeArguments"] = ce.TypeArguments
+	}
 	return json.Marshal(x)
 }
 
 func (ce *CallExpression) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type      string            `json:"type"`
-		Loc       SourceLocation    `json:"loc"`
 		Callee    json.RawMessage   `json:"callee"`
 		Arguments []json.RawMessage `json:"arguments"`
+		Optional  bool              `json:"optional"`
+
+		TypeArguments  json.RawMessage `json:"typeArguments"`
+		TypeParameters json.RawMessage `json:"typeParameters"`
 	}
 	err := json.Unmarshal(b, &x)
 	if err == nil && x.Type != ce.Type() {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ce.Type(), x.Type)
 	}
 	if err == nil {
-		ce.Loc = x.Loc
-		ce.Callee, _, err = unmarshalExpression(x.Callee)
+		ce.Loc, ce.Optional = x.Location(), x.Optional
+		ce.Callee, err = unmarshalExpressionOrSuper(x.Callee)
+		var err2 error
+		if ce.TypeArguments, err2 = unmarshalTypeArguments(x.TypeArguments, x.TypeParameters); err == nil && err2 != nil {
+			err = err2
+		}
 		if len(x.Arguments) == 0 {
 			ce.Arguments = nil
 		} else {
-			ce.Arguments = make([]Expression, len(x.Arguments))
+			ce.Arguments = make([]ExpressionOrSpread, len(x.Arguments))
 			for i := range x.Arguments {
-				var err2 error
-				ce.Arguments[i], _, err2 = unmarshalExpression(x.Arguments[i])
+				ce.Arguments[i], err2 = unmarshalExpressionOrSpread(x.Arguments[i])
 				if err == nil && err2 != nil {
 					err = err2
 				}
@@ -620,21 +1148,132 @@ func (ce *CallExpression) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// ChainElement is the Expression of a ChainExpression, which is either a
+// CallExpression or a MemberExpression.
+type ChainElement interface {
+	Expression
+	isChainElement()
+}
+
+func (CallExpression) isChainElement()   {}
+func (MemberExpression) isChainElement() {}
+
+// inChain indicates whether the Node being validated is within the nearest
+// ChainExpression, i.e. reached from it through the Object of each
+// MemberExpression and the Callee of each CallExpression.  An argument or
+// computed property, as in a?.b(c.d?.e), starts a chain of its own.
+func (ctx validationContext) inChain() bool {
+	for i := len(ctx.ancestors) - 1; i >= 0; i-- {
+		// Object and Callee are the first children walked.
+		first := ctx.children[i] == 1
+		switch a := ctx.ancestors[i].(type) {
+		case ChainExpression:
+			return true
+		case MemberExpression:
+			if !first || a.Object == nil {
+				return false
+			}
+		case CallExpression:
+			if !first || a.Callee == nil {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// ChainExpression wraps an optional chain, e.g. a?.b.c(), which
+// short-circuits to undefined if any optional MemberExpression or
+// CallExpression within it has a null or undefined Object or Callee.
+type ChainExpression struct {
+	baseExpression
+	Loc        SourceLocation
+	Expression ChainElement
+}
+
+func (ChainExpression) Type() string                { return "ChainExpression" }
+func (ce ChainExpression) Location() SourceLocation { return ce.Loc }
+func (ChainExpression) MinVersion() Version         { return ES2020 }
+
+func (ce ChainExpression) IsZero() bool {
+	return ce.Loc.IsZero() && (ce.Expression == nil || ce.Expression.IsZero())
+}
+
+func (ce ChainExpression) Walk(v Visitor) {
+	if v = v.Visit(ce); v != nil {
+		defer v.Visit(nil)
+		if ce.Expression != nil {
+			ce.Expression.Walk(v)
+		}
+	}
+}
+
+func (ce ChainExpression) Errors() []error {
+	c := nodeChecker{Node: ce}
+	c.require(ce.Expression, "optional chain")
+	return c.errors()
+}
+
+func (ce ChainExpression) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(ce)
+	x["expression"] = ce.Expression
+	return json.Marshal(x)
+}
+
+func (ce *ChainExpression) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type       string          `json:"type"`
+		Expression json.RawMessage `json:"expression"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ce.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ce.Type(), x.Type)
+	}
+	if err == nil {
+		ce.Loc = x.Location()
+		var e Expression
+		e, _, err = unmarshalExpression(x.Expression)
+		if elem, ok := e.(ChainElement); ok || e == nil {
+			ce.Expression = elem
+		} else if err == nil {
+			err = fmt.Errorf("%w CallExpression or MemberExpression, got %v", ErrWrongType, string(x.Expression))
+		}
+	}
+	return err
+}
+
 // NewExpression is an expression which calls a constructor.
 type NewExpression struct {
 	baseExpression
 	Loc       SourceLocation
 	Callee    Expression
-	Arguments []Expression
+	Arguments []ExpressionOrSpread
+
+	TypeArguments TypeParameterInstantiation // or nil
 }
 
 func (NewExpression) Type() string                { return "NewExpression" }
 func (ne NewExpression) Location() SourceLocation { return ne.Loc }
 
+func (ne NewExpression) MinVersion() Version {
+	if hasSpread(nodeSlice{
+		Index: func(i int) Node { return ne.Arguments[i] },
+		Len:   len(ne.Arguments),
+	}) {
+		return ES2015
+	}
+	return ES5
+}
+
 func (ne NewExpression) IsZero() bool {
 	return ne.Loc.IsZero() &&
 		(ne.Callee == nil || ne.Callee.IsZero()) &&
-		len(ne.Arguments) == 0
+		len(ne.Arguments) == 0 &&
+		ne.TypeArguments == nil
 }
 
 func (ne NewExpression) Walk(v Visitor) {
@@ -643,6 +1282,9 @@ func (ne NewExpression) Walk(v Visitor) {
 		if ne.Callee != nil {
 			ne.Callee.Walk(v)
 		}
+		if ne.TypeArguments != nil {
+			ne.TypeArguments.Walk(v)
+		}
 		for _, a := range ne.Arguments {
 			a.Walk(v)
 		}
@@ -656,6 +1298,7 @@ func (ne NewExpression) Errors() []error {
 		Index: func(i int) Node { return ne.Arguments[i] },
 		Len:   len(ne.Arguments),
 	}, "new argument")
+	c.optional(ne.TypeArguments)
 	return c.errors()
 }
 
@@ -663,28 +1306,40 @@ func (ne NewExpression) MarshalJSON() ([]byte, error) {
 	x := nodeToMap(ne)
 	x["callee"] = ne.Callee
 	x["arguments"] = ne.Arguments
+	if ne.TypeArguments != nil {
+		x["typeArguments"] = ne.TypeArguments
+	}
 	return json.Marshal(x)
 }
 
 func (ne *NewExpression) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type      string            `json:"type"`
 		Callee    json.RawMessage   `json:"callee"`
 		Arguments []json.RawMessage `json:"arguments"`
+
+		TypeArguments  json.RawMessage `json:"typeArguments"`
+		TypeParameters json.RawMessage `json:"typeParameters"`
 	}
 	err := json.Unmarshal(b, &x)
 	if err == nil && x.Type != ne.Type() {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ne.Type(), x.Type)
 	}
 	if err == nil {
+		ne.Loc = x.Location()
 		ne.Callee, _, err = unmarshalExpression(x.Callee)
+		var err2 error
+		if ne.TypeArguments, err2 = unmarshalTypeArguments(x.TypeArguments, x.TypeParameters); err == nil && err2 != nil {
+			err = err2
+		}
 		if len(x.Arguments) == 0 {
 			ne.Arguments = nil
 		} else {
-			ne.Arguments = make([]Expression, len(x.Arguments))
+			ne.Arguments = make([]ExpressionOrSpread, len(x.Arguments))
 			for i := range x.Arguments {
-				var err2 error
-				ne.Arguments[i], _, err2 = unmarshalExpression(x.Arguments[i])
+				ne.Arguments[i], err2 = unmarshalExpressionOrSpread(x.Arguments[i])
 				if err == nil && err2 != nil {
 					err = err2
 				}
@@ -734,8 +1389,9 @@ func (se SequenceExpression) MarshalJSON() ([]byte, error) {
 
 func (se *SequenceExpression) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type        string            `json:"type"`
-		Loc         SourceLocation    `json:"loc"`
 		Expressions []json.RawMessage `json:"expressions"`
 	}
 	err := json.Unmarshal(b, &x)
@@ -743,7 +1399,7 @@ func (se *SequenceExpression) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, se.Type(), x.Type)
 	}
 	if err == nil {
-		se.Loc = x.Loc
+		se.Loc = x.Location()
 		if len(x.Expressions) == 0 {
 			se.Expressions = nil
 		} else {
@@ -759,3 +1415,170 @@ func (se *SequenceExpression) UnmarshalJSON(b []byte) error {
 	}
 	return err
 }
+
+// ParenthesizedExpression is an Expression wrapped in parentheses.  It is not
+// part of the ESTree specification, but is produced by some parsers (e.g.
+// Acorn with preserveParens), and is needed to express operands which are only
+// valid when parenthesized, such as (-a) ** b or (a ?? b) || c.  Validate
+// only requires such operands to be parenthesized when
+// ValidateOptions.PreserveParens is set, since otherwise the parentheses were
+// discarded.
+type ParenthesizedExpression struct {
+	baseExpression
+	Loc        SourceLocation
+	Expression Expression
+}
+
+func (ParenthesizedExpression) Type() string                { return "ParenthesizedExpression" }
+func (pe ParenthesizedExpression) Location() SourceLocation { return pe.Loc }
+
+func (pe ParenthesizedExpression) IsZero() bool {
+	return pe.Loc.IsZero() && (pe.Expression == nil || pe.Expression.IsZero())
+}
+
+func (pe ParenthesizedExpression) Walk(v Visitor) {
+	if v = v.Visit(pe); v != nil {
+		defer v.Visit(nil)
+		if pe.Expression != nil {
+			pe.Expression.Walk(v)
+		}
+	}
+}
+
+func (pe ParenthesizedExpression) Errors() []error {
+	c := nodeChecker{Node: pe}
+	c.require(pe.Expression, "parenthesized expression")
+	return c.errors()
+}
+
+func (pe ParenthesizedExpression) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(pe)
+	x["expression"] = pe.Expression
+	return json.Marshal(x)
+}
+
+func (pe *ParenthesizedExpression) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type       string          `json:"type"`
+		Expression json.RawMessage `json:"expression"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != pe.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, pe.Type(), x.Type)
+	}
+	if err == nil {
+		pe.Loc = x.Location()
+		pe.Expression, _, err = unmarshalExpression(x.Expression)
+	}
+	return err
+}
+
+// MetaProperty is a meta property expression, i.e. new.target or
+// import.meta.
+type MetaProperty struct {
+	baseExpression
+	Loc      SourceLocation
+	Meta     Identifier
+	Property Identifier
+}
+
+func (MetaProperty) Type() string                { return "MetaProperty" }
+func (mp MetaProperty) Location() SourceLocation { return mp.Loc }
+
+func (mp MetaProperty) MinVersion() Version {
+	if mp.Meta.Name == "import" {
+		return ES2020
+	}
+	return ES2015
+}
+
+func (mp MetaProperty) IsZero() bool {
+	return mp.Loc.IsZero() && mp.Meta.IsZero() && mp.Property.IsZero()
+}
+
+func (mp MetaProperty) Walk(v Visitor) {
+	if v = v.Visit(mp); v != nil {
+		defer v.Visit(nil)
+		mp.Meta.Walk(v)
+		mp.Property.Walk(v)
+	}
+}
+
+// Errors checks the MetaProperty.  new.target is only allowed within a
+// non-arrow function, and import.meta within a module; since that can't be
+// determined without the ancestor Nodes, use Validate to check placement.
+func (mp MetaProperty) Errors() []error {
+	return mp.errors(true)
+}
+
+func (mp MetaProperty) contextErrors(ctx validationContext) []error {
+	switch mp.Meta.Name {
+	case "new":
+		return mp.errors(newTargetAllowed(ctx.ancestors))
+	case "import":
+		p, ok := rootNode(ctx.ancestors).(Program)
+		return mp.errors(ok && p.SourceType == Module)
+	}
+	return mp.errors(true)
+}
+
+func (mp MetaProperty) errors(allowed bool) []error {
+	c := nodeChecker{Node: mp}
+	c.require(mp.Meta, "meta")
+	c.require(mp.Property, "meta property")
+	switch {
+	case mp.Meta.Name == "new" && mp.Property.Name == "target":
+		if !allowed {
+			c.appendf("new.target outside of function %w", ErrNotAllowed)
+		}
+	case mp.Meta.Name == "import" && mp.Property.Name == "meta":
+		if !allowed {
+			c.appendf("import.meta outside of module %w", ErrNotAllowed)
+		}
+	case !mp.Meta.IsZero() && !mp.Property.IsZero():
+		c.appendf("%w meta property %s.%s", ErrWrongValue, mp.Meta.Name, mp.Property.Name)
+	}
+	return c.errors()
+}
+
+// newTargetAllowed indicates whether ancestors describe a position where
+// new.target may appear, i.e. inside a non-arrow function, class field
+// initializer, or static block.
+func newTargetAllowed(ancestors []Node) bool {
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		switch ancestors[i].(type) {
+		case ArrowFunctionExpression:
+			// Arrow functions inherit new.target from their enclosing scope.
+		case Function, PropertyDefinition, StaticBlock:
+			return true
+		}
+	}
+	return false
+}
+
+func (mp MetaProperty) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(mp)
+	x["meta"] = mp.Meta
+	x["property"] = mp.Property
+	return json.Marshal(x)
+}
+
+func (mp *MetaProperty) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type     string     `json:"type"`
+		Meta     Identifier `json:"meta"`
+		Property Identifier `json:"property"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != mp.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, mp.Type(), x.Type)
+	}
+	if err == nil {
+		mp.Loc, mp.Meta, mp.Property = x.Location(), x.Meta, x.Property
+	}
+	return err
+}
@@ -1,6 +1,8 @@
 package estree
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 )
 
@@ -63,7 +65,7 @@ func TestObjectExpression(t *testing.T) {
 		t.Error("expected !IsZero()")
 	}
 
-	oe.Properties = []Property{
+	oe.Properties = []PropertyOrSpread{
 		Property{
 			Key:   Identifier{Name: "foo"},
 			Value: StringLiteral{Value: "bar"},
@@ -83,11 +85,11 @@ func TestObjectExpression(t *testing.T) {
 	oe.Walk(&v)
 	v.expect(t, oe,
 		oe.Properties[0],
-		oe.Properties[0].Key, nil,
-		oe.Properties[0].Value, nil, nil,
+		oe.Properties[0].(Property).Key, nil,
+		oe.Properties[0].(Property).Value, nil, nil,
 		oe.Properties[1],
-		oe.Properties[1].Key, nil,
-		oe.Properties[1].Value, nil, nil, nil)
+		oe.Properties[1].(Property).Key, nil,
+		oe.Properties[1].(Property).Value, nil, nil, nil)
 
 	testRoundtripJSON(t, oe, new(ObjectExpression))
 
@@ -149,6 +151,77 @@ func TestProperty(t *testing.T) {
 	if !hasError(ErrWrongValue, p.Errors()...) {
 		t.Error("expected ErrWrongValue for invalid PropertyKind")
 	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := new(Property).UnmarshalJSON(b); !errors.Is(err, ErrWrongValue) {
+		t.Errorf("expected ErrWrongValue unmarshaling invalid PropertyKind, got %v", err)
+	}
+
+	p.Kind = Init
+
+	// {foo, [bar]: 1, baz() { super.qux; }}
+	p.Value, p.Shorthand = Identifier{Name: "foo"}, true
+	computed := Property{
+		Key:      Identifier{Name: "bar"},
+		Value:    NumberLiteral{Value: 1},
+		Kind:     Init,
+		Computed: true,
+	}
+	method := Property{
+		Key: Identifier{Name: "baz"},
+		Value: FunctionExpression{
+			Body: FunctionBody{
+				Body: []DirectiveOrStatement{
+					ExpressionStatement{
+						Expression: MemberExpression{
+							Object:   Super{},
+							Property: Identifier{Name: "qux"},
+						},
+					},
+				},
+			},
+		},
+		Kind:   Init,
+		Method: true,
+	}
+	for _, prop := range []Property{p, computed, method} {
+		if prop.MinVersion() != ES2015 {
+			t.Errorf("expected ES2015, got %s", prop.MinVersion())
+		}
+		testRoundtripJSON(t, prop, new(Property))
+	}
+	oe := ObjectExpression{Properties: []PropertyOrSpread{p, computed, method}}
+	testRoundtripJSON(t, oe, new(ObjectExpression))
+	if errs := Validate(oe); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	p.Value = Identifier{Name: "bar"}
+	if !hasError(ErrWrongValue, p.Errors()...) {
+		t.Error("expected ErrWrongValue for shorthand with different key and value")
+	}
+	computed.Key = BinaryExpression{
+		Operator: Add,
+		Left:     StringLiteral{Value: "b"},
+		Right:    StringLiteral{Value: "ar"},
+	}
+	if errs := computed.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	computed.Computed = false
+	if !hasError(ErrWrongValue, computed.Errors()...) {
+		t.Error("expected ErrWrongValue for Expression key without Computed")
+	}
+	method.Value = Identifier{Name: "baz"}
+	if !hasError(ErrNotAllowed, method.Errors()...) {
+		t.Error("expected ErrNotAllowed for method with Identifier value")
+	}
+	method.Value, method.Kind = FunctionExpression{}, Get
+	if !hasError(ErrWrongValue, method.Errors()...) {
+		t.Error("expected ErrWrongValue for method getter")
+	}
 }
 
 func TestFunctionExpression(t *testing.T) {
@@ -194,6 +267,65 @@ func TestFunctionExpression(t *testing.T) {
 	}
 }
 
+func TestArrowFunctionExpression(t *testing.T) {
+	var afe ArrowFunctionExpression
+	if !afe.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	afe.Params = []Pattern{
+		Identifier{Name: "foo"},
+	}
+	afe.Body = BinaryExpression{
+		Operator: Multiply,
+		Left:     Identifier{Name: "foo"},
+		Right:    NumberLiteral{Value: 2},
+	}
+	afe.Expression = true
+	if afe.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if afe.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", afe.MinVersion())
+	}
+
+	var v mockVisitor
+	afe.Walk(&v)
+	v.expect(t, afe,
+		afe.Params[0], nil,
+		afe.Body,
+		afe.Body.(BinaryExpression).Left, nil,
+		afe.Body.(BinaryExpression).Right, nil, nil, nil)
+
+	testRoundtripJSON(t, afe, new(ArrowFunctionExpression))
+
+	var f Function = afe
+	if body := f.FunctionBody().Body; len(body) != 1 || body[0].(ReturnStatement).Argument != afe.Body {
+		t.Errorf("expected ReturnStatement in FunctionBody, got %v", body)
+	}
+
+	if errs := afe.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	afe.Expression = false
+	if !hasError(ErrWrongValue, afe.Errors()...) {
+		t.Error("expected ErrWrongValue for Expression body without Expression flag")
+	}
+	afe.Body = FunctionBody{
+		Body: []DirectiveOrStatement{
+			ReturnStatement{},
+		},
+	}
+	testRoundtripJSON(t, afe, new(ArrowFunctionExpression))
+	if errs := afe.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	afe.Body = nil
+	if !hasError(ErrMissingNode, afe.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Body")
+	}
+}
+
 func TestConditionalExpression(t *testing.T) {
 	var ce ConditionalExpression
 	if !ce.IsZero() {
@@ -335,6 +467,14 @@ func TestNewExpression(t *testing.T) {
 
 	testRoundtripJSON(t, ne, new(NewExpression))
 
+	withLoc := ne
+	withLoc.Loc = loc(1, 9, 22)
+	withLoc.Loc.Range = Range{Start: 9, End: 22}
+	withLoc.Loc.Comments = &NodeComments{
+		LeadingComments: []Comment{{Loc: loc(1, 0, 8), Kind: Block, Value: " x "}},
+	}
+	testRoundtripJSON(t, withLoc, new(NewExpression))
+
 	if errs := ne.Errors(); len(errs) != 0 {
 		t.Fatalf("unexpected errors: %v", errs)
 	}
@@ -392,3 +532,341 @@ func TestSequenceExpression(t *testing.T) {
 		t.Error("expected ErrMissingNode for nil Expression")
 	}
 }
+
+func TestGeneratorAndAsyncFunctions(t *testing.T) {
+	fe := FunctionExpression{Generator: true}
+	if fe.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if fe.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", fe.MinVersion())
+	}
+	fe.Async = true
+	if fe.MinVersion() != ES2018 {
+		t.Errorf("expected ES2018, got %s", fe.MinVersion())
+	}
+	testRoundtripJSON(t, fe, new(FunctionExpression))
+
+	fd := FunctionDeclaration{ID: Identifier{Name: "foo"}, Async: true}
+	if fd.MinVersion() != ES2017 {
+		t.Errorf("expected ES2017, got %s", fd.MinVersion())
+	}
+	testRoundtripJSON(t, fd, new(FunctionDeclaration))
+
+	afe := ArrowFunctionExpression{Body: FunctionBody{}, Async: true}
+	if afe.MinVersion() != ES2017 {
+		t.Errorf("expected ES2017, got %s", afe.MinVersion())
+	}
+	testRoundtripJSON(t, afe, new(ArrowFunctionExpression))
+	if errs := afe.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	afe.Generator = true
+	if !hasError(ErrNotAllowed, afe.Errors()...) {
+		t.Error("expected ErrNotAllowed for generator arrow function")
+	}
+
+	var f Function = fe
+	if !f.FunctionGenerator() || !f.FunctionAsync() {
+		t.Error("expected FunctionGenerator() and FunctionAsync()")
+	}
+}
+
+func TestSpreadElement(t *testing.T) {
+	var se SpreadElement
+	if !se.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	se.Argument = Identifier{Name: "foo"}
+	if se.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if se.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", se.MinVersion())
+	}
+
+	var v mockVisitor
+	se.Walk(&v)
+	v.expect(t, se, se.Argument, nil, nil)
+
+	testRoundtripJSON(t, se, new(SpreadElement))
+
+	if errs := se.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	ae := ArrayExpression{Elements: []ExpressionOrArrayHole{se, ArrayHole{}}}
+	if ae.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", ae.MinVersion())
+	}
+	testRoundtripJSON(t, ae, new(ArrayExpression))
+
+	ce := CallExpression{
+		Callee:    Identifier{Name: "bar"},
+		Arguments: []ExpressionOrSpread{NullLiteral{}, se},
+	}
+	if ce.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", ce.MinVersion())
+	}
+	testRoundtripJSON(t, ce, new(CallExpression))
+
+	ne := NewExpression{
+		Callee:    Identifier{Name: "Bar"},
+		Arguments: []ExpressionOrSpread{se},
+	}
+	if ne.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", ne.MinVersion())
+	}
+
+	oe := ObjectExpression{
+		Properties: []PropertyOrSpread{
+			se,
+			Property{
+				Key:   Identifier{Name: "baz"},
+				Value: BoolLiteral{Value: true},
+				Kind:  Init,
+			},
+		},
+	}
+	if oe.MinVersion() != ES2018 {
+		t.Errorf("expected ES2018, got %s", oe.MinVersion())
+	}
+	testRoundtripJSON(t, oe, new(ObjectExpression))
+	if errs := Validate(oe); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	se.Argument = nil
+	if !hasError(ErrMissingNode, se.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Argument")
+	}
+}
+
+func TestChainExpression(t *testing.T) {
+	var ce ChainExpression
+	if !ce.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// a?.b.c()
+	ce.Expression = CallExpression{
+		Callee: MemberExpression{
+			Object: MemberExpression{
+				Object:   Identifier{Name: "a"},
+				Property: Identifier{Name: "b"},
+				Optional: true,
+			},
+			Property: Identifier{Name: "c"},
+		},
+	}
+	if ce.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if ce.MinVersion() != ES2020 {
+		t.Errorf("expected ES2020, got %s", ce.MinVersion())
+	}
+	inner := ce.Expression.(CallExpression).Callee.(MemberExpression).Object.(MemberExpression)
+	if inner.MinVersion() != ES2020 {
+		t.Errorf("expected ES2020, got %s", inner.MinVersion())
+	}
+
+	var v mockVisitor
+	ce.Walk(&v)
+	v.expect(t, ce,
+		ce.Expression,
+		ce.Expression.(CallExpression).Callee,
+		inner,
+		inner.Object, nil,
+		inner.Property, nil, nil,
+		ce.Expression.(CallExpression).Callee.(MemberExpression).Property, nil,
+		nil, nil, nil)
+
+	testRoundtripJSON(t, ce, new(ChainExpression))
+
+	if errs := Validate(ce); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !hasError(ErrNotAllowed, Validate(inner)...) {
+		t.Error("expected ErrNotAllowed for optional member outside chain")
+	}
+	if !hasError(ErrNotAllowed, Validate(CallExpression{
+		Callee:   Identifier{Name: "f"},
+		Optional: true,
+	})...) {
+		t.Error("expected ErrNotAllowed for optional call outside chain")
+	}
+	if !hasError(ErrNotAllowed, Validate(ExpressionStatement{
+		Expression: ArrayExpression{
+			Elements: []ExpressionOrArrayHole{inner},
+		},
+	})...) {
+		t.Error("expected ErrNotAllowed for optional member in array")
+	}
+	// a?.b(c.d?.e): the argument is not part of the chain.
+	arg := MemberExpression{
+		Object: MemberExpression{
+			Object:   Identifier{Name: "c"},
+			Property: Identifier{Name: "d"},
+		},
+		Property: Identifier{Name: "e"},
+		Optional: true,
+	}
+	if !hasError(ErrNotAllowed, Validate(ChainExpression{
+		Expression: CallExpression{
+			Callee:    inner,
+			Arguments: []ExpressionOrSpread{arg},
+		},
+	})...) {
+		t.Error("expected ErrNotAllowed for optional member in argument")
+	}
+	// a?.[c.d?.e]: neither is a computed property.
+	if !hasError(ErrNotAllowed, Validate(ChainExpression{
+		Expression: MemberExpression{
+			Object:   Identifier{Name: "a"},
+			Property: arg,
+			Computed: true,
+			Optional: true,
+		},
+	})...) {
+		t.Error("expected ErrNotAllowed for optional member in computed property")
+	}
+	// a?.b[a?.b]: the property is not part of the chain, even though it is
+	// identical to the object.
+	ab := MemberExpression{
+		Object:   Identifier{Name: "a"},
+		Property: Identifier{Name: "b"},
+		Optional: true,
+	}
+	if !hasError(ErrNotAllowed, Validate(ChainExpression{
+		Expression: MemberExpression{
+			Object:   ab,
+			Property: ab,
+			Computed: true,
+		},
+	})...) {
+		t.Error("expected ErrNotAllowed for computed property identical to object")
+	}
+	if errs := Validate(ChainExpression{
+		Expression: CallExpression{
+			Callee:    inner,
+			Arguments: []ExpressionOrSpread{ChainExpression{Expression: arg}},
+		},
+	}); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	ae := AssignmentExpression{
+		Operator: Assign,
+		Left:     ce,
+		Right:    NullLiteral{},
+	}
+	if !hasError(ErrNotAllowed, ae.Errors()...) {
+		t.Error("expected ErrNotAllowed for chain as assignment target")
+	}
+
+	ce.Expression = nil
+	if !hasError(ErrMissingNode, ce.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Expression")
+	}
+}
+
+func TestParenthesizedExpression(t *testing.T) {
+	var pe ParenthesizedExpression
+	if !pe.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	pe.Expression = Identifier{Name: "foo"}
+	if pe.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if pe.MinVersion() != ES5 {
+		t.Errorf("expected ES5, got %s", pe.MinVersion())
+	}
+
+	var v mockVisitor
+	pe.Walk(&v)
+	v.expect(t, pe, pe.Expression, nil, nil)
+
+	testRoundtripJSON(t, pe, new(ParenthesizedExpression))
+
+	if errs := pe.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	pe.Expression = nil
+	if !hasError(ErrMissingNode, pe.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Expression")
+	}
+}
+
+func TestMetaProperty(t *testing.T) {
+	var mp MetaProperty
+	if !mp.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	mp.Meta = Identifier{Name: "new"}
+	mp.Property = Identifier{Name: "target"}
+	if mp.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if mp.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", mp.MinVersion())
+	}
+
+	var v mockVisitor
+	mp.Walk(&v)
+	v.expect(t, mp, mp.Meta, nil, mp.Property, nil, nil)
+
+	testRoundtripJSON(t, mp, new(MetaProperty))
+
+	if errs := mp.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !hasError(ErrNotAllowed, Validate(mp)...) {
+		t.Error("expected ErrNotAllowed for new.target outside of function")
+	}
+	fe := FunctionExpression{
+		Body: FunctionBody{
+			Body: []DirectiveOrStatement{
+				ReturnStatement{
+					Argument: ArrowFunctionExpression{Body: mp, Expression: true},
+				},
+			},
+		},
+	}
+	if errs := Validate(fe); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !hasError(ErrNotAllowed, Validate(ArrowFunctionExpression{Body: mp, Expression: true})...) {
+		t.Error("expected ErrNotAllowed for new.target in top-level arrow function")
+	}
+
+	mp.Meta = Identifier{Name: "import"}
+	mp.Property = Identifier{Name: "meta"}
+	if mp.MinVersion() != ES2020 {
+		t.Errorf("expected ES2020, got %s", mp.MinVersion())
+	}
+	p := Program{
+		Body: []DirectiveOrStatement{
+			ExpressionStatement{Expression: mp},
+		},
+	}
+	if !hasError(ErrNotAllowed, Validate(p)...) {
+		t.Error("expected ErrNotAllowed for import.meta in script")
+	}
+	p.SourceType = Module
+	if errs := Validate(p); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	mp.Property = Identifier{Name: "target"}
+	if !hasError(ErrWrongValue, mp.Errors()...) {
+		t.Error("expected ErrWrongValue for import.target")
+	}
+	mp.Meta = Identifier{}
+	if !hasError(ErrMissingNode, mp.Errors()...) {
+		t.Error("expected ErrMissingNode for zero Meta")
+	}
+}
@@ -0,0 +1,432 @@
+package estree
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// This file provides the hooks used by packages which extend ESTree with
+// additional Node types, such as pifke.org/estree/ts.
+//
+// An extension Node implements the interfaces of this package by embedding
+// one of the exported base types below, and is decoded from JSON by
+// registering an unmarshal function for its Type.
+
+// BaseExpression may be embedded by an extension Node to implement
+// Expression.
+type BaseExpression struct {
+	baseExpression
+}
+
+// BaseStatement may be embedded by an extension Node to implement Statement.
+type BaseStatement struct {
+	baseStatement
+}
+
+// BaseDeclaration may be embedded by an extension Node to implement
+// Declaration.
+type BaseDeclaration struct {
+	baseDeclaration
+}
+
+// BasePattern may be embedded by an extension Node to implement Pattern.
+type BasePattern struct {
+	basePattern
+}
+
+// TypeAnnotation is a type annotation, which may be attached to an
+// Identifier, pattern, function return type, or class field.  This package
+// does not implement any type annotations; see the ts and flow subpackages.
+//
+// Type annotations are not part of ESTree, but are used by the TypeScript and
+// Flow extensions.  The TypeAnnotation fields of Nodes in this package are
+// nil for plain ESTree.
+type TypeAnnotation interface {
+	Node
+	isTypeAnnotation()
+}
+
+// BaseTypeAnnotation may be embedded by an extension Node to implement
+// TypeAnnotation.
+type BaseTypeAnnotation struct{}
+
+func (BaseTypeAnnotation) isTypeAnnotation() {}
+
+// TypeParameterDeclaration is the list of type parameters of a generic
+// function or class, e.g. the <T> in function f<T>(x: T) {}.  Like
+// TypeAnnotation, it is not part of ESTree, and is implemented by extension
+// packages.
+type TypeParameterDeclaration interface {
+	Node
+	isTypeParameterDeclaration()
+}
+
+// BaseTypeParameterDeclaration may be embedded by an extension Node to
+// implement TypeParameterDeclaration.
+type BaseTypeParameterDeclaration struct{}
+
+func (BaseTypeParameterDeclaration) isTypeParameterDeclaration() {}
+
+// TypeParameterInstantiation is the list of type arguments passed to a generic
+// function or class, e.g. the <string> in useState<string>().  Like
+// TypeAnnotation, it is not part of ESTree, and is implemented by extension
+// packages.
+type TypeParameterInstantiation interface {
+	Node
+	isTypeParameterInstantiation()
+}
+
+// BaseTypeParameterInstantiation may be embedded by an extension Node to
+// implement TypeParameterInstantiation.
+type BaseTypeParameterInstantiation struct{}
+
+func (BaseTypeParameterInstantiation) isTypeParameterInstantiation() {}
+
+// BaseClassElement may be embedded by an extension Node to implement
+// ClassElement.
+type BaseClassElement struct{}
+
+func (BaseClassElement) isClassElement() {}
+
+// BaseMethodValue may be embedded by an extension Node to implement
+// MethodValue.
+type BaseMethodValue struct{}
+
+func (BaseMethodValue) isMethodValue() {}
+
+// ClassImplements is an entry in the implements clause of a class, e.g. the
+// Bar in class Foo implements Bar {}.  Like TypeAnnotation, it is not part of
+// ESTree, and is implemented by extension packages.
+type ClassImplements interface {
+	Node
+	isClassImplements()
+}
+
+// BaseClassImplements may be embedded by an extension Node to implement
+// ClassImplements.
+type BaseClassImplements struct{}
+
+func (BaseClassImplements) isClassImplements() {}
+
+var (
+	extensionExpressions     = make(map[string]func(json.RawMessage) (Expression, error))
+	extensionStatements      = make(map[string]func(json.RawMessage) (Statement, error))
+	extensionPatterns        = make(map[string]func(json.RawMessage) (Pattern, error))
+	extensionTypeAnnotations = make(map[string]func(json.RawMessage) (TypeAnnotation, error))
+	extensionTypeParameters  = make(map[string]func(json.RawMessage) (TypeParameterDeclaration, error))
+	extensionTypeArguments   = make(map[string]func(json.RawMessage) (TypeParameterInstantiation, error))
+	extensionClassElements   = make(map[string]func(json.RawMessage) (ClassElement, error))
+	extensionMethodValues    = make(map[string]func(json.RawMessage) (MethodValue, error))
+	extensionImplements      = make(map[string]func(json.RawMessage) (ClassImplements, error))
+)
+
+// RegisterExpression makes an extension Expression available for decoding
+// from JSON, wherever an Expression is allowed.  It is intended to be called
+// from the init function of the extension package, and panics if typ is
+// already registered.
+func RegisterExpression(typ string, unmarshal func(json.RawMessage) (Expression, error)) {
+	if _, dup := extensionExpressions[typ]; dup {
+		panic("estree: RegisterExpression called twice for " + typ)
+	}
+	extensionExpressions[typ] = unmarshal
+}
+
+// RegisterStatement makes an extension Statement (including a Declaration)
+// available for decoding from JSON, wherever a Statement is allowed.  It is
+// intended to be called from the init function of the extension package, and
+// panics if typ is already registered.
+func RegisterStatement(typ string, unmarshal func(json.RawMessage) (Statement, error)) {
+	if _, dup := extensionStatements[typ]; dup {
+		panic("estree: RegisterStatement called twice for " + typ)
+	}
+	extensionStatements[typ] = unmarshal
+}
+
+// RegisterPattern makes an extension Pattern available for decoding from
+// JSON, wherever a Pattern is allowed.  It is intended to be called from the
+// init function of the extension package, and panics if typ is already
+// registered.
+func RegisterPattern(typ string, unmarshal func(json.RawMessage) (Pattern, error)) {
+	if _, dup := extensionPatterns[typ]; dup {
+		panic("estree: RegisterPattern called twice for " + typ)
+	}
+	extensionPatterns[typ] = unmarshal
+}
+
+// RegisterClassElement makes an extension ClassElement available for decoding
+// from JSON, wherever a ClassElement is allowed.  It is intended to be called
+// from the init function of the extension package, and panics if typ is
+// already registered.
+func RegisterClassElement(typ string, unmarshal func(json.RawMessage) (ClassElement, error)) {
+	if _, dup := extensionClassElements[typ]; dup {
+		panic("estree: RegisterClassElement called twice for " + typ)
+	}
+	extensionClassElements[typ] = unmarshal
+}
+
+// RegisterMethodValue makes an extension MethodValue available for decoding
+// from JSON, as the Value of a MethodDefinition.  It is intended to be called
+// from the init function of the extension package, and panics if typ is
+// already registered.
+func RegisterMethodValue(typ string, unmarshal func(json.RawMessage) (MethodValue, error)) {
+	if _, dup := extensionMethodValues[typ]; dup {
+		panic("estree: RegisterMethodValue called twice for " + typ)
+	}
+	extensionMethodValues[typ] = unmarshal
+}
+
+// RegisterClassImplements makes an extension ClassImplements available for
+// decoding from JSON.  It is intended to be called from the init function of
+// the extension package, and panics if typ is already registered.
+func RegisterClassImplements(typ string, unmarshal func(json.RawMessage) (ClassImplements, error)) {
+	if _, dup := extensionImplements[typ]; dup {
+		panic("estree: RegisterClassImplements called twice for " + typ)
+	}
+	extensionImplements[typ] = unmarshal
+}
+
+// RegisterTypeAnnotation makes an extension TypeAnnotation available for
+// decoding from JSON.  It is intended to be called from the init function of
+// the extension package, and panics if typ is already registered.
+func RegisterTypeAnnotation(typ string, unmarshal func(json.RawMessage) (TypeAnnotation, error)) {
+	if _, dup := extensionTypeAnnotations[typ]; dup {
+		panic("estree: RegisterTypeAnnotation called twice for " + typ)
+	}
+	extensionTypeAnnotations[typ] = unmarshal
+}
+
+// RegisterTypeParameterDeclaration makes an extension
+// TypeParameterDeclaration available for decoding from JSON.  It is intended
+// to be called from the init function of the extension package, and panics
+// if typ is already registered.
+func RegisterTypeParameterDeclaration(typ string, unmarshal func(json.RawMessage) (TypeParameterDeclaration, error)) {
+	if _, dup := extensionTypeParameters[typ]; dup {
+		panic("estree: RegisterTypeParameterDeclaration called twice for " + typ)
+	}
+	extensionTypeParameters[typ] = unmarshal
+}
+
+// RegisterTypeParameterInstantiation makes an extension
+// TypeParameterInstantiation available for decoding from JSON.  It is
+// intended to be called from the init function of the extension package, and
+// panics if typ is already registered.
+func RegisterTypeParameterInstantiation(typ string, unmarshal func(json.RawMessage) (TypeParameterInstantiation, error)) {
+	if _, dup := extensionTypeArguments[typ]; dup {
+		panic("estree: RegisterTypeParameterInstantiation called twice for " + typ)
+	}
+	extensionTypeArguments[typ] = unmarshal
+}
+
+func unmarshalTypeAnnotation(m json.RawMessage) (TypeAnnotation, error) {
+	if isNullOrEmptyRawMessage(m) {
+		return nil, nil
+	}
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(m, &x); err != nil {
+		return nil, err
+	}
+	if unmarshal, ok := extensionTypeAnnotations[x.Type]; ok {
+		ta, err := unmarshal(m)
+		if err != nil {
+			return nil, err
+		}
+		return ta, nil
+	}
+	return nil, fmt.Errorf("%w TypeAnnotation, got %v", ErrWrongType, string(m))
+}
+
+func unmarshalTypeParameterDeclaration(m json.RawMessage) (TypeParameterDeclaration, error) {
+	if isNullOrEmptyRawMessage(m) {
+		return nil, nil
+	}
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(m, &x); err != nil {
+		return nil, err
+	}
+	if unmarshal, ok := extensionTypeParameters[x.Type]; ok {
+		tpd, err := unmarshal(m)
+		if err != nil {
+			return nil, err
+		}
+		return tpd, nil
+	}
+	return nil, fmt.Errorf("%w TypeParameterDeclaration, got %v", ErrWrongType, string(m))
+}
+
+func unmarshalTypeParameterInstantiation(m json.RawMessage) (TypeParameterInstantiation, error) {
+	if isNullOrEmptyRawMessage(m) {
+		return nil, nil
+	}
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(m, &x); err != nil {
+		return nil, err
+	}
+	if unmarshal, ok := extensionTypeArguments[x.Type]; ok {
+		tpi, err := unmarshal(m)
+		if err != nil {
+			return nil, err
+		}
+		return tpi, nil
+	}
+	return nil, fmt.Errorf("%w TypeParameterInstantiation, got %v", ErrWrongType, string(m))
+}
+
+func unmarshalClassImplements(m json.RawMessage) (ClassImplements, error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(m, &x); err != nil {
+		return nil, err
+	}
+	if unmarshal, ok := extensionImplements[x.Type]; ok {
+		ci, err := unmarshal(m)
+		if err != nil {
+			return nil, err
+		}
+		return ci, nil
+	}
+	return nil, fmt.Errorf("%w ClassImplements, got %v", ErrWrongType, string(m))
+}
+
+// unmarshalImplements decodes the implements clause of a class, returning the
+// first error encountered.
+func unmarshalImplements(ms []json.RawMessage) ([]ClassImplements, error) {
+	if len(ms) == 0 {
+		return nil, nil
+	}
+	var err error
+	impls := make([]ClassImplements, len(ms))
+	for i := range ms {
+		var err2 error
+		impls[i], err2 = unmarshalClassImplements(ms[i])
+		if err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return impls, err
+}
+
+// unmarshalTypeArguments decodes type arguments, e.g. of a call or superclass.
+// typescript-estree stores these in typeArguments (or superTypeArguments),
+// while its older versions, Babel, and Flow may use typeParameters (or
+// superTypeParameters); the former is preferred if both are present.
+func unmarshalTypeArguments(typeArguments, typeParameters json.RawMessage) (TypeParameterInstantiation, error) {
+	if isNullOrEmptyRawMessage(typeArguments) {
+		return unmarshalTypeParameterInstantiation(typeParameters)
+	}
+	return unmarshalTypeParameterInstantiation(typeArguments)
+}
+
+// UnmarshalExpression decodes any Expression, including registered
+// extensions.  It returns nil if m is null or empty.
+func UnmarshalExpression(m json.RawMessage) (Expression, error) {
+	e, _, err := unmarshalExpression(m)
+	return e, err
+}
+
+// UnmarshalStatement decodes any Statement, including registered
+// extensions.  It returns nil if m is null or empty.
+func UnmarshalStatement(m json.RawMessage) (Statement, error) {
+	s, _, err := unmarshalStatement(m)
+	return s, err
+}
+
+// UnmarshalPattern decodes any Pattern, including registered extensions.
+// It returns nil if m is null or empty.
+func UnmarshalPattern(m json.RawMessage) (Pattern, error) {
+	p, _, err := unmarshalPattern(m)
+	return p, err
+}
+
+// UnmarshalExpressionOrPrivateIdentifier decodes any Expression, including
+// registered extensions, or a PrivateIdentifier, e.g. the key of a class
+// member.
+func UnmarshalExpressionOrPrivateIdentifier(m json.RawMessage) (ExpressionOrPrivateIdentifier, error) {
+	return unmarshalExpressionOrPrivateIdentifier(m)
+}
+
+// UnmarshalDirectiveOrStatement decodes any Directive, Statement, or
+// ModuleDeclaration, including registered extensions.
+func UnmarshalDirectiveOrStatement(m json.RawMessage) (DirectiveOrStatement, error) {
+	return unmarshalDirectiveOrStatement(m)
+}
+
+// UnmarshalLiteral decodes any Literal.
+func UnmarshalLiteral(m json.RawMessage) (Literal, error) {
+	l, _, err := unmarshalLiteral(m)
+	return l, err
+}
+
+// UnmarshalTypeAnnotation decodes a registered TypeAnnotation.  It returns
+// nil if m is null or empty.
+func UnmarshalTypeAnnotation(m json.RawMessage) (TypeAnnotation, error) {
+	return unmarshalTypeAnnotation(m)
+}
+
+// UnmarshalTypeParameterDeclaration decodes a registered
+// TypeParameterDeclaration.  It returns nil if m is null or empty.
+func UnmarshalTypeParameterDeclaration(m json.RawMessage) (TypeParameterDeclaration, error) {
+	return unmarshalTypeParameterDeclaration(m)
+}
+
+// UnmarshalTypeParameterInstantiation decodes a registered
+// TypeParameterInstantiation.  It returns nil if m is null or empty.
+func UnmarshalTypeParameterInstantiation(m json.RawMessage) (TypeParameterInstantiation, error) {
+	return unmarshalTypeParameterInstantiation(m)
+}
+
+// UnmarshalClassImplements decodes a registered ClassImplements.
+func UnmarshalClassImplements(m json.RawMessage) (ClassImplements, error) {
+	return unmarshalClassImplements(m)
+}
+
+// NodeToMap returns a map containing the fields common to every Node when
+// marshaled to JSON, to which an extension Node's MarshalJSON adds its own
+// fields.
+func NodeToMap(n Node) map[string]interface{} {
+	return nodeToMap(n)
+}
+
+// Checker accumulates SyntaxErrors for an extension Node's Errors method, in
+// the same manner as the Nodes of this package.
+type Checker struct {
+	c nodeChecker
+}
+
+// NewChecker returns a Checker for n.
+func NewChecker(n Node) *Checker {
+	return &Checker{c: nodeChecker{Node: n}}
+}
+
+// Require reports ErrMissingNode if n is nil or zero.
+func (c *Checker) Require(n Node, what string) {
+	c.c.require(n, what)
+}
+
+// RequireEach reports ErrMissingNode for each element of a slice of Nodes
+// which is nil or zero.
+func (c *Checker) RequireEach(length int, index func(i int) Node, what string) {
+	c.c.requireEach(nodeSlice{Index: index, Len: length}, what)
+}
+
+// Optional notes the location of n, which may be nil, for positioning
+// subsequent errors.
+func (c *Checker) Optional(n Node) {
+	c.c.optional(n)
+}
+
+// Appendf reports an error at the current location.
+func (c *Checker) Appendf(format string, args ...interface{}) {
+	c.c.appendf(format, args...)
+}
+
+// Errors returns the accumulated errors.
+func (c *Checker) Errors() []error {
+	return c.c.errors()
+}
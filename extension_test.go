@@ -0,0 +1,140 @@
+package estree
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// mockTypeAnnotation is a minimal extension Node, registered for testing.
+type mockTypeAnnotation struct {
+	BaseTypeAnnotation
+	Name string
+}
+
+func (mockTypeAnnotation) Type() string             { return "MockTypeAnnotation" }
+func (mockTypeAnnotation) Location() SourceLocation { return SourceLocation{} }
+func (mockTypeAnnotation) MinVersion() Version      { return ES5 }
+func (mta mockTypeAnnotation) IsZero() bool         { return mta.Name == "" }
+func (mta mockTypeAnnotation) Walk(v Visitor)       { v.Visit(mta); v.Visit(nil) }
+func (mta mockTypeAnnotation) Errors() []error      { return NewChecker(mta).Errors() }
+func (mta *mockTypeAnnotation) UnmarshalJSON(b []byte) error {
+	var x struct {
+		Name string `json:"name"`
+	}
+	err := json.Unmarshal(b, &x)
+	mta.Name = x.Name
+	return err
+}
+
+func (mta mockTypeAnnotation) MarshalJSON() ([]byte, error) {
+	x := NodeToMap(mta)
+	x["name"] = mta.Name
+	return json.Marshal(x)
+}
+
+type mockExpression struct {
+	BaseExpression
+	Argument Expression
+}
+
+func (mockExpression) Type() string             { return "MockExpression" }
+func (mockExpression) Location() SourceLocation { return SourceLocation{} }
+func (me mockExpression) IsZero() bool          { return me.Argument == nil }
+func (me mockExpression) Walk(v Visitor)        { v.Visit(me); v.Visit(nil) }
+
+func (me mockExpression) Errors() []error {
+	c := NewChecker(me)
+	c.Require(me.Argument, "argument")
+	return c.Errors()
+}
+
+func (me mockExpression) MarshalJSON() ([]byte, error) {
+	x := NodeToMap(me)
+	x["argument"] = me.Argument
+	return json.Marshal(x)
+}
+
+func init() {
+	RegisterTypeAnnotation("MockTypeAnnotation", func(m json.RawMessage) (TypeAnnotation, error) {
+		var mta mockTypeAnnotation
+		err := json.Unmarshal(m, &mta)
+		return mta, err
+	})
+	RegisterExpression("MockExpression", func(m json.RawMessage) (Expression, error) {
+		var x struct {
+			Argument json.RawMessage `json:"argument"`
+		}
+		err := json.Unmarshal(m, &x)
+		var me mockExpression
+		if err == nil {
+			me.Argument, err = UnmarshalExpression(x.Argument)
+		}
+		return me, err
+	})
+}
+
+func TestRegisterExpression(t *testing.T) {
+	es := ExpressionStatement{
+		Expression: mockExpression{Argument: Identifier{Name: "foo"}},
+	}
+	testRoundtripJSON(t, es, new(ExpressionStatement))
+
+	es.Expression = mockExpression{}
+	if !hasError(ErrMissingNode, es.Expression.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Argument")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for duplicate registration")
+		}
+	}()
+	RegisterExpression("MockExpression", nil)
+}
+
+func TestTypeAnnotation(t *testing.T) {
+	i := Identifier{
+		Name:           "foo",
+		TypeAnnotation: mockTypeAnnotation{Name: "string"},
+		Optional:       true,
+	}
+	if i.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	var v mockVisitor
+	i.Walk(&v)
+	v.expect(t, i, i.TypeAnnotation, nil, nil)
+
+	testRoundtripJSON(t, i, new(Identifier))
+	testRoundtripJSON(t, Identifier{Optional: true}, new(Identifier))
+
+	afe := ArrowFunctionExpression{
+		Params:     []Pattern{i},
+		Body:       FunctionBody{},
+		ReturnType: mockTypeAnnotation{Name: "void"},
+	}
+	v = mockVisitor{}
+	afe.Walk(&v)
+	v.expect(t, afe,
+		i, i.TypeAnnotation, nil, nil,
+		afe.ReturnType, nil,
+		afe.Body, nil, nil)
+	testRoundtripJSON(t, afe, new(ArrowFunctionExpression))
+
+	pd := PropertyDefinition{
+		Key:            Identifier{Name: "bar"},
+		TypeAnnotation: mockTypeAnnotation{Name: "number"},
+	}
+	testRoundtripJSON(t, pd, new(PropertyDefinition))
+	testRoundtripJSON(t, ArrayPattern{TypeAnnotation: pd.TypeAnnotation}, new(ArrayPattern))
+	testRoundtripJSON(t, ObjectPattern{TypeAnnotation: pd.TypeAnnotation}, new(ObjectPattern))
+	testRoundtripJSON(t, RestElement{
+		Argument:       Identifier{Name: "baz"},
+		TypeAnnotation: pd.TypeAnnotation,
+	}, new(RestElement))
+
+	if _, err := UnmarshalTypeAnnotation([]byte(`{"type":"Identifier","name":"foo"}`)); !hasError(ErrWrongType, err) {
+		t.Errorf("expected ErrWrongType, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package flow
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"pifke.org/estree"
+)
+
+// ClassImplements is an entry in the implements clause of a class, e.g. the
+// Bar<T> in class Foo<T> implements Bar<T> {}.
+type ClassImplements struct {
+	estree.BaseClassImplements
+	Loc            estree.SourceLocation
+	ID             estree.Identifier
+	TypeParameters TypeParameterInstantiation // possibly zero
+}
+
+func (ClassImplements) Type() string                       { return "ClassImplements" }
+func (ci ClassImplements) Location() estree.SourceLocation { return ci.Loc }
+func (ClassImplements) MinVersion() estree.Version         { return estree.ES5 }
+
+func (ci ClassImplements) IsZero() bool {
+	return ci.Loc.IsZero() && ci.ID.IsZero() && ci.TypeParameters.IsZero()
+}
+
+func (ci ClassImplements) Walk(v estree.Visitor) {
+	if v = v.Visit(ci); v != nil {
+		defer v.Visit(nil)
+		ci.ID.Walk(v)
+		if !ci.TypeParameters.IsZero() {
+			ci.TypeParameters.Walk(v)
+		}
+	}
+}
+
+func (ci ClassImplements) Errors() []error {
+	c := estree.NewChecker(ci)
+	c.Require(ci.ID, "implemented interface")
+	c.Optional(ci.TypeParameters)
+	return c.Errors()
+}
+
+func (ci ClassImplements) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ci)
+	x["id"] = ci.ID
+	if ci.TypeParameters.IsZero() {
+		x["typeParameters"] = nil
+	} else {
+		x["typeParameters"] = ci.TypeParameters
+	}
+	return json.Marshal(x)
+}
+
+func (ci *ClassImplements) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string                     `json:"type"`
+		ID             estree.Identifier          `json:"id"`
+		TypeParameters TypeParameterInstantiation `json:"typeParameters"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ci.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ci.Type(), x.Type)
+	}
+	if err == nil {
+		ci.Loc, ci.ID, ci.TypeParameters = x.Location(), x.ID, x.TypeParameters
+	}
+	return err
+}
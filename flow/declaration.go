@@ -0,0 +1,856 @@
+package flow
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"pifke.org/estree"
+)
+
+// TypeParameter declares a generic type parameter, e.g. the T: Foo = Bar in
+// type Baz<T: Foo = Bar>.
+type TypeParameter struct {
+	Loc      estree.SourceLocation
+	Name     string
+	Bound    TypeAnnotation // possibly zero
+	Default  FlowType       // or nil
+	Variance Variance       // possibly zero
+}
+
+func (TypeParameter) Type() string                       { return "TypeParameter" }
+func (tp TypeParameter) Location() estree.SourceLocation { return tp.Loc }
+func (TypeParameter) MinVersion() estree.Version         { return estree.ES5 }
+
+func (tp TypeParameter) IsZero() bool {
+	return tp.Loc.IsZero() &&
+		tp.Name == "" &&
+		tp.Bound.IsZero() &&
+		(tp.Default == nil || tp.Default.IsZero()) &&
+		tp.Variance.IsZero()
+}
+
+func (tp TypeParameter) Walk(v estree.Visitor) {
+	if v = v.Visit(tp); v != nil {
+		defer v.Visit(nil)
+		if !tp.Variance.IsZero() {
+			tp.Variance.Walk(v)
+		}
+		if !tp.Bound.IsZero() {
+			tp.Bound.Walk(v)
+		}
+		if tp.Default != nil {
+			tp.Default.Walk(v)
+		}
+	}
+}
+
+func (tp TypeParameter) Errors() []error {
+	c := estree.NewChecker(tp)
+	if tp.Name == "" {
+		c.Appendf("%w empty type parameter name", estree.ErrWrongValue)
+	}
+	c.Optional(tp.Bound)
+	c.Optional(tp.Default)
+	c.Optional(tp.Variance)
+	return c.Errors()
+}
+
+func (tp TypeParameter) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(tp)
+	x["name"] = tp.Name
+	if tp.Bound.IsZero() {
+		x["bound"] = nil
+	} else {
+		x["bound"] = tp.Bound
+	}
+	x["default"] = tp.Default
+	x["variance"] = varianceToJSON(tp.Variance)
+	return json.Marshal(x)
+}
+
+func (tp *TypeParameter) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type     string          `json:"type"`
+		Name     string          `json:"name"`
+		Bound    TypeAnnotation  `json:"bound"`
+		Default  json.RawMessage `json:"default"`
+		Variance Variance        `json:"variance"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tp.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tp.Type(), x.Type)
+	}
+	if err == nil {
+		tp.Loc, tp.Name, tp.Bound, tp.Variance = x.Location(), x.Name, x.Bound, x.Variance
+		tp.Default, err = unmarshalType(x.Default)
+	}
+	return err
+}
+
+// TypeParameterDeclaration is the list of type parameters of a generic
+// declaration, e.g. the <K, V> in type Map<K, V>.  It is also the
+// TypeParameters of a generic estree function or class.
+type TypeParameterDeclaration struct {
+	estree.BaseTypeParameterDeclaration
+	Loc    estree.SourceLocation
+	Params []TypeParameter
+}
+
+func (TypeParameterDeclaration) Type() string { return "TypeParameterDeclaration" }
+func (tpd TypeParameterDeclaration) Location() estree.SourceLocation {
+	return tpd.Loc
+}
+func (TypeParameterDeclaration) MinVersion() estree.Version { return estree.ES5 }
+
+func (tpd TypeParameterDeclaration) IsZero() bool {
+	return tpd.Loc.IsZero() && len(tpd.Params) == 0
+}
+
+func (tpd TypeParameterDeclaration) Walk(v estree.Visitor) {
+	if v = v.Visit(tpd); v != nil {
+		defer v.Visit(nil)
+		for _, p := range tpd.Params {
+			p.Walk(v)
+		}
+	}
+}
+
+func (tpd TypeParameterDeclaration) Errors() []error {
+	c := estree.NewChecker(tpd)
+	if len(tpd.Params) == 0 {
+		c.Appendf("%w type parameter", estree.ErrMissingNode)
+	}
+	c.RequireEach(len(tpd.Params), func(i int) estree.Node { return tpd.Params[i] }, "type parameter")
+	return c.Errors()
+}
+
+func (tpd TypeParameterDeclaration) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(tpd)
+	x["params"] = tpd.Params
+	return json.Marshal(x)
+}
+
+func (tpd *TypeParameterDeclaration) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		return nil // optional type parameters are null when absent
+	}
+	var x struct {
+		estree.NodeFields
+
+		Type   string          `json:"type"`
+		Params []TypeParameter `json:"params"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tpd.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tpd.Type(), x.Type)
+	}
+	if err == nil {
+		tpd.Loc, tpd.Params = x.Location(), x.Params
+	}
+	return err
+}
+
+// typeParametersToJSON returns the JSON representation of optional type
+// parameters, which are null when absent.
+func typeParametersToJSON(tpd TypeParameterDeclaration) interface{} {
+	if tpd.IsZero() {
+		return nil
+	}
+	return tpd
+}
+
+// TypeAlias declares a named type, e.g. type Foo = string.
+type TypeAlias struct {
+	estree.BaseDeclaration
+	Loc            estree.SourceLocation
+	ID             estree.Identifier
+	TypeParameters TypeParameterDeclaration // possibly zero
+	Right          FlowType
+}
+
+func (TypeAlias) Type() string                       { return "TypeAlias" }
+func (ta TypeAlias) Location() estree.SourceLocation { return ta.Loc }
+
+func (ta TypeAlias) IsZero() bool {
+	return ta.Loc.IsZero() &&
+		ta.ID.IsZero() &&
+		ta.TypeParameters.IsZero() &&
+		(ta.Right == nil || ta.Right.IsZero())
+}
+
+func (ta TypeAlias) Walk(v estree.Visitor) {
+	if v = v.Visit(ta); v != nil {
+		defer v.Visit(nil)
+		ta.ID.Walk(v)
+		if !ta.TypeParameters.IsZero() {
+			ta.TypeParameters.Walk(v)
+		}
+		if ta.Right != nil {
+			ta.Right.Walk(v)
+		}
+	}
+}
+
+func (ta TypeAlias) Errors() []error {
+	c := estree.NewChecker(ta)
+	c.Require(ta.ID, "type name")
+	c.Optional(ta.TypeParameters)
+	c.Require(ta.Right, "type")
+	return c.Errors()
+}
+
+func (ta TypeAlias) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ta)
+	x["id"] = ta.ID
+	x["typeParameters"] = typeParametersToJSON(ta.TypeParameters)
+	x["right"] = ta.Right
+	return json.Marshal(x)
+}
+
+func (ta *TypeAlias) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string                   `json:"type"`
+		ID             estree.Identifier        `json:"id"`
+		TypeParameters TypeParameterDeclaration `json:"typeParameters"`
+		Right          json.RawMessage          `json:"right"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ta.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ta.Type(), x.Type)
+	}
+	if err == nil {
+		ta.Loc, ta.ID, ta.TypeParameters = x.Location(), x.ID, x.TypeParameters
+		ta.Right, err = unmarshalType(x.Right)
+	}
+	return err
+}
+
+// DeclareTypeAlias declares a named type in a declaration file or
+// DeclareModule, e.g. declare type Foo = string.
+type DeclareTypeAlias struct {
+	estree.BaseDeclaration
+	Loc            estree.SourceLocation
+	ID             estree.Identifier
+	TypeParameters TypeParameterDeclaration // possibly zero
+	Right          FlowType
+}
+
+func (DeclareTypeAlias) Type() string                        { return "DeclareTypeAlias" }
+func (dta DeclareTypeAlias) Location() estree.SourceLocation { return dta.Loc }
+
+func (dta DeclareTypeAlias) IsZero() bool {
+	return dta.Loc.IsZero() &&
+		dta.ID.IsZero() &&
+		dta.TypeParameters.IsZero() &&
+		(dta.Right == nil || dta.Right.IsZero())
+}
+
+func (dta DeclareTypeAlias) Walk(v estree.Visitor) {
+	if v = v.Visit(dta); v != nil {
+		defer v.Visit(nil)
+		dta.ID.Walk(v)
+		if !dta.TypeParameters.IsZero() {
+			dta.TypeParameters.Walk(v)
+		}
+		if dta.Right != nil {
+			dta.Right.Walk(v)
+		}
+	}
+}
+
+func (dta DeclareTypeAlias) Errors() []error {
+	c := estree.NewChecker(dta)
+	c.Require(dta.ID, "type name")
+	c.Optional(dta.TypeParameters)
+	c.Require(dta.Right, "type")
+	return c.Errors()
+}
+
+func (dta DeclareTypeAlias) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(dta)
+	x["id"] = dta.ID
+	x["typeParameters"] = typeParametersToJSON(dta.TypeParameters)
+	x["right"] = dta.Right
+	return json.Marshal(x)
+}
+
+func (dta *DeclareTypeAlias) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string                   `json:"type"`
+		ID             estree.Identifier        `json:"id"`
+		TypeParameters TypeParameterDeclaration `json:"typeParameters"`
+		Right          json.RawMessage          `json:"right"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != dta.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, dta.Type(), x.Type)
+	}
+	if err == nil {
+		dta.Loc, dta.ID, dta.TypeParameters = x.Location(), x.ID, x.TypeParameters
+		dta.Right, err = unmarshalType(x.Right)
+	}
+	return err
+}
+
+// OpaqueType declares a type whose underlying type is hidden outside the
+// defining module, e.g. opaque type Token: string = string.
+type OpaqueType struct {
+	estree.BaseDeclaration
+	Loc            estree.SourceLocation
+	ID             estree.Identifier
+	TypeParameters TypeParameterDeclaration // possibly zero
+	Supertype      FlowType                 // or nil
+	Impltype       FlowType
+}
+
+func (OpaqueType) Type() string                       { return "OpaqueType" }
+func (ot OpaqueType) Location() estree.SourceLocation { return ot.Loc }
+
+func (ot OpaqueType) IsZero() bool {
+	return ot.Loc.IsZero() &&
+		ot.ID.IsZero() &&
+		ot.TypeParameters.IsZero() &&
+		(ot.Supertype == nil || ot.Supertype.IsZero()) &&
+		(ot.Impltype == nil || ot.Impltype.IsZero())
+}
+
+func (ot OpaqueType) Walk(v estree.Visitor) {
+	if v = v.Visit(ot); v != nil {
+		defer v.Visit(nil)
+		ot.ID.Walk(v)
+		if !ot.TypeParameters.IsZero() {
+			ot.TypeParameters.Walk(v)
+		}
+		if ot.Supertype != nil {
+			ot.Supertype.Walk(v)
+		}
+		if ot.Impltype != nil {
+			ot.Impltype.Walk(v)
+		}
+	}
+}
+
+func (ot OpaqueType) Errors() []error {
+	c := estree.NewChecker(ot)
+	c.Require(ot.ID, "type name")
+	c.Optional(ot.TypeParameters)
+	c.Optional(ot.Supertype)
+	c.Require(ot.Impltype, "underlying type")
+	return c.Errors()
+}
+
+func (ot OpaqueType) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ot)
+	x["id"] = ot.ID
+	x["typeParameters"] = typeParametersToJSON(ot.TypeParameters)
+	x["supertype"] = ot.Supertype
+	x["impltype"] = ot.Impltype
+	return json.Marshal(x)
+}
+
+func (ot *OpaqueType) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string                   `json:"type"`
+		ID             estree.Identifier        `json:"id"`
+		TypeParameters TypeParameterDeclaration `json:"typeParameters"`
+		Supertype      json.RawMessage          `json:"supertype"`
+		Impltype       json.RawMessage          `json:"impltype"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ot.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ot.Type(), x.Type)
+	}
+	if err == nil {
+		ot.Loc, ot.ID, ot.TypeParameters = x.Location(), x.ID, x.TypeParameters
+		ot.Supertype, err = unmarshalType(x.Supertype)
+		var err2 error
+		if ot.Impltype, err2 = unmarshalType(x.Impltype); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// DeclareVariable declares the type of a variable defined elsewhere, e.g.
+// declare var foo: number.  The type is ID.TypeAnnotation.
+type DeclareVariable struct {
+	estree.BaseDeclaration
+	Loc estree.SourceLocation
+	ID  estree.Identifier
+}
+
+func (DeclareVariable) Type() string                       { return "DeclareVariable" }
+func (dv DeclareVariable) Location() estree.SourceLocation { return dv.Loc }
+
+func (dv DeclareVariable) IsZero() bool {
+	return dv.Loc.IsZero() && dv.ID.IsZero()
+}
+
+func (dv DeclareVariable) Walk(v estree.Visitor) {
+	if v = v.Visit(dv); v != nil {
+		defer v.Visit(nil)
+		dv.ID.Walk(v)
+	}
+}
+
+func (dv DeclareVariable) Errors() []error {
+	c := estree.NewChecker(dv)
+	c.Require(dv.ID, "variable name")
+	return c.Errors()
+}
+
+func (dv DeclareVariable) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(dv)
+	x["id"] = dv.ID
+	return json.Marshal(x)
+}
+
+func (dv *DeclareVariable) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type string            `json:"type"`
+		ID   estree.Identifier `json:"id"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != dv.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, dv.Type(), x.Type)
+	}
+	if err == nil {
+		dv.Loc, dv.ID = x.Location(), x.ID
+	}
+	return err
+}
+
+// DeclareFunction declares the type of a function defined elsewhere, e.g.
+// declare function foo(x: number): string.  The type is ID.TypeAnnotation,
+// which must be a FunctionTypeAnnotation.
+type DeclareFunction struct {
+	estree.BaseDeclaration
+	Loc estree.SourceLocation
+	ID  estree.Identifier
+}
+
+func (DeclareFunction) Type() string                       { return "DeclareFunction" }
+func (df DeclareFunction) Location() estree.SourceLocation { return df.Loc }
+
+func (df DeclareFunction) IsZero() bool {
+	return df.Loc.IsZero() && df.ID.IsZero()
+}
+
+func (df DeclareFunction) Walk(v estree.Visitor) {
+	if v = v.Visit(df); v != nil {
+		defer v.Visit(nil)
+		df.ID.Walk(v)
+	}
+}
+
+func (df DeclareFunction) Errors() []error {
+	c := estree.NewChecker(df)
+	c.Require(df.ID, "function name")
+	ta, _ := df.ID.TypeAnnotation.(TypeAnnotation)
+	if _, ok := ta.TypeAnnotation.(FunctionTypeAnnotation); !ok {
+		c.Appendf("%w declared function without FunctionTypeAnnotation", estree.ErrWrongValue)
+	}
+	return c.Errors()
+}
+
+func (df DeclareFunction) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(df)
+	x["id"] = df.ID
+	x["predicate"] = nil
+	return json.Marshal(x)
+}
+
+func (df *DeclareFunction) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type string            `json:"type"`
+		ID   estree.Identifier `json:"id"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != df.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, df.Type(), x.Type)
+	}
+	if err == nil {
+		df.Loc, df.ID = x.Location(), x.ID
+	}
+	return err
+}
+
+// InterfaceExtends is an entry in the extends clause of an interface or
+// declared class, e.g. the Bar<T> in interface Foo<T> extends Bar<T> {}.
+type InterfaceExtends struct {
+	Loc            estree.SourceLocation
+	ID             estree.Node                // Identifier or QualifiedTypeIdentifier
+	TypeParameters TypeParameterInstantiation // possibly zero
+}
+
+func (InterfaceExtends) Type() string                       { return "InterfaceExtends" }
+func (ie InterfaceExtends) Location() estree.SourceLocation { return ie.Loc }
+func (InterfaceExtends) MinVersion() estree.Version         { return estree.ES5 }
+
+func (ie InterfaceExtends) IsZero() bool {
+	return ie.Loc.IsZero() &&
+		(ie.ID == nil || ie.ID.IsZero()) &&
+		ie.TypeParameters.IsZero()
+}
+
+func (ie InterfaceExtends) Walk(v estree.Visitor) {
+	if v = v.Visit(ie); v != nil {
+		defer v.Visit(nil)
+		if ie.ID != nil {
+			ie.ID.Walk(v)
+		}
+		if !ie.TypeParameters.IsZero() {
+			ie.TypeParameters.Walk(v)
+		}
+	}
+}
+
+func (ie InterfaceExtends) Errors() []error {
+	c := estree.NewChecker(ie)
+	c.Require(ie.ID, "extended interface")
+	checkTypeIdentifier(c, ie.ID, "extended interface")
+	c.Optional(ie.TypeParameters)
+	return c.Errors()
+}
+
+func (ie InterfaceExtends) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ie)
+	x["id"] = ie.ID
+	if ie.TypeParameters.IsZero() {
+		x["typeParameters"] = nil
+	} else {
+		x["typeParameters"] = ie.TypeParameters
+	}
+	return json.Marshal(x)
+}
+
+func (ie *InterfaceExtends) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string                     `json:"type"`
+		ID             json.RawMessage            `json:"id"`
+		TypeParameters TypeParameterInstantiation `json:"typeParameters"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ie.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ie.Type(), x.Type)
+	}
+	if err == nil {
+		ie.Loc, ie.TypeParameters = x.Location(), x.TypeParameters
+		ie.ID, err = unmarshalTypeIdentifier(x.ID)
+	}
+	return err
+}
+
+// interfaceExtendsToJSON returns the JSON representation of an extends
+// clause, which is an empty list when absent.
+func interfaceExtendsToJSON(ies []InterfaceExtends) interface{} {
+	if len(ies) == 0 {
+		return []InterfaceExtends{}
+	}
+	return ies
+}
+
+// InterfaceDeclaration declares an interface, e.g.
+// interface Foo<T> extends Bar<T> { baz: T }.
+type InterfaceDeclaration struct {
+	estree.BaseDeclaration
+	Loc            estree.SourceLocation
+	ID             estree.Identifier
+	TypeParameters TypeParameterDeclaration // possibly zero
+	Extends        []InterfaceExtends
+	Body           ObjectTypeAnnotation
+}
+
+func (InterfaceDeclaration) Type() string                       { return "InterfaceDeclaration" }
+func (id InterfaceDeclaration) Location() estree.SourceLocation { return id.Loc }
+
+func (id InterfaceDeclaration) IsZero() bool {
+	return id.Loc.IsZero() &&
+		id.ID.IsZero() &&
+		id.TypeParameters.IsZero() &&
+		len(id.Extends) == 0 &&
+		len(id.Body.Properties) == 0
+}
+
+func (id InterfaceDeclaration) Walk(v estree.Visitor) {
+	if v = v.Visit(id); v != nil {
+		defer v.Visit(nil)
+		id.ID.Walk(v)
+		if !id.TypeParameters.IsZero() {
+			id.TypeParameters.Walk(v)
+		}
+		for _, e := range id.Extends {
+			e.Walk(v)
+		}
+		id.Body.Walk(v)
+	}
+}
+
+func (id InterfaceDeclaration) Errors() []error {
+	c := estree.NewChecker(id)
+	c.Require(id.ID, "interface name")
+	c.Optional(id.TypeParameters)
+	c.RequireEach(len(id.Extends), func(i int) estree.Node { return id.Extends[i] }, "extended interface")
+	c.Require(id.Body, "interface body")
+	return c.Errors()
+}
+
+func (id InterfaceDeclaration) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(id)
+	x["id"] = id.ID
+	x["typeParameters"] = typeParametersToJSON(id.TypeParameters)
+	x["extends"] = interfaceExtendsToJSON(id.Extends)
+	x["body"] = id.Body
+	return json.Marshal(x)
+}
+
+func (id *InterfaceDeclaration) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string                   `json:"type"`
+		ID             estree.Identifier        `json:"id"`
+		TypeParameters TypeParameterDeclaration `json:"typeParameters"`
+		Extends        []InterfaceExtends       `json:"extends"`
+		Body           ObjectTypeAnnotation     `json:"body"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != id.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, id.Type(), x.Type)
+	}
+	if err == nil {
+		id.Loc, id.ID, id.TypeParameters, id.Body = x.Location(), x.ID, x.TypeParameters, x.Body
+		if len(x.Extends) == 0 {
+			id.Extends = nil
+		} else {
+			id.Extends = x.Extends
+		}
+	}
+	return err
+}
+
+// DeclareClass declares the type of a class defined elsewhere, e.g.
+// declare class Foo extends Bar mixins Baz implements Qux { static x: number }.
+type DeclareClass struct {
+	estree.BaseDeclaration
+	Loc            estree.SourceLocation
+	ID             estree.Identifier
+	TypeParameters TypeParameterDeclaration // possibly zero
+	Extends        []InterfaceExtends       // at most one
+	Mixins         []InterfaceExtends
+	Implements     []ClassImplements
+	Body           ObjectTypeAnnotation
+}
+
+func (DeclareClass) Type() string                       { return "DeclareClass" }
+func (dc DeclareClass) Location() estree.SourceLocation { return dc.Loc }
+
+func (dc DeclareClass) IsZero() bool {
+	return dc.Loc.IsZero() &&
+		dc.ID.IsZero() &&
+		dc.TypeParameters.IsZero() &&
+		len(dc.Extends) == 0 &&
+		len(dc.Mixins) == 0 &&
+		len(dc.Implements) == 0 &&
+		len(dc.Body.Properties) == 0
+}
+
+func (dc DeclareClass) Walk(v estree.Visitor) {
+	if v = v.Visit(dc); v != nil {
+		defer v.Visit(nil)
+		dc.ID.Walk(v)
+		if !dc.TypeParameters.IsZero() {
+			dc.TypeParameters.Walk(v)
+		}
+		for _, e := range dc.Extends {
+			e.Walk(v)
+		}
+		for _, m := range dc.Mixins {
+			m.Walk(v)
+		}
+		for _, i := range dc.Implements {
+			i.Walk(v)
+		}
+		dc.Body.Walk(v)
+	}
+}
+
+func (dc DeclareClass) Errors() []error {
+	c := estree.NewChecker(dc)
+	c.Require(dc.ID, "class name")
+	c.Optional(dc.TypeParameters)
+	if len(dc.Extends) > 1 {
+		c.Appendf("more than one superclass %w", estree.ErrNotAllowed)
+	}
+	c.RequireEach(len(dc.Extends), func(i int) estree.Node { return dc.Extends[i] }, "superclass")
+	c.RequireEach(len(dc.Mixins), func(i int) estree.Node { return dc.Mixins[i] }, "mixin")
+	c.RequireEach(len(dc.Implements), func(i int) estree.Node { return dc.Implements[i] }, "implemented interface")
+	c.Require(dc.Body, "class body")
+	return c.Errors()
+}
+
+func (dc DeclareClass) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(dc)
+	x["id"] = dc.ID
+	x["typeParameters"] = typeParametersToJSON(dc.TypeParameters)
+	x["extends"] = interfaceExtendsToJSON(dc.Extends)
+	x["mixins"] = interfaceExtendsToJSON(dc.Mixins)
+	if len(dc.Implements) == 0 {
+		x["implements"] = []ClassImplements{}
+	} else {
+		x["implements"] = dc.Implements
+	}
+	x["body"] = dc.Body
+	return json.Marshal(x)
+}
+
+func (dc *DeclareClass) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string                   `json:"type"`
+		ID             estree.Identifier        `json:"id"`
+		TypeParameters TypeParameterDeclaration `json:"typeParameters"`
+		Extends        []InterfaceExtends       `json:"extends"`
+		Mixins         []InterfaceExtends       `json:"mixins"`
+		Implements     []ClassImplements        `json:"implements"`
+		Body           ObjectTypeAnnotation     `json:"body"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != dc.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, dc.Type(), x.Type)
+	}
+	if err == nil {
+		dc.Loc, dc.ID, dc.TypeParameters, dc.Body = x.Location(), x.ID, x.TypeParameters, x.Body
+		dc.Extends, dc.Mixins, dc.Implements = nil, nil, nil
+		if len(x.Extends) != 0 {
+			dc.Extends = x.Extends
+		}
+		if len(x.Mixins) != 0 {
+			dc.Mixins = x.Mixins
+		}
+		if len(x.Implements) != 0 {
+			dc.Implements = x.Implements
+		}
+	}
+	return err
+}
+
+// ModuleKind indicates how a DeclareModule exports its bindings.
+type ModuleKind string
+
+const (
+	CommonJS ModuleKind = "CommonJS"
+	ES       ModuleKind = "ES"
+)
+
+func (mk ModuleKind) IsValid() bool {
+	switch mk {
+	case CommonJS, ES:
+		return true
+	}
+	return false
+}
+
+// DeclareModule declares the types exported by a module defined elsewhere,
+// e.g. declare module "foo" { declare var bar: string; }.
+type DeclareModule struct {
+	estree.BaseDeclaration
+	Loc  estree.SourceLocation
+	ID   estree.LiteralOrIdentifier // Identifier or StringLiteral
+	Body estree.BlockStatement
+	Kind ModuleKind // possibly empty
+}
+
+func (DeclareModule) Type() string                       { return "DeclareModule" }
+func (dm DeclareModule) Location() estree.SourceLocation { return dm.Loc }
+
+func (dm DeclareModule) IsZero() bool {
+	return dm.Loc.IsZero() &&
+		(dm.ID == nil || dm.ID.IsZero()) &&
+		len(dm.Body.Body) == 0 &&
+		dm.Kind == ""
+}
+
+func (dm DeclareModule) Walk(v estree.Visitor) {
+	if v = v.Visit(dm); v != nil {
+		defer v.Visit(nil)
+		if dm.ID != nil {
+			dm.ID.Walk(v)
+		}
+		dm.Body.Walk(v)
+	}
+}
+
+func (dm DeclareModule) Errors() []error {
+	c := estree.NewChecker(dm)
+	c.Require(dm.ID, "module name")
+	switch dm.ID.(type) {
+	case nil, estree.Identifier, estree.StringLiteral:
+	default:
+		c.Appendf("%w module name %s", estree.ErrWrongValue, dm.ID.Type())
+	}
+	c.Require(dm.Body, "module body")
+	for _, s := range dm.Body.Body {
+		switch s.(type) {
+		case nil, DeclareVariable, DeclareFunction, DeclareClass, DeclareTypeAlias,
+			TypeAlias, OpaqueType, InterfaceDeclaration:
+		default:
+			c.Appendf("%s in declared module %w", s.Type(), estree.ErrNotAllowed)
+		}
+	}
+	if dm.Kind != "" && !dm.Kind.IsValid() {
+		c.Appendf("%w DeclareModule.Kind %q", estree.ErrWrongValue, dm.Kind)
+	}
+	return c.Errors()
+}
+
+func (dm DeclareModule) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(dm)
+	x["id"] = dm.ID
+	x["body"] = dm.Body
+	if dm.Kind != "" {
+		x["kind"] = dm.Kind
+	}
+	return json.Marshal(x)
+}
+
+func (dm *DeclareModule) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type string                `json:"type"`
+		ID   json.RawMessage       `json:"id"`
+		Body estree.BlockStatement `json:"body"`
+		Kind ModuleKind            `json:"kind"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != dm.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, dm.Type(), x.Type)
+	}
+	if err == nil {
+		dm.Loc, dm.Body, dm.Kind = x.Location(), x.Body, x.Kind
+		var id estree.Expression
+		if id, err = estree.UnmarshalExpression(x.ID); err == nil && id != nil {
+			var ok bool
+			if dm.ID, ok = id.(estree.LiteralOrIdentifier); !ok {
+				err = fmt.Errorf("%w Identifier or Literal, got %v", estree.ErrWrongType, string(x.ID))
+			}
+		}
+	}
+	return err
+}
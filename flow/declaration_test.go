@@ -0,0 +1,362 @@
+package flow
+
+import (
+	"encoding/json"
+	"testing"
+
+	"pifke.org/estree"
+)
+
+func TestTypeAlias(t *testing.T) {
+	var ta TypeAlias
+	if !ta.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// type Foo<T: Bar = string> = ?T
+	ta.ID = estree.Identifier{Name: "Foo"}
+	ta.TypeParameters.Params = []TypeParameter{
+		TypeParameter{
+			Name:    "T",
+			Bound:   TypeAnnotation{TypeAnnotation: GenericTypeAnnotation{ID: estree.Identifier{Name: "Bar"}}},
+			Default: PrimitiveTypeAnnotation{Kind: String},
+		},
+	}
+	ta.Right = NullableTypeAnnotation{
+		TypeAnnotation: GenericTypeAnnotation{ID: estree.Identifier{Name: "T"}},
+	}
+	if ta.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if ta.MinVersion() != estree.ES5 {
+		t.Errorf("expected ES5, got %s", ta.MinVersion())
+	}
+
+	tp := ta.TypeParameters.Params[0]
+	var v mockVisitor
+	ta.Walk(&v)
+	v.expect(t, ta,
+		ta.ID, nil,
+		ta.TypeParameters, tp, tp.Bound, tp.Bound.TypeAnnotation,
+		estree.Identifier{Name: "Bar"}, nil, nil, nil, tp.Default, nil, nil, nil,
+		ta.Right, GenericTypeAnnotation{ID: estree.Identifier{Name: "T"}},
+		estree.Identifier{Name: "T"}, nil, nil, nil, nil)
+
+	testRoundtripJSON(t, ta, new(TypeAlias))
+
+	if errs := estree.Validate(ta); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !hasError(estree.ErrWrongValue, TypeParameter{Default: tp.Default}.Errors()...) {
+		t.Error("expected ErrWrongValue for empty type parameter name")
+	}
+	ta.TypeParameters.Params[0] = TypeParameter{}
+	if !hasError(estree.ErrMissingNode, ta.TypeParameters.Errors()...) {
+		t.Error("expected ErrMissingNode for zero type parameter")
+	}
+	ta.Right = nil
+	if !hasError(estree.ErrMissingNode, ta.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Right")
+	}
+}
+
+func TestOpaqueType(t *testing.T) {
+	var ot OpaqueType
+	if !ot.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// opaque type Token: string = string
+	ot.ID = estree.Identifier{Name: "Token"}
+	ot.Supertype = PrimitiveTypeAnnotation{Kind: String}
+	ot.Impltype = PrimitiveTypeAnnotation{Kind: String}
+	if ot.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	var v mockVisitor
+	ot.Walk(&v)
+	v.expect(t, ot, ot.ID, nil, ot.Supertype, nil, ot.Impltype, nil, nil)
+
+	testRoundtripJSON(t, ot, new(OpaqueType))
+
+	if errs := estree.Validate(ot); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ot.Supertype = nil
+	if errs := ot.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ot.Impltype = nil
+	if !hasError(estree.ErrMissingNode, ot.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Impltype")
+	}
+}
+
+func TestDeclareFunction(t *testing.T) {
+	var df DeclareFunction
+	if !df.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// declare function foo(x: number): string
+	df.ID = estree.Identifier{
+		Name: "foo",
+		TypeAnnotation: TypeAnnotation{TypeAnnotation: FunctionTypeAnnotation{
+			Params: []FunctionTypeParam{
+				FunctionTypeParam{
+					Name:           estree.Identifier{Name: "x"},
+					TypeAnnotation: PrimitiveTypeAnnotation{Kind: Number},
+				},
+			},
+			ReturnType: PrimitiveTypeAnnotation{Kind: String},
+		}},
+	}
+	if df.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	fta := df.ID.TypeAnnotation.(TypeAnnotation).TypeAnnotation.(FunctionTypeAnnotation)
+	var v mockVisitor
+	df.Walk(&v)
+	v.expect(t, df,
+		df.ID, df.ID.TypeAnnotation, fta,
+		fta.Params[0], fta.Params[0].Name, nil, fta.Params[0].TypeAnnotation, nil, nil,
+		fta.ReturnType, nil, nil, nil, nil, nil)
+
+	testRoundtripJSON(t, df, new(DeclareFunction))
+
+	if errs := estree.Validate(df); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	df.ID.TypeAnnotation = TypeAnnotation{TypeAnnotation: PrimitiveTypeAnnotation{Kind: String}}
+	if !hasError(estree.ErrWrongValue, df.Errors()...) {
+		t.Error("expected ErrWrongValue for non-function type")
+	}
+	df.ID = estree.Identifier{}
+	if !hasError(estree.ErrMissingNode, df.Errors()...) {
+		t.Error("expected ErrMissingNode for zero ID")
+	}
+}
+
+func TestDeclareModule(t *testing.T) {
+	var dm DeclareModule
+	if !dm.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// declare module "foo" { declare var bar: string; }
+	dv := DeclareVariable{ID: estree.Identifier{
+		Name:           "bar",
+		TypeAnnotation: TypeAnnotation{TypeAnnotation: PrimitiveTypeAnnotation{Kind: String}},
+	}}
+	dm.ID = estree.StringLiteral{Value: "foo"}
+	dm.Body.Body = []estree.Statement{dv}
+	dm.Kind = CommonJS
+	if dm.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	var v mockVisitor
+	dm.Walk(&v)
+	v.expect(t, dm,
+		dm.ID, nil,
+		dm.Body, dv, dv.ID, dv.ID.TypeAnnotation, PrimitiveTypeAnnotation{Kind: String},
+		nil, nil, nil, nil, nil, nil)
+
+	testRoundtripJSON(t, dm, new(DeclareModule))
+	testRoundtripJSON(t, dv, new(DeclareVariable))
+
+	if errs := estree.Validate(dm); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	dm.Kind = "AMD"
+	if !hasError(estree.ErrWrongValue, dm.Errors()...) {
+		t.Error("expected ErrWrongValue for invalid Kind")
+	}
+	dm.Kind = ES
+	dm.Body.Body = append(dm.Body.Body, estree.ExpressionStatement{
+		Expression: estree.Identifier{Name: "baz"},
+	})
+	if !hasError(estree.ErrNotAllowed, dm.Errors()...) {
+		t.Error("expected ErrNotAllowed for ExpressionStatement in module body")
+	}
+	dm.ID = estree.NumberLiteral{Value: 1}
+	if !hasError(estree.ErrWrongValue, dm.Errors()...) {
+		t.Error("expected ErrWrongValue for NumberLiteral module name")
+	}
+}
+
+func TestInterfaceDeclaration(t *testing.T) {
+	var id InterfaceDeclaration
+	if !id.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// interface Foo<T> extends Bar.Baz<T> { +qux: T }
+	id.ID = estree.Identifier{Name: "Foo"}
+	id.TypeParameters.Params = []TypeParameter{TypeParameter{Name: "T"}}
+	id.Extends = []InterfaceExtends{
+		InterfaceExtends{
+			ID: QualifiedTypeIdentifier{
+				Qualification: estree.Identifier{Name: "Bar"},
+				ID:            estree.Identifier{Name: "Baz"},
+			},
+			TypeParameters: TypeParameterInstantiation{
+				Params: []FlowType{GenericTypeAnnotation{ID: estree.Identifier{Name: "T"}}},
+			},
+		},
+	}
+	id.Body.Properties = []ObjectTypeMember{
+		ObjectTypeProperty{
+			Key:      estree.Identifier{Name: "qux"},
+			Value:    GenericTypeAnnotation{ID: estree.Identifier{Name: "T"}},
+			Variance: Variance{Kind: Covariant},
+		},
+	}
+	if id.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	ie := id.Extends[0]
+	var v mockVisitor
+	ie.Walk(&v)
+	qti := ie.ID.(QualifiedTypeIdentifier)
+	v.expect(t, ie,
+		qti, qti.Qualification, nil, qti.ID, nil, nil,
+		ie.TypeParameters, ie.TypeParameters.Params[0], estree.Identifier{Name: "T"}, nil, nil, nil, nil)
+
+	testRoundtripJSON(t, id, new(InterfaceDeclaration))
+	testRoundtripJSON(t, ie, new(InterfaceExtends))
+
+	if errs := estree.Validate(id); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	id.Extends[0].ID = estree.StringLiteral{Value: "Bar"}
+	if !hasError(estree.ErrWrongValue, estree.Validate(id)...) {
+		t.Error("expected ErrWrongValue for StringLiteral extended interface")
+	}
+	id.ID = estree.Identifier{}
+	if !hasError(estree.ErrMissingNode, id.Errors()...) {
+		t.Error("expected ErrMissingNode for missing ID")
+	}
+}
+
+func TestDeclareClass(t *testing.T) {
+	var dc DeclareClass
+	if !dc.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// declare class Foo extends Bar mixins Baz implements Qux { static x: number }
+	dc.ID = estree.Identifier{Name: "Foo"}
+	dc.Extends = []InterfaceExtends{InterfaceExtends{ID: estree.Identifier{Name: "Bar"}}}
+	dc.Mixins = []InterfaceExtends{InterfaceExtends{ID: estree.Identifier{Name: "Baz"}}}
+	dc.Implements = []ClassImplements{ClassImplements{ID: estree.Identifier{Name: "Qux"}}}
+	dc.Body.Properties = []ObjectTypeMember{
+		ObjectTypeProperty{
+			Key:    estree.Identifier{Name: "x"},
+			Value:  PrimitiveTypeAnnotation{Kind: Number},
+			Static: true,
+		},
+	}
+	if dc.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	var v mockVisitor
+	dc.Walk(&v)
+	otp := dc.Body.Properties[0].(ObjectTypeProperty)
+	v.expect(t, dc,
+		dc.ID, nil,
+		dc.Extends[0], dc.Extends[0].ID, nil, nil,
+		dc.Mixins[0], dc.Mixins[0].ID, nil, nil,
+		dc.Implements[0], dc.Implements[0].ID, nil, nil,
+		dc.Body, otp, otp.Key, nil, otp.Value, nil, nil, nil, nil)
+
+	testRoundtripJSON(t, dc, new(DeclareClass))
+
+	if errs := estree.Validate(dc); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	dc.Extends = append(dc.Extends, InterfaceExtends{ID: estree.Identifier{Name: "Quux"}})
+	if !hasError(estree.ErrNotAllowed, dc.Errors()...) {
+		t.Error("expected ErrNotAllowed for more than one superclass")
+	}
+
+	// declare type Foo<T> = Array<T>
+	dta := DeclareTypeAlias{
+		ID:             estree.Identifier{Name: "Foo"},
+		TypeParameters: TypeParameterDeclaration{Params: []TypeParameter{TypeParameter{Name: "T"}}},
+		Right: GenericTypeAnnotation{
+			ID: estree.Identifier{Name: "Array"},
+			TypeParameters: TypeParameterInstantiation{
+				Params: []FlowType{GenericTypeAnnotation{ID: estree.Identifier{Name: "T"}}},
+			},
+		},
+	}
+	testRoundtripJSON(t, dta, new(DeclareTypeAlias))
+	if errs := estree.Validate(dta); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	dta.Right = nil
+	if !hasError(estree.ErrMissingNode, dta.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Right")
+	}
+
+	b := []byte(`{"type":"DeclareModule","id":{"type":"Literal","value":"foo"},` +
+		`"body":{"type":"BlockStatement","body":[` +
+		`{"type":"DeclareClass","id":{"type":"Identifier","name":"Foo"},"typeParameters":null,` +
+		`"extends":[],"mixins":[],"implements":[],"body":{"type":"ObjectTypeAnnotation",` +
+		`"properties":[],"indexers":[],"callProperties":[],"internalSlots":[],"exact":false}},` +
+		`{"type":"DeclareTypeAlias","id":{"type":"Identifier","name":"Bar"},"typeParameters":null,` +
+		`"right":{"type":"StringTypeAnnotation"}},` +
+		`{"type":"InterfaceDeclaration","id":{"type":"Identifier","name":"Baz"},"typeParameters":null,` +
+		`"extends":[],"body":{"type":"ObjectTypeAnnotation","properties":[],"indexers":[],` +
+		`"callProperties":[],"internalSlots":[],"exact":false}}]}}`)
+	var dm DeclareModule
+	if err := json.Unmarshal(b, &dm); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := dm.Body.Body[0].(DeclareClass); !ok {
+		t.Errorf("expected DeclareClass, got %#v", dm.Body.Body[0])
+	}
+	if _, ok := dm.Body.Body[1].(DeclareTypeAlias); !ok {
+		t.Errorf("expected DeclareTypeAlias, got %#v", dm.Body.Body[1])
+	}
+	if _, ok := dm.Body.Body[2].(InterfaceDeclaration); !ok {
+		t.Errorf("expected InterfaceDeclaration, got %#v", dm.Body.Body[2])
+	}
+	if errs := estree.Validate(dm); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestDeclarationDispatch(t *testing.T) {
+	b := []byte(`{"type":"Program","sourceType":"module","body":[` +
+		`{"type":"ExportNamedDeclaration","declaration":{"type":"TypeAlias",` +
+		`"id":{"type":"Identifier","name":"Foo"},"typeParameters":null,` +
+		`"right":{"type":"StringTypeAnnotation"}},"specifiers":[],"source":null},` +
+		`{"type":"DeclareModule","id":{"type":"Identifier","name":"bar"},` +
+		`"body":{"type":"BlockStatement","body":[{"type":"OpaqueType",` +
+		`"id":{"type":"Identifier","name":"Baz"},"typeParameters":null,"supertype":null,` +
+		`"impltype":{"type":"NumberTypeAnnotation"}}]},"kind":"ES"}]}`)
+	var p estree.Program
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatal(err)
+	}
+	end := p.Body[0].(estree.ExportNamedDeclaration)
+	if ta, ok := end.Declaration.(TypeAlias); !ok || ta.ID.Name != "Foo" {
+		t.Errorf("expected TypeAlias, got %#v", end.Declaration)
+	}
+	dm, ok := p.Body[1].(DeclareModule)
+	if !ok || dm.Kind != ES {
+		t.Fatalf("expected DeclareModule, got %#v", p.Body[1])
+	}
+	if _, ok := dm.Body.Body[0].(OpaqueType); !ok {
+		t.Errorf("expected OpaqueType, got %#v", dm.Body.Body[0])
+	}
+	if errs := estree.Validate(p); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
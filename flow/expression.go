@@ -0,0 +1,68 @@
+package flow
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"pifke.org/estree"
+)
+
+// TypeCastExpression asserts the type of an expression, e.g. (x: any).
+type TypeCastExpression struct {
+	estree.BaseExpression
+	Loc            estree.SourceLocation
+	Expression     estree.Expression
+	TypeAnnotation TypeAnnotation
+}
+
+func (TypeCastExpression) Type() string                        { return "TypeCastExpression" }
+func (tce TypeCastExpression) Location() estree.SourceLocation { return tce.Loc }
+
+func (tce TypeCastExpression) IsZero() bool {
+	return tce.Loc.IsZero() &&
+		(tce.Expression == nil || tce.Expression.IsZero()) &&
+		tce.TypeAnnotation.IsZero()
+}
+
+func (tce TypeCastExpression) Walk(v estree.Visitor) {
+	if v = v.Visit(tce); v != nil {
+		defer v.Visit(nil)
+		if tce.Expression != nil {
+			tce.Expression.Walk(v)
+		}
+		tce.TypeAnnotation.Walk(v)
+	}
+}
+
+func (tce TypeCastExpression) Errors() []error {
+	c := estree.NewChecker(tce)
+	c.Require(tce.Expression, "expression")
+	c.Require(tce.TypeAnnotation, "type annotation")
+	return c.Errors()
+}
+
+func (tce TypeCastExpression) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(tce)
+	x["expression"] = tce.Expression
+	x["typeAnnotation"] = tce.TypeAnnotation
+	return json.Marshal(x)
+}
+
+func (tce *TypeCastExpression) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string          `json:"type"`
+		Expression     json.RawMessage `json:"expression"`
+		TypeAnnotation TypeAnnotation  `json:"typeAnnotation"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tce.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tce.Type(), x.Type)
+	}
+	if err == nil {
+		tce.Loc, tce.TypeAnnotation = x.Location(), x.TypeAnnotation
+		tce.Expression, err = estree.UnmarshalExpression(x.Expression)
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package flow
+
+import (
+	"encoding/json"
+	"testing"
+
+	"pifke.org/estree"
+)
+
+func TestTypeCastExpression(t *testing.T) {
+	var tce TypeCastExpression
+	if !tce.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// (x: any)
+	tce.Expression = estree.Identifier{Name: "x"}
+	tce.TypeAnnotation = TypeAnnotation{TypeAnnotation: PrimitiveTypeAnnotation{Kind: Any}}
+	if tce.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if tce.MinVersion() != estree.ES5 {
+		t.Errorf("expected ES5, got %s", tce.MinVersion())
+	}
+
+	var v mockVisitor
+	tce.Walk(&v)
+	v.expect(t, tce,
+		tce.Expression, nil,
+		tce.TypeAnnotation, tce.TypeAnnotation.TypeAnnotation, nil, nil, nil)
+
+	testRoundtripJSON(t, tce, new(TypeCastExpression))
+
+	if errs := estree.Validate(tce); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	tce.TypeAnnotation = TypeAnnotation{}
+	if !hasError(estree.ErrMissingNode, tce.Errors()...) {
+		t.Error("expected ErrMissingNode for zero TypeAnnotation")
+	}
+	tce.Expression = nil
+	if !hasError(estree.ErrMissingNode, tce.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Expression")
+	}
+
+	b := []byte(`{"type":"ExpressionStatement","expression":{"type":"TypeCastExpression",` +
+		`"expression":{"type":"Identifier","name":"x"},"typeAnnotation":{"type":"TypeAnnotation",` +
+		`"typeAnnotation":{"type":"MixedTypeAnnotation"}}}}`)
+	var es estree.ExpressionStatement
+	if err := json.Unmarshal(b, &es); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := es.Expression.(TypeCastExpression); !ok {
+		t.Errorf("expected TypeCastExpression, got %#v", es.Expression)
+	}
+}
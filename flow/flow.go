@@ -0,0 +1,153 @@
+// Package flow implements the Flow extensions to ESTree, as produced by the
+// Flow parser and by Babel.
+//
+// Importing this package registers its declarations and expressions with
+// pifke.org/estree, so that they are decoded from JSON wherever a Statement or
+// Expression is allowed.  Type annotations are attached to estree Nodes (such
+// as Identifier.TypeAnnotation or FunctionDeclaration.ReturnType) using
+// TypeAnnotation.  The implements clause of a class is a list of
+// ClassImplements, and its superclass type arguments are carried by
+// estree.ClassDeclaration.SuperTypeArguments.
+package flow
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"pifke.org/estree"
+)
+
+func init() {
+	estree.RegisterTypeAnnotation("TypeAnnotation", func(m json.RawMessage) (estree.TypeAnnotation, error) {
+		var ta TypeAnnotation
+		err := json.Unmarshal(m, &ta)
+		return ta, err
+	})
+	estree.RegisterTypeParameterDeclaration("TypeParameterDeclaration", func(m json.RawMessage) (estree.TypeParameterDeclaration, error) {
+		var tpd TypeParameterDeclaration
+		err := json.Unmarshal(m, &tpd)
+		return tpd, err
+	})
+	estree.RegisterTypeParameterInstantiation("TypeParameterInstantiation", func(m json.RawMessage) (estree.TypeParameterInstantiation, error) {
+		var tpi TypeParameterInstantiation
+		err := json.Unmarshal(m, &tpi)
+		return tpi, err
+	})
+
+	estree.RegisterStatement("TypeAlias", func(m json.RawMessage) (estree.Statement, error) {
+		var ta TypeAlias
+		err := json.Unmarshal(m, &ta)
+		return ta, err
+	})
+	estree.RegisterStatement("InterfaceDeclaration", func(m json.RawMessage) (estree.Statement, error) {
+		var id InterfaceDeclaration
+		err := json.Unmarshal(m, &id)
+		return id, err
+	})
+	estree.RegisterStatement("OpaqueType", func(m json.RawMessage) (estree.Statement, error) {
+		var ot OpaqueType
+		err := json.Unmarshal(m, &ot)
+		return ot, err
+	})
+	estree.RegisterStatement("DeclareVariable", func(m json.RawMessage) (estree.Statement, error) {
+		var dv DeclareVariable
+		err := json.Unmarshal(m, &dv)
+		return dv, err
+	})
+	estree.RegisterStatement("DeclareFunction", func(m json.RawMessage) (estree.Statement, error) {
+		var df DeclareFunction
+		err := json.Unmarshal(m, &df)
+		return df, err
+	})
+	estree.RegisterStatement("DeclareClass", func(m json.RawMessage) (estree.Statement, error) {
+		var dc DeclareClass
+		err := json.Unmarshal(m, &dc)
+		return dc, err
+	})
+	estree.RegisterStatement("DeclareTypeAlias", func(m json.RawMessage) (estree.Statement, error) {
+		var dta DeclareTypeAlias
+		err := json.Unmarshal(m, &dta)
+		return dta, err
+	})
+	estree.RegisterStatement("DeclareModule", func(m json.RawMessage) (estree.Statement, error) {
+		var dm DeclareModule
+		err := json.Unmarshal(m, &dm)
+		return dm, err
+	})
+
+	estree.RegisterClassImplements("ClassImplements", func(m json.RawMessage) (estree.ClassImplements, error) {
+		var ci ClassImplements
+		err := json.Unmarshal(m, &ci)
+		return ci, err
+	})
+
+	estree.RegisterExpression("TypeCastExpression", func(m json.RawMessage) (estree.Expression, error) {
+		var tce TypeCastExpression
+		err := json.Unmarshal(m, &tce)
+		return tce, err
+	})
+}
+
+// isNull indicates m is JSON null or empty, i.e. an optional Node is absent.
+func isNull(m json.RawMessage) bool {
+	return len(m) == 0 || string(m) == "null"
+}
+
+// TypeAnnotation wraps a FlowType, where it is attached to an estree Node,
+// e.g. the ": string" in let x: string.
+type TypeAnnotation struct {
+	estree.BaseTypeAnnotation
+	Loc            estree.SourceLocation
+	TypeAnnotation FlowType
+}
+
+func (TypeAnnotation) Type() string                       { return "TypeAnnotation" }
+func (ta TypeAnnotation) Location() estree.SourceLocation { return ta.Loc }
+func (TypeAnnotation) MinVersion() estree.Version         { return estree.ES5 }
+
+func (ta TypeAnnotation) IsZero() bool {
+	return ta.Loc.IsZero() &&
+		(ta.TypeAnnotation == nil || ta.TypeAnnotation.IsZero())
+}
+
+func (ta TypeAnnotation) Walk(v estree.Visitor) {
+	if v = v.Visit(ta); v != nil {
+		defer v.Visit(nil)
+		if ta.TypeAnnotation != nil {
+			ta.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (ta TypeAnnotation) Errors() []error {
+	c := estree.NewChecker(ta)
+	c.Require(ta.TypeAnnotation, "type")
+	return c.Errors()
+}
+
+func (ta TypeAnnotation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ta)
+	x["typeAnnotation"] = ta.TypeAnnotation
+	return json.Marshal(x)
+}
+
+func (ta *TypeAnnotation) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		return nil // optional type annotations are null when absent
+	}
+	var x struct {
+		estree.NodeFields
+
+		Type           string          `json:"type"`
+		TypeAnnotation json.RawMessage `json:"typeAnnotation"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ta.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ta.Type(), x.Type)
+	}
+	if err == nil {
+		ta.Loc = x.Location()
+		ta.TypeAnnotation, err = unmarshalType(x.TypeAnnotation)
+	}
+	return err
+}
@@ -0,0 +1,295 @@
+package flow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"pifke.org/estree"
+)
+
+func printNodes(nodes []estree.Node) string {
+	var s strings.Builder
+	s.WriteRune('[')
+	for i, n := range nodes {
+		if i > 0 {
+			s.WriteString(", ")
+		}
+		fmt.Fprintf(&s, "%T", n)
+	}
+	s.WriteRune(']')
+	return s.String()
+}
+
+// mockVisitor implements estree.Visitor by storing the list of nodes visited.
+type mockVisitor []estree.Node
+
+func (v *mockVisitor) Visit(n estree.Node) estree.Visitor {
+	*v = append(*v, n)
+	return v
+}
+
+func (v mockVisitor) String() string {
+	return "visited: " + printNodes(v)
+}
+
+// expect logs a test error if the visited nodes don't match.
+func (v mockVisitor) expect(t *testing.T, nodes ...estree.Node) {
+	if !reflect.DeepEqual(nodes, []estree.Node(v)) {
+		t.Helper()
+		t.Error("expected:", printNodes(nodes))
+		t.Error(v)
+	}
+}
+
+// testRoundtripJSON is a test helper for JSON serialization.
+func testRoundtripJSON(t *testing.T, in estree.Node, out json.Unmarshaler) {
+	t.Helper()
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Error(err)
+	} else if err := out.UnmarshalJSON(b); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(in, reflect.ValueOf(out).Elem().Interface()) {
+		t.Errorf("JSON roundtrip failed marshaling/unmarshaling %T", in)
+		t.Log("marshal:", string(b))
+		t.Logf("unmarshal: %+v", out)
+		if b2, err := json.Marshal(out); err == nil {
+			t.Log("re-marshal:", string(b2))
+		}
+	}
+
+	err = out.UnmarshalJSON([]byte(`{"type":"DoesNotExist","foo":"bar"}`))
+	if !errors.Is(err, estree.ErrWrongType) {
+		t.Errorf("expected ErrWrongType unmarshaling %T, got %v", in, err)
+	}
+}
+
+// hasError indicates one of errs satisfies errors.Is for expect.
+func hasError(expect error, errs ...error) bool {
+	for _, err := range errs {
+		if errors.Is(err, expect) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTypeAnnotation(t *testing.T) {
+	var ta TypeAnnotation
+	if !ta.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	ta.TypeAnnotation = PrimitiveTypeAnnotation{Kind: String}
+	if ta.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if ta.MinVersion() != estree.ES5 {
+		t.Errorf("expected ES5, got %s", ta.MinVersion())
+	}
+
+	var v mockVisitor
+	ta.Walk(&v)
+	v.expect(t, ta, ta.TypeAnnotation, nil, nil)
+
+	testRoundtripJSON(t, ta, new(TypeAnnotation))
+
+	if errs := ta.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ta.TypeAnnotation = nil
+	if !hasError(estree.ErrMissingNode, ta.Errors()...) {
+		t.Error("expected ErrMissingNode for nil TypeAnnotation")
+	}
+}
+
+func TestAnnotatedFunction(t *testing.T) {
+	// function foo(x: ?number): void {}
+	fd := estree.FunctionDeclaration{
+		ID: estree.Identifier{Name: "foo"},
+		Params: []estree.Pattern{
+			estree.Identifier{
+				Name: "x",
+				TypeAnnotation: TypeAnnotation{
+					TypeAnnotation: NullableTypeAnnotation{
+						TypeAnnotation: PrimitiveTypeAnnotation{Kind: Number},
+					},
+				},
+			},
+		},
+		ReturnType: TypeAnnotation{TypeAnnotation: PrimitiveTypeAnnotation{Kind: Void}},
+	}
+	if errs := estree.Validate(fd); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	x := fd.Params[0].(estree.Identifier)
+	xt := x.TypeAnnotation.(TypeAnnotation).TypeAnnotation
+	var v mockVisitor
+	fd.Walk(&v)
+	v.expect(t, fd,
+		fd.ID, nil,
+		x, x.TypeAnnotation, xt, xt.(NullableTypeAnnotation).TypeAnnotation, nil, nil, nil, nil,
+		fd.ReturnType, fd.ReturnType.(TypeAnnotation).TypeAnnotation, nil, nil,
+		fd.Body, nil, nil)
+
+	b, err := json.Marshal(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out estree.FunctionDeclaration
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fd, out) {
+		t.Errorf("JSON roundtrip failed: %s", b)
+	}
+}
+
+func TestAnnotatedClassProperty(t *testing.T) {
+	// class Foo { bar: string = "baz"; }
+	b := []byte(`{"type":"ClassDeclaration","id":{"type":"Identifier","name":"Foo"},` +
+		`"superClass":null,"body":{"type":"ClassBody","body":[{"type":"PropertyDefinition",` +
+		`"key":{"type":"Identifier","name":"bar"},"computed":false,"static":false,` +
+		`"typeAnnotation":{"type":"TypeAnnotation","typeAnnotation":{"type":"StringTypeAnnotation"}},` +
+		`"value":{"type":"Literal","value":"baz"}}]}}`)
+	var cd estree.ClassDeclaration
+	if err := json.Unmarshal(b, &cd); err != nil {
+		t.Fatal(err)
+	}
+	pd, ok := cd.Body.Body[0].(estree.PropertyDefinition)
+	if !ok {
+		t.Fatalf("expected PropertyDefinition, got %#v", cd.Body.Body[0])
+	}
+	expect := TypeAnnotation{TypeAnnotation: PrimitiveTypeAnnotation{Kind: String}}
+	if !reflect.DeepEqual(pd.TypeAnnotation, expect) {
+		t.Errorf("expected %#v, got %#v", expect, pd.TypeAnnotation)
+	}
+	if errs := estree.Validate(cd); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	testRoundtripJSON(t, cd, new(estree.ClassDeclaration))
+}
+
+func TestTypeImportsAndGenerics(t *testing.T) {
+	// import typeof Foo from "foo"; export type {Bar};
+	// function id<T>(x: T): T {}; class Box<T> {}
+	b := []byte(`{"type":"Program","sourceType":"module","body":[` +
+		`{"type":"ImportDeclaration","importKind":"typeof","specifiers":[` +
+		`{"type":"ImportDefaultSpecifier","local":{"type":"Identifier","name":"Foo"}}],` +
+		`"source":{"type":"Literal","value":"foo","raw":"\"foo\""}},` +
+		`{"type":"ExportNamedDeclaration","exportKind":"type","declaration":null,"source":null,` +
+		`"specifiers":[{"type":"ExportSpecifier","local":{"type":"Identifier","name":"Bar"},` +
+		`"exported":{"type":"Identifier","name":"Bar"}}]},` +
+		`{"type":"FunctionDeclaration","id":{"type":"Identifier","name":"id"},` +
+		`"typeParameters":{"type":"TypeParameterDeclaration","params":[` +
+		`{"type":"TypeParameter","name":"T","bound":null,"default":null,"variance":null}]},` +
+		`"params":[{"type":"Identifier","name":"x","typeAnnotation":{"type":"TypeAnnotation",` +
+		`"typeAnnotation":{"type":"GenericTypeAnnotation","id":{"type":"Identifier","name":"T"},"typeParameters":null}}}],` +
+		`"returnType":{"type":"TypeAnnotation",` +
+		`"typeAnnotation":{"type":"GenericTypeAnnotation","id":{"type":"Identifier","name":"T"},"typeParameters":null}},` +
+		`"body":{"type":"BlockStatement","body":[]},"generator":false,"async":false},` +
+		`{"type":"ClassDeclaration","id":{"type":"Identifier","name":"Box"},` +
+		`"typeParameters":{"type":"TypeParameterDeclaration","params":[{"type":"TypeParameter","name":"T"}]},` +
+		`"superClass":null,"body":{"type":"ClassBody","body":[]}}]}`)
+	var p estree.Program
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatal(err)
+	}
+	if errs := estree.Validate(p); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if id := p.Body[0].(estree.ImportDeclaration); id.ImportKind != estree.TypeofBinding {
+		t.Errorf("expected import typeof, got %q", id.ImportKind)
+	}
+	if end := p.Body[1].(estree.ExportNamedDeclaration); end.ExportKind != estree.TypeBinding {
+		t.Errorf("expected export type, got %q", end.ExportKind)
+	}
+	fd := p.Body[2].(estree.FunctionDeclaration)
+	if tpd, ok := fd.TypeParameters.(TypeParameterDeclaration); !ok || len(tpd.Params) != 1 || tpd.Params[0].Name != "T" {
+		t.Errorf("expected <T>, got %+v", fd.TypeParameters)
+	}
+	cd := p.Body[3].(estree.ClassDeclaration)
+	if _, ok := cd.TypeParameters.(TypeParameterDeclaration); !ok {
+		t.Errorf("expected TypeParameterDeclaration, got %+v", cd.TypeParameters)
+	}
+
+	out, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p2 estree.Program
+	if err := json.Unmarshal(out, &p2); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p, p2) {
+		t.Errorf("JSON roundtrip failed: %s", out)
+	}
+
+	end := p.Body[1].(estree.ExportNamedDeclaration)
+	end.ExportKind = estree.TypeofBinding
+	if !hasError(estree.ErrNotAllowed, end.Errors()...) {
+		t.Error("expected ErrNotAllowed for export typeof")
+	}
+}
+
+func TestTypeArguments(t *testing.T) {
+	// f<string>()
+	b := []byte(`{"type":"CallExpression","callee":{"type":"Identifier","name":"f"},` +
+		`"arguments":[],"optional":false,"typeArguments":{"type":"TypeParameterInstantiation",` +
+		`"params":[{"type":"StringTypeAnnotation"}]}}`)
+	var ce estree.CallExpression
+	if err := json.Unmarshal(b, &ce); err != nil {
+		t.Fatal(err)
+	}
+	if tpi, ok := ce.TypeArguments.(TypeParameterInstantiation); !ok || len(tpi.Params) != 1 {
+		t.Errorf("expected <string>, got %+v", ce.TypeArguments)
+	}
+	if errs := estree.Validate(ce); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	testRoundtripJSON(t, ce, new(estree.CallExpression))
+}
+
+func TestClassHeritage(t *testing.T) {
+	// class Foo extends Bar<string> implements Baz<number>, Qux {}
+	b := []byte(`{"type":"ClassDeclaration","id":{"type":"Identifier","name":"Foo"},` +
+		`"superClass":{"type":"Identifier","name":"Bar"},` +
+		`"superTypeParameters":{"type":"TypeParameterInstantiation","params":[{"type":"StringTypeAnnotation"}]},` +
+		`"implements":[{"type":"ClassImplements","id":{"type":"Identifier","name":"Baz"},` +
+		`"typeParameters":{"type":"TypeParameterInstantiation","params":[{"type":"NumberTypeAnnotation"}]}},` +
+		`{"type":"ClassImplements","id":{"type":"Identifier","name":"Qux"},"typeParameters":null}],` +
+		`"body":{"type":"ClassBody","body":[]}}`)
+	var cd estree.ClassDeclaration
+	if err := json.Unmarshal(b, &cd); err != nil {
+		t.Fatal(err)
+	}
+	if tpi, ok := cd.SuperTypeArguments.(TypeParameterInstantiation); !ok || len(tpi.Params) != 1 {
+		t.Errorf("expected <string>, got %+v", cd.SuperTypeArguments)
+	}
+	if len(cd.Implements) != 2 {
+		t.Fatalf("expected 2 implemented interfaces, got %+v", cd.Implements)
+	}
+	ci, ok := cd.Implements[0].(ClassImplements)
+	if !ok || ci.ID.Name != "Baz" || len(ci.TypeParameters.Params) != 1 {
+		t.Errorf("expected Baz<number>, got %+v", cd.Implements[0])
+	}
+	if errs := estree.Validate(cd); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	testRoundtripJSON(t, cd, new(estree.ClassDeclaration))
+	testRoundtripJSON(t, ci, new(ClassImplements))
+
+	var v mockVisitor
+	ci.Walk(&v)
+	v.expect(t, ci, ci.ID, nil, ci.TypeParameters, ci.TypeParameters.Params[0], nil, nil, nil)
+
+	if !hasError(estree.ErrMissingNode, ClassImplements{}.Errors()...) {
+		t.Error("expected ErrMissingNode for missing ID")
+	}
+}
@@ -0,0 +1,1687 @@
+package flow
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"pifke.org/estree"
+)
+
+// FlowType is any Flow type expression.
+type FlowType interface {
+	estree.Node
+	isFlowType()
+}
+
+type baseType struct{}
+
+func (baseType) MinVersion() estree.Version { return estree.ES5 }
+func (baseType) isFlowType()                {}
+
+func unmarshalType(m json.RawMessage) (t FlowType, err error) {
+	if isNull(m) {
+		return nil, nil
+	}
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err = json.Unmarshal(m, &x); err == nil {
+		switch x.Type {
+		case GenericTypeAnnotation{}.Type():
+			var gta GenericTypeAnnotation
+			err, t = json.Unmarshal(m, &gta), gta
+		case NullableTypeAnnotation{}.Type():
+			var nta NullableTypeAnnotation
+			err, t = json.Unmarshal(m, &nta), nta
+		case UnionTypeAnnotation{}.Type():
+			var uta UnionTypeAnnotation
+			err, t = json.Unmarshal(m, &uta), uta
+		case IntersectionTypeAnnotation{}.Type():
+			var ita IntersectionTypeAnnotation
+			err, t = json.Unmarshal(m, &ita), ita
+		case ArrayTypeAnnotation{}.Type():
+			var ata ArrayTypeAnnotation
+			err, t = json.Unmarshal(m, &ata), ata
+		case StringLiteralTypeAnnotation{}.Type():
+			var slta StringLiteralTypeAnnotation
+			err, t = json.Unmarshal(m, &slta), slta
+		case NumberLiteralTypeAnnotation{}.Type():
+			var nlta NumberLiteralTypeAnnotation
+			err, t = json.Unmarshal(m, &nlta), nlta
+		case BooleanLiteralTypeAnnotation{}.Type():
+			var blta BooleanLiteralTypeAnnotation
+			err, t = json.Unmarshal(m, &blta), blta
+		case TypeofTypeAnnotation{}.Type():
+			var tta TypeofTypeAnnotation
+			err, t = json.Unmarshal(m, &tta), tta
+		case TupleTypeAnnotation{}.Type():
+			var tta TupleTypeAnnotation
+			err, t = json.Unmarshal(m, &tta), tta
+		case ObjectTypeAnnotation{}.Type():
+			var ota ObjectTypeAnnotation
+			err, t = json.Unmarshal(m, &ota), ota
+		case FunctionTypeAnnotation{}.Type():
+			var fta FunctionTypeAnnotation
+			err, t = json.Unmarshal(m, &fta), fta
+		default:
+			if PrimitiveKind(x.Type).IsValid() {
+				var pta PrimitiveTypeAnnotation
+				err, t = json.Unmarshal(m, &pta), pta
+			} else {
+				err = fmt.Errorf("%w FlowType, got %v", estree.ErrWrongType, string(m))
+			}
+		}
+		if err != nil {
+			t = nil
+		}
+	}
+	return
+}
+
+// unmarshalTypes decodes a list of FlowTypes, returning the first error
+// encountered.
+func unmarshalTypes(ms []json.RawMessage) ([]FlowType, error) {
+	if len(ms) == 0 {
+		return nil, nil
+	}
+	var err error
+	types := make([]FlowType, len(ms))
+	for i := range ms {
+		var err2 error
+		types[i], err2 = unmarshalType(ms[i])
+		if err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return types, err
+}
+
+// PrimitiveKind is the Type of a PrimitiveTypeAnnotation.
+type PrimitiveKind string
+
+const (
+	Any     PrimitiveKind = "AnyTypeAnnotation"
+	Mixed   PrimitiveKind = "MixedTypeAnnotation"
+	Empty   PrimitiveKind = "EmptyTypeAnnotation"
+	Void    PrimitiveKind = "VoidTypeAnnotation"
+	Null    PrimitiveKind = "NullLiteralTypeAnnotation"
+	Boolean PrimitiveKind = "BooleanTypeAnnotation"
+	Number  PrimitiveKind = "NumberTypeAnnotation"
+	BigInt  PrimitiveKind = "BigIntTypeAnnotation"
+	String  PrimitiveKind = "StringTypeAnnotation"
+	Symbol  PrimitiveKind = "SymbolTypeAnnotation"
+)
+
+func (k PrimitiveKind) IsValid() bool {
+	switch k {
+	case Any, Mixed, Empty, Void, Null, Boolean, Number, BigInt, String, Symbol:
+		return true
+	}
+	return false
+}
+
+// PrimitiveTypeAnnotation is a built-in type, e.g. string or mixed.  Unlike
+// most Nodes, its Type is determined by Kind.
+type PrimitiveTypeAnnotation struct {
+	baseType
+	Loc  estree.SourceLocation
+	Kind PrimitiveKind
+}
+
+func (pta PrimitiveTypeAnnotation) Type() string                    { return string(pta.Kind) }
+func (pta PrimitiveTypeAnnotation) Location() estree.SourceLocation { return pta.Loc }
+
+func (pta PrimitiveTypeAnnotation) IsZero() bool {
+	return pta.Loc.IsZero() && pta.Kind == ""
+}
+
+func (pta PrimitiveTypeAnnotation) Walk(v estree.Visitor) {
+	if v = v.Visit(pta); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (pta PrimitiveTypeAnnotation) Errors() []error {
+	c := estree.NewChecker(pta)
+	if !pta.Kind.IsValid() {
+		c.Appendf("%w PrimitiveTypeAnnotation.Kind %q", estree.ErrWrongValue, pta.Kind)
+	}
+	return c.Errors()
+}
+
+func (pta PrimitiveTypeAnnotation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(estree.NodeToMap(pta))
+}
+
+func (pta *PrimitiveTypeAnnotation) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type PrimitiveKind `json:"type"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && !x.Type.IsValid() {
+		err = fmt.Errorf("%w PrimitiveTypeAnnotation, got %q", estree.ErrWrongType, x.Type)
+	}
+	if err == nil {
+		pta.Loc, pta.Kind = x.Location(), x.Type
+	}
+	return err
+}
+
+// unmarshalTypeIdentifier decodes the name of a type, which is either an
+// Identifier or QualifiedTypeIdentifier.
+func unmarshalTypeIdentifier(m json.RawMessage) (estree.Node, error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(m, &x)
+	if err == nil {
+		switch x.Type {
+		case estree.Identifier{}.Type():
+			var i estree.Identifier
+			if err = json.Unmarshal(m, &i); err == nil {
+				return i, nil
+			}
+		case QualifiedTypeIdentifier{}.Type():
+			var qti QualifiedTypeIdentifier
+			if err = json.Unmarshal(m, &qti); err == nil {
+				return qti, nil
+			}
+		default:
+			err = fmt.Errorf("%w Identifier or QualifiedTypeIdentifier, got %v", estree.ErrWrongType, string(m))
+		}
+	}
+	return nil, err
+}
+
+// checkTypeIdentifier reports ErrWrongValue if n is not an Identifier or
+// QualifiedTypeIdentifier.
+func checkTypeIdentifier(c *estree.Checker, n estree.Node, what string) {
+	switch n.(type) {
+	case nil, estree.Identifier, QualifiedTypeIdentifier:
+	default:
+		c.Appendf("%w %s %s", estree.ErrWrongValue, what, n.Type())
+	}
+}
+
+// QualifiedTypeIdentifier is a dotted type name, e.g. React.Node.
+type QualifiedTypeIdentifier struct {
+	Loc           estree.SourceLocation
+	Qualification estree.Node // Identifier or QualifiedTypeIdentifier
+	ID            estree.Identifier
+}
+
+func (QualifiedTypeIdentifier) Type() string                        { return "QualifiedTypeIdentifier" }
+func (qti QualifiedTypeIdentifier) Location() estree.SourceLocation { return qti.Loc }
+func (QualifiedTypeIdentifier) MinVersion() estree.Version          { return estree.ES5 }
+
+func (qti QualifiedTypeIdentifier) IsZero() bool {
+	return qti.Loc.IsZero() &&
+		(qti.Qualification == nil || qti.Qualification.IsZero()) &&
+		qti.ID.IsZero()
+}
+
+func (qti QualifiedTypeIdentifier) Walk(v estree.Visitor) {
+	if v = v.Visit(qti); v != nil {
+		defer v.Visit(nil)
+		if qti.Qualification != nil {
+			qti.Qualification.Walk(v)
+		}
+		qti.ID.Walk(v)
+	}
+}
+
+func (qti QualifiedTypeIdentifier) Errors() []error {
+	c := estree.NewChecker(qti)
+	c.Require(qti.Qualification, "qualifier")
+	checkTypeIdentifier(c, qti.Qualification, "qualifier")
+	c.Require(qti.ID, "qualified name")
+	return c.Errors()
+}
+
+func (qti QualifiedTypeIdentifier) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(qti)
+	x["qualification"] = qti.Qualification
+	x["id"] = qti.ID
+	return json.Marshal(x)
+}
+
+func (qti *QualifiedTypeIdentifier) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type          string            `json:"type"`
+		Qualification json.RawMessage   `json:"qualification"`
+		ID            estree.Identifier `json:"id"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != qti.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, qti.Type(), x.Type)
+	}
+	if err == nil {
+		qti.Loc, qti.ID = x.Location(), x.ID
+		qti.Qualification, err = unmarshalTypeIdentifier(x.Qualification)
+	}
+	return err
+}
+
+// TypeParameterInstantiation is a list of type arguments, e.g. the <string>
+// in Array<string>.
+type TypeParameterInstantiation struct {
+	estree.BaseTypeParameterInstantiation
+	Loc    estree.SourceLocation
+	Params []FlowType
+}
+
+func (TypeParameterInstantiation) Type() string { return "TypeParameterInstantiation" }
+func (tpi TypeParameterInstantiation) Location() estree.SourceLocation {
+	return tpi.Loc
+}
+func (TypeParameterInstantiation) MinVersion() estree.Version { return estree.ES5 }
+
+func (tpi TypeParameterInstantiation) IsZero() bool {
+	return tpi.Loc.IsZero() && len(tpi.Params) == 0
+}
+
+func (tpi TypeParameterInstantiation) Walk(v estree.Visitor) {
+	if v = v.Visit(tpi); v != nil {
+		defer v.Visit(nil)
+		for _, p := range tpi.Params {
+			if p != nil {
+				p.Walk(v)
+			}
+		}
+	}
+}
+
+func (tpi TypeParameterInstantiation) Errors() []error {
+	c := estree.NewChecker(tpi)
+	c.RequireEach(len(tpi.Params), func(i int) estree.Node { return tpi.Params[i] }, "type argument")
+	return c.Errors()
+}
+
+func (tpi TypeParameterInstantiation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(tpi)
+	if len(tpi.Params) == 0 {
+		x["params"] = []FlowType{}
+	} else {
+		x["params"] = tpi.Params
+	}
+	return json.Marshal(x)
+}
+
+func (tpi *TypeParameterInstantiation) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		return nil // optional type arguments are null when absent
+	}
+	var x struct {
+		estree.NodeFields
+
+		Type   string            `json:"type"`
+		Params []json.RawMessage `json:"params"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tpi.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tpi.Type(), x.Type)
+	}
+	if err == nil {
+		tpi.Loc = x.Location()
+		tpi.Params, err = unmarshalTypes(x.Params)
+	}
+	return err
+}
+
+// GenericTypeAnnotation refers to a named type, e.g. Foo or Array<string>.
+type GenericTypeAnnotation struct {
+	baseType
+	Loc            estree.SourceLocation
+	ID             estree.Node                // Identifier or QualifiedTypeIdentifier
+	TypeParameters TypeParameterInstantiation // possibly zero
+}
+
+func (GenericTypeAnnotation) Type() string                        { return "GenericTypeAnnotation" }
+func (gta GenericTypeAnnotation) Location() estree.SourceLocation { return gta.Loc }
+
+func (gta GenericTypeAnnotation) IsZero() bool {
+	return gta.Loc.IsZero() &&
+		(gta.ID == nil || gta.ID.IsZero()) &&
+		gta.TypeParameters.IsZero()
+}
+
+func (gta GenericTypeAnnotation) Walk(v estree.Visitor) {
+	if v = v.Visit(gta); v != nil {
+		defer v.Visit(nil)
+		if gta.ID != nil {
+			gta.ID.Walk(v)
+		}
+		if !gta.TypeParameters.IsZero() {
+			gta.TypeParameters.Walk(v)
+		}
+	}
+}
+
+func (gta GenericTypeAnnotation) Errors() []error {
+	c := estree.NewChecker(gta)
+	c.Require(gta.ID, "type name")
+	checkTypeIdentifier(c, gta.ID, "type name")
+	c.Optional(gta.TypeParameters)
+	return c.Errors()
+}
+
+func (gta GenericTypeAnnotation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(gta)
+	x["id"] = gta.ID
+	if gta.TypeParameters.IsZero() {
+		x["typeParameters"] = nil
+	} else {
+		x["typeParameters"] = gta.TypeParameters
+	}
+	return json.Marshal(x)
+}
+
+func (gta *GenericTypeAnnotation) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string                     `json:"type"`
+		ID             json.RawMessage            `json:"id"`
+		TypeParameters TypeParameterInstantiation `json:"typeParameters"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != gta.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, gta.Type(), x.Type)
+	}
+	if err == nil {
+		gta.Loc, gta.TypeParameters = x.Location(), x.TypeParameters
+		gta.ID, err = unmarshalTypeIdentifier(x.ID)
+	}
+	return err
+}
+
+// NullableTypeAnnotation is a maybe type, e.g. ?string.
+type NullableTypeAnnotation struct {
+	baseType
+	Loc            estree.SourceLocation
+	TypeAnnotation FlowType
+}
+
+func (NullableTypeAnnotation) Type() string                        { return "NullableTypeAnnotation" }
+func (nta NullableTypeAnnotation) Location() estree.SourceLocation { return nta.Loc }
+
+func (nta NullableTypeAnnotation) IsZero() bool {
+	return nta.Loc.IsZero() &&
+		(nta.TypeAnnotation == nil || nta.TypeAnnotation.IsZero())
+}
+
+func (nta NullableTypeAnnotation) Walk(v estree.Visitor) {
+	if v = v.Visit(nta); v != nil {
+		defer v.Visit(nil)
+		if nta.TypeAnnotation != nil {
+			nta.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (nta NullableTypeAnnotation) Errors() []error {
+	c := estree.NewChecker(nta)
+	c.Require(nta.TypeAnnotation, "type")
+	return c.Errors()
+}
+
+func (nta NullableTypeAnnotation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(nta)
+	x["typeAnnotation"] = nta.TypeAnnotation
+	return json.Marshal(x)
+}
+
+func (nta *NullableTypeAnnotation) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string          `json:"type"`
+		TypeAnnotation json.RawMessage `json:"typeAnnotation"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != nta.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, nta.Type(), x.Type)
+	}
+	if err == nil {
+		nta.Loc = x.Location()
+		nta.TypeAnnotation, err = unmarshalType(x.TypeAnnotation)
+	}
+	return err
+}
+
+// UnionTypeAnnotation is a union of types, e.g. string | number.
+type UnionTypeAnnotation struct {
+	baseType
+	Loc   estree.SourceLocation
+	Types []FlowType
+}
+
+func (UnionTypeAnnotation) Type() string                        { return "UnionTypeAnnotation" }
+func (uta UnionTypeAnnotation) Location() estree.SourceLocation { return uta.Loc }
+
+func (uta UnionTypeAnnotation) IsZero() bool {
+	return uta.Loc.IsZero() && len(uta.Types) == 0
+}
+
+func (uta UnionTypeAnnotation) Walk(v estree.Visitor) {
+	if v = v.Visit(uta); v != nil {
+		defer v.Visit(nil)
+		for _, t := range uta.Types {
+			if t != nil {
+				t.Walk(v)
+			}
+		}
+	}
+}
+
+func (uta UnionTypeAnnotation) Errors() []error {
+	c := estree.NewChecker(uta)
+	if len(uta.Types) == 0 {
+		c.Appendf("%w union member", estree.ErrMissingNode)
+	}
+	c.RequireEach(len(uta.Types), func(i int) estree.Node { return uta.Types[i] }, "union member")
+	return c.Errors()
+}
+
+func (uta UnionTypeAnnotation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(uta)
+	x["types"] = uta.Types
+	return json.Marshal(x)
+}
+
+func (uta *UnionTypeAnnotation) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type  string            `json:"type"`
+		Types []json.RawMessage `json:"types"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != uta.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, uta.Type(), x.Type)
+	}
+	if err == nil {
+		uta.Loc = x.Location()
+		uta.Types, err = unmarshalTypes(x.Types)
+	}
+	return err
+}
+
+// IntersectionTypeAnnotation is an intersection of types, e.g. Foo & Bar.
+type IntersectionTypeAnnotation struct {
+	baseType
+	Loc   estree.SourceLocation
+	Types []FlowType
+}
+
+func (IntersectionTypeAnnotation) Type() string { return "IntersectionTypeAnnotation" }
+func (ita IntersectionTypeAnnotation) Location() estree.SourceLocation {
+	return ita.Loc
+}
+
+func (ita IntersectionTypeAnnotation) IsZero() bool {
+	return ita.Loc.IsZero() && len(ita.Types) == 0
+}
+
+func (ita IntersectionTypeAnnotation) Walk(v estree.Visitor) {
+	if v = v.Visit(ita); v != nil {
+		defer v.Visit(nil)
+		for _, t := range ita.Types {
+			if t != nil {
+				t.Walk(v)
+			}
+		}
+	}
+}
+
+func (ita IntersectionTypeAnnotation) Errors() []error {
+	c := estree.NewChecker(ita)
+	if len(ita.Types) == 0 {
+		c.Appendf("%w intersection member", estree.ErrMissingNode)
+	}
+	c.RequireEach(len(ita.Types), func(i int) estree.Node { return ita.Types[i] }, "intersection member")
+	return c.Errors()
+}
+
+func (ita IntersectionTypeAnnotation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ita)
+	x["types"] = ita.Types
+	return json.Marshal(x)
+}
+
+func (ita *IntersectionTypeAnnotation) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type  string            `json:"type"`
+		Types []json.RawMessage `json:"types"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ita.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ita.Type(), x.Type)
+	}
+	if err == nil {
+		ita.Loc = x.Location()
+		ita.Types, err = unmarshalTypes(x.Types)
+	}
+	return err
+}
+
+// ArrayTypeAnnotation is an array type, e.g. string[].
+type ArrayTypeAnnotation struct {
+	baseType
+	Loc         estree.SourceLocation
+	ElementType FlowType
+}
+
+func (ArrayTypeAnnotation) Type() string                        { return "ArrayTypeAnnotation" }
+func (ata ArrayTypeAnnotation) Location() estree.SourceLocation { return ata.Loc }
+
+func (ata ArrayTypeAnnotation) IsZero() bool {
+	return ata.Loc.IsZero() &&
+		(ata.ElementType == nil || ata.ElementType.IsZero())
+}
+
+func (ata ArrayTypeAnnotation) Walk(v estree.Visitor) {
+	if v = v.Visit(ata); v != nil {
+		defer v.Visit(nil)
+		if ata.ElementType != nil {
+			ata.ElementType.Walk(v)
+		}
+	}
+}
+
+func (ata ArrayTypeAnnotation) Errors() []error {
+	c := estree.NewChecker(ata)
+	c.Require(ata.ElementType, "array element type")
+	return c.Errors()
+}
+
+func (ata ArrayTypeAnnotation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ata)
+	x["elementType"] = ata.ElementType
+	return json.Marshal(x)
+}
+
+func (ata *ArrayTypeAnnotation) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type        string          `json:"type"`
+		ElementType json.RawMessage `json:"elementType"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ata.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ata.Type(), x.Type)
+	}
+	if err == nil {
+		ata.Loc = x.Location()
+		ata.ElementType, err = unmarshalType(x.ElementType)
+	}
+	return err
+}
+
+// StringLiteralTypeAnnotation is a string literal used as a type, e.g.
+// "foo".
+type StringLiteralTypeAnnotation struct {
+	baseType
+	Loc   estree.SourceLocation
+	Value string
+	Raw   string
+}
+
+func (StringLiteralTypeAnnotation) Type() string { return "StringLiteralTypeAnnotation" }
+func (slta StringLiteralTypeAnnotation) Location() estree.SourceLocation {
+	return slta.Loc
+}
+func (StringLiteralTypeAnnotation) IsZero() bool { return false }
+
+func (slta StringLiteralTypeAnnotation) Walk(v estree.Visitor) {
+	if v = v.Visit(slta); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (slta StringLiteralTypeAnnotation) Errors() []error {
+	return estree.NewChecker(slta).Errors()
+}
+
+func (slta StringLiteralTypeAnnotation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(slta)
+	x["value"] = slta.Value
+	x["raw"] = slta.Raw
+	return json.Marshal(x)
+}
+
+func (slta *StringLiteralTypeAnnotation) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type  string `json:"type"`
+		Value string `json:"value"`
+		Raw   string `json:"raw"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != slta.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, slta.Type(), x.Type)
+	}
+	if err == nil {
+		slta.Loc, slta.Value, slta.Raw = x.Location(), x.Value, x.Raw
+	}
+	return err
+}
+
+// NumberLiteralTypeAnnotation is a number literal used as a type, e.g. 42.
+type NumberLiteralTypeAnnotation struct {
+	baseType
+	Loc   estree.SourceLocation
+	Value float64
+	Raw   string
+}
+
+func (NumberLiteralTypeAnnotation) Type() string { return "NumberLiteralTypeAnnotation" }
+func (nlta NumberLiteralTypeAnnotation) Location() estree.SourceLocation {
+	return nlta.Loc
+}
+func (NumberLiteralTypeAnnotation) IsZero() bool { return false }
+
+func (nlta NumberLiteralTypeAnnotation) Walk(v estree.Visitor) {
+	if v = v.Visit(nlta); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (nlta NumberLiteralTypeAnnotation) Errors() []error {
+	return estree.NewChecker(nlta).Errors()
+}
+
+func (nlta NumberLiteralTypeAnnotation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(nlta)
+	x["value"] = nlta.Value
+	x["raw"] = nlta.Raw
+	return json.Marshal(x)
+}
+
+func (nlta *NumberLiteralTypeAnnotation) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type  string  `json:"type"`
+		Value float64 `json:"value"`
+		Raw   string  `json:"raw"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != nlta.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, nlta.Type(), x.Type)
+	}
+	if err == nil {
+		nlta.Loc, nlta.Value, nlta.Raw = x.Location(), x.Value, x.Raw
+	}
+	return err
+}
+
+// BooleanLiteralTypeAnnotation is a boolean literal used as a type, e.g.
+// true.
+type BooleanLiteralTypeAnnotation struct {
+	baseType
+	Loc   estree.SourceLocation
+	Value bool
+	Raw   string
+}
+
+func (BooleanLiteralTypeAnnotation) Type() string { return "BooleanLiteralTypeAnnotation" }
+func (blta BooleanLiteralTypeAnnotation) Location() estree.SourceLocation {
+	return blta.Loc
+}
+func (BooleanLiteralTypeAnnotation) IsZero() bool { return false }
+
+func (blta BooleanLiteralTypeAnnotation) Walk(v estree.Visitor) {
+	if v = v.Visit(blta); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (blta BooleanLiteralTypeAnnotation) Errors() []error {
+	return estree.NewChecker(blta).Errors()
+}
+
+func (blta BooleanLiteralTypeAnnotation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(blta)
+	x["value"] = blta.Value
+	x["raw"] = blta.Raw
+	return json.Marshal(x)
+}
+
+func (blta *BooleanLiteralTypeAnnotation) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type  string `json:"type"`
+		Value bool   `json:"value"`
+		Raw   string `json:"raw"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != blta.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, blta.Type(), x.Type)
+	}
+	if err == nil {
+		blta.Loc, blta.Value, blta.Raw = x.Location(), x.Value, x.Raw
+	}
+	return err
+}
+
+// TypeofTypeAnnotation is the type of a value, e.g. typeof foo.  The
+// Argument is usually a GenericTypeAnnotation naming the value.
+type TypeofTypeAnnotation struct {
+	baseType
+	Loc      estree.SourceLocation
+	Argument FlowType
+}
+
+func (TypeofTypeAnnotation) Type() string                        { return "TypeofTypeAnnotation" }
+func (tta TypeofTypeAnnotation) Location() estree.SourceLocation { return tta.Loc }
+
+func (tta TypeofTypeAnnotation) IsZero() bool {
+	return tta.Loc.IsZero() &&
+		(tta.Argument == nil || tta.Argument.IsZero())
+}
+
+func (tta TypeofTypeAnnotation) Walk(v estree.Visitor) {
+	if v = v.Visit(tta); v != nil {
+		defer v.Visit(nil)
+		if tta.Argument != nil {
+			tta.Argument.Walk(v)
+		}
+	}
+}
+
+func (tta TypeofTypeAnnotation) Errors() []error {
+	c := estree.NewChecker(tta)
+	c.Require(tta.Argument, "typeof argument")
+	return c.Errors()
+}
+
+func (tta TypeofTypeAnnotation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(tta)
+	x["argument"] = tta.Argument
+	return json.Marshal(x)
+}
+
+func (tta *TypeofTypeAnnotation) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type     string          `json:"type"`
+		Argument json.RawMessage `json:"argument"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tta.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tta.Type(), x.Type)
+	}
+	if err == nil {
+		tta.Loc = x.Location()
+		tta.Argument, err = unmarshalType(x.Argument)
+	}
+	return err
+}
+
+// TupleTypeAnnotation is a tuple type, e.g. [string, number].
+type TupleTypeAnnotation struct {
+	baseType
+	Loc   estree.SourceLocation
+	Types []FlowType
+}
+
+func (TupleTypeAnnotation) Type() string                        { return "TupleTypeAnnotation" }
+func (tta TupleTypeAnnotation) Location() estree.SourceLocation { return tta.Loc }
+func (TupleTypeAnnotation) IsZero() bool                        { return false }
+
+func (tta TupleTypeAnnotation) Walk(v estree.Visitor) {
+	if v = v.Visit(tta); v != nil {
+		defer v.Visit(nil)
+		for _, t := range tta.Types {
+			if t != nil {
+				t.Walk(v)
+			}
+		}
+	}
+}
+
+func (tta TupleTypeAnnotation) Errors() []error {
+	c := estree.NewChecker(tta)
+	c.RequireEach(len(tta.Types), func(i int) estree.Node { return tta.Types[i] }, "tuple element")
+	return c.Errors()
+}
+
+func (tta TupleTypeAnnotation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(tta)
+	if len(tta.Types) == 0 {
+		x["types"] = []FlowType{}
+	} else {
+		x["types"] = tta.Types
+	}
+	return json.Marshal(x)
+}
+
+func (tta *TupleTypeAnnotation) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type  string            `json:"type"`
+		Types []json.RawMessage `json:"types"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tta.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tta.Type(), x.Type)
+	}
+	if err == nil {
+		tta.Loc = x.Location()
+		tta.Types, err = unmarshalTypes(x.Types)
+	}
+	return err
+}
+
+// VarianceKind is a value for Variance.Kind.
+type VarianceKind string
+
+const (
+	Covariant     VarianceKind = "plus"  // +foo: T
+	Contravariant VarianceKind = "minus" // -foo: T
+)
+
+func (vk VarianceKind) IsValid() bool {
+	return vk == Covariant || vk == Contravariant
+}
+
+// Variance is the variance sigil of a property or type parameter, e.g. the +
+// in +foo: string.
+type Variance struct {
+	Loc  estree.SourceLocation
+	Kind VarianceKind
+}
+
+func (Variance) Type() string                       { return "Variance" }
+func (va Variance) Location() estree.SourceLocation { return va.Loc }
+func (Variance) MinVersion() estree.Version         { return estree.ES5 }
+
+func (va Variance) IsZero() bool {
+	return va.Loc.IsZero() && va.Kind == ""
+}
+
+func (va Variance) Walk(v estree.Visitor) {
+	if v = v.Visit(va); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (va Variance) Errors() []error {
+	c := estree.NewChecker(va)
+	if !va.Kind.IsValid() {
+		c.Appendf("%w Variance.Kind %q", estree.ErrWrongValue, va.Kind)
+	}
+	return c.Errors()
+}
+
+func (va Variance) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(va)
+	x["kind"] = va.Kind
+	return json.Marshal(x)
+}
+
+func (va *Variance) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		return nil // variance is null when absent
+	}
+	var x struct {
+		estree.NodeFields
+
+		Type string       `json:"type"`
+		Kind VarianceKind `json:"kind"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != va.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, va.Type(), x.Type)
+	}
+	if err == nil && !x.Kind.IsValid() {
+		err = fmt.Errorf("%w Variance.Kind %q", estree.ErrWrongValue, x.Kind)
+	}
+	if err == nil {
+		va.Loc, va.Kind = x.Location(), x.Kind
+	}
+	return err
+}
+
+// varianceToJSON returns the JSON representation of an optional Variance,
+// which is null when absent.
+func varianceToJSON(va Variance) interface{} {
+	if va.IsZero() {
+		return nil
+	}
+	return va
+}
+
+// ObjectTypeAnnotation is an object type, e.g. { foo: string } or the exact
+// {| foo: string |}.
+type ObjectTypeAnnotation struct {
+	baseType
+	Loc            estree.SourceLocation
+	Properties     []ObjectTypeMember
+	Indexers       []ObjectTypeIndexer
+	CallProperties []ObjectTypeCallProperty
+	InternalSlots  []ObjectTypeInternalSlot
+	Exact          bool
+	Inexact        bool // explicitly inexact, e.g. { foo: string, ... }
+}
+
+func (ObjectTypeAnnotation) Type() string                        { return "ObjectTypeAnnotation" }
+func (ota ObjectTypeAnnotation) Location() estree.SourceLocation { return ota.Loc }
+func (ObjectTypeAnnotation) IsZero() bool                        { return false }
+
+func (ota ObjectTypeAnnotation) Walk(v estree.Visitor) {
+	if v = v.Visit(ota); v != nil {
+		defer v.Visit(nil)
+		for _, p := range ota.Properties {
+			if p != nil {
+				p.Walk(v)
+			}
+		}
+		for _, i := range ota.Indexers {
+			i.Walk(v)
+		}
+		for _, cp := range ota.CallProperties {
+			cp.Walk(v)
+		}
+		for _, is := range ota.InternalSlots {
+			is.Walk(v)
+		}
+	}
+}
+
+func (ota ObjectTypeAnnotation) Errors() []error {
+	c := estree.NewChecker(ota)
+	c.RequireEach(len(ota.Properties), func(i int) estree.Node { return ota.Properties[i] }, "object type property")
+	c.RequireEach(len(ota.Indexers), func(i int) estree.Node { return ota.Indexers[i] }, "object type indexer")
+	c.RequireEach(len(ota.CallProperties), func(i int) estree.Node { return ota.CallProperties[i] }, "object type call property")
+	c.RequireEach(len(ota.InternalSlots), func(i int) estree.Node { return ota.InternalSlots[i] }, "object type internal slot")
+	if ota.Exact && ota.Inexact {
+		c.Appendf("exact and inexact object type %w", estree.ErrNotAllowed)
+	}
+	return c.Errors()
+}
+
+func (ota ObjectTypeAnnotation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ota)
+	if len(ota.Properties) == 0 {
+		x["properties"] = []ObjectTypeMember{}
+	} else {
+		x["properties"] = ota.Properties
+	}
+	if len(ota.Indexers) == 0 {
+		x["indexers"] = []ObjectTypeIndexer{}
+	} else {
+		x["indexers"] = ota.Indexers
+	}
+	if len(ota.CallProperties) == 0 {
+		x["callProperties"] = []ObjectTypeCallProperty{}
+	} else {
+		x["callProperties"] = ota.CallProperties
+	}
+	if len(ota.InternalSlots) == 0 {
+		x["internalSlots"] = []ObjectTypeInternalSlot{}
+	} else {
+		x["internalSlots"] = ota.InternalSlots
+	}
+	x["exact"] = ota.Exact
+	x["inexact"] = ota.Inexact
+	return json.Marshal(x)
+}
+
+func (ota *ObjectTypeAnnotation) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string                   `json:"type"`
+		Properties     []json.RawMessage        `json:"properties"`
+		Indexers       []ObjectTypeIndexer      `json:"indexers"`
+		CallProperties []ObjectTypeCallProperty `json:"callProperties"`
+		InternalSlots  []ObjectTypeInternalSlot `json:"internalSlots"`
+		Exact          bool                     `json:"exact"`
+		Inexact        bool                     `json:"inexact"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ota.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ota.Type(), x.Type)
+	}
+	if err == nil {
+		ota.Loc, ota.Exact, ota.Inexact = x.Location(), x.Exact, x.Inexact
+		ota.Indexers, ota.CallProperties, ota.InternalSlots = nil, nil, nil
+		if len(x.Indexers) != 0 {
+			ota.Indexers = x.Indexers
+		}
+		if len(x.CallProperties) != 0 {
+			ota.CallProperties = x.CallProperties
+		}
+		if len(x.InternalSlots) != 0 {
+			ota.InternalSlots = x.InternalSlots
+		}
+		ota.Properties, err = unmarshalObjectTypeMembers(x.Properties)
+	}
+	return err
+}
+
+// ObjectTypeMember is an element of ObjectTypeAnnotation.Properties: either
+// an ObjectTypeProperty or an ObjectTypeSpreadProperty.
+type ObjectTypeMember interface {
+	estree.Node
+	isObjectTypeMember()
+}
+
+// unmarshalObjectTypeMembers decodes a list of ObjectTypeMembers, returning
+// the first error encountered.
+func unmarshalObjectTypeMembers(ms []json.RawMessage) ([]ObjectTypeMember, error) {
+	if len(ms) == 0 {
+		return nil, nil
+	}
+	var err error
+	members := make([]ObjectTypeMember, len(ms))
+	for i, m := range ms {
+		var x struct {
+			Type string `json:"type"`
+		}
+		err2 := json.Unmarshal(m, &x)
+		if err2 == nil {
+			switch x.Type {
+			case ObjectTypeProperty{}.Type():
+				var otp ObjectTypeProperty
+				err2, members[i] = json.Unmarshal(m, &otp), otp
+			case ObjectTypeSpreadProperty{}.Type():
+				var otsp ObjectTypeSpreadProperty
+				err2, members[i] = json.Unmarshal(m, &otsp), otsp
+			default:
+				err2 = fmt.Errorf("%w ObjectTypeProperty or ObjectTypeSpreadProperty, got %q", estree.ErrWrongType, x.Type)
+			}
+		}
+		if err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return members, err
+}
+
+// ObjectTypeProperty is a property of an ObjectTypeAnnotation, e.g.
+// foo?: string, +bar: number, or the method baz(): void.
+type ObjectTypeProperty struct {
+	Loc      estree.SourceLocation
+	Key      estree.LiteralOrIdentifier // Identifier or StringLiteral
+	Value    FlowType                   // a FunctionTypeAnnotation if Method, or Kind is Get or Set
+	Optional bool
+	Static   bool // only in a DeclareClass body
+	Proto    bool // only in a DeclareClass body
+	Method   bool
+	Kind     estree.PropertyKind // Get or Set, or empty for a plain property
+	Variance Variance            // possibly zero
+}
+
+func (ObjectTypeProperty) Type() string                        { return "ObjectTypeProperty" }
+func (otp ObjectTypeProperty) Location() estree.SourceLocation { return otp.Loc }
+func (ObjectTypeProperty) MinVersion() estree.Version          { return estree.ES5 }
+func (ObjectTypeProperty) isObjectTypeMember()                 {}
+
+func (otp ObjectTypeProperty) IsZero() bool {
+	return otp.Loc.IsZero() &&
+		(otp.Key == nil || otp.Key.IsZero()) &&
+		(otp.Value == nil || otp.Value.IsZero()) &&
+		!otp.Optional &&
+		!otp.Static &&
+		!otp.Proto &&
+		!otp.Method &&
+		otp.Kind == "" &&
+		otp.Variance.IsZero()
+}
+
+func (otp ObjectTypeProperty) Walk(v estree.Visitor) {
+	if v = v.Visit(otp); v != nil {
+		defer v.Visit(nil)
+		if !otp.Variance.IsZero() {
+			otp.Variance.Walk(v)
+		}
+		if otp.Key != nil {
+			otp.Key.Walk(v)
+		}
+		if otp.Value != nil {
+			otp.Value.Walk(v)
+		}
+	}
+}
+
+func (otp ObjectTypeProperty) Errors() []error {
+	c := estree.NewChecker(otp)
+	c.Require(otp.Key, "property name")
+	switch otp.Key.(type) {
+	case nil, estree.Identifier, estree.StringLiteral:
+	default:
+		c.Appendf("%w property name %s", estree.ErrWrongValue, otp.Key.Type())
+	}
+	c.Require(otp.Value, "property type")
+	switch otp.Kind {
+	case "":
+	case estree.Get, estree.Set:
+		if otp.Method || otp.Optional {
+			c.Appendf("method or optional %s accessor %w", otp.Kind, estree.ErrNotAllowed)
+		}
+	default:
+		c.Appendf("%w ObjectTypeProperty.Kind %q", estree.ErrWrongValue, otp.Kind)
+	}
+	if _, ok := otp.Value.(FunctionTypeAnnotation); !ok && otp.Value != nil && (otp.Method || otp.Kind != "") {
+		c.Appendf("%w method type %s", estree.ErrWrongValue, otp.Value.Type())
+	}
+	if otp.Proto && otp.Static {
+		c.Appendf("static proto property %w", estree.ErrNotAllowed)
+	}
+	c.Optional(otp.Variance)
+	return c.Errors()
+}
+
+func (otp ObjectTypeProperty) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(otp)
+	x["key"] = otp.Key
+	x["value"] = otp.Value
+	x["optional"] = otp.Optional
+	x["static"] = otp.Static
+	x["proto"] = otp.Proto
+	x["method"] = otp.Method
+	x["variance"] = varianceToJSON(otp.Variance)
+	if otp.Kind == "" {
+		x["kind"] = estree.Init
+	} else {
+		x["kind"] = otp.Kind
+	}
+	return json.Marshal(x)
+}
+
+func (otp *ObjectTypeProperty) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type     string              `json:"type"`
+		Key      json.RawMessage     `json:"key"`
+		Value    json.RawMessage     `json:"value"`
+		Optional bool                `json:"optional"`
+		Static   bool                `json:"static"`
+		Proto    bool                `json:"proto"`
+		Method   bool                `json:"method"`
+		Kind     estree.PropertyKind `json:"kind"`
+		Variance Variance            `json:"variance"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != otp.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, otp.Type(), x.Type)
+	}
+	if err == nil {
+		otp.Loc, otp.Optional, otp.Variance = x.Location(), x.Optional, x.Variance
+		otp.Static, otp.Proto, otp.Method = x.Static, x.Proto, x.Method
+		if x.Kind == estree.Init {
+			otp.Kind = ""
+		} else {
+			otp.Kind = x.Kind
+		}
+		var key estree.Expression
+		if key, err = estree.UnmarshalExpression(x.Key); err == nil && key != nil {
+			var ok bool
+			if otp.Key, ok = key.(estree.LiteralOrIdentifier); !ok {
+				err = fmt.Errorf("%w Identifier or Literal, got %v", estree.ErrWrongType, string(x.Key))
+			}
+		}
+		var err2 error
+		if otp.Value, err2 = unmarshalType(x.Value); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// ObjectTypeSpreadProperty spreads the properties of another type into an
+// ObjectTypeAnnotation, e.g. the ...Foo in { ...Foo, bar: string }.
+type ObjectTypeSpreadProperty struct {
+	Loc      estree.SourceLocation
+	Argument FlowType
+}
+
+func (ObjectTypeSpreadProperty) Type() string { return "ObjectTypeSpreadProperty" }
+func (otsp ObjectTypeSpreadProperty) Location() estree.SourceLocation {
+	return otsp.Loc
+}
+func (ObjectTypeSpreadProperty) MinVersion() estree.Version { return estree.ES5 }
+func (ObjectTypeSpreadProperty) isObjectTypeMember()        {}
+
+func (otsp ObjectTypeSpreadProperty) IsZero() bool {
+	return otsp.Loc.IsZero() &&
+		(otsp.Argument == nil || otsp.Argument.IsZero())
+}
+
+func (otsp ObjectTypeSpreadProperty) Walk(v estree.Visitor) {
+	if v = v.Visit(otsp); v != nil {
+		defer v.Visit(nil)
+		if otsp.Argument != nil {
+			otsp.Argument.Walk(v)
+		}
+	}
+}
+
+func (otsp ObjectTypeSpreadProperty) Errors() []error {
+	c := estree.NewChecker(otsp)
+	c.Require(otsp.Argument, "spread type")
+	return c.Errors()
+}
+
+func (otsp ObjectTypeSpreadProperty) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(otsp)
+	x["argument"] = otsp.Argument
+	return json.Marshal(x)
+}
+
+func (otsp *ObjectTypeSpreadProperty) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type     string          `json:"type"`
+		Argument json.RawMessage `json:"argument"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != otsp.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, otsp.Type(), x.Type)
+	}
+	if err == nil {
+		otsp.Loc = x.Location()
+		otsp.Argument, err = unmarshalType(x.Argument)
+	}
+	return err
+}
+
+// ObjectTypeIndexer is an indexer of an ObjectTypeAnnotation, e.g.
+// [key: string]: number.  The name of the key is optional.
+type ObjectTypeIndexer struct {
+	Loc      estree.SourceLocation
+	ID       estree.Identifier // possibly zero
+	Key      FlowType
+	Value    FlowType
+	Static   bool     // only in a DeclareClass body
+	Variance Variance // possibly zero
+}
+
+func (ObjectTypeIndexer) Type() string                        { return "ObjectTypeIndexer" }
+func (oti ObjectTypeIndexer) Location() estree.SourceLocation { return oti.Loc }
+func (ObjectTypeIndexer) MinVersion() estree.Version          { return estree.ES5 }
+
+func (oti ObjectTypeIndexer) IsZero() bool {
+	return oti.Loc.IsZero() &&
+		oti.ID.IsZero() &&
+		(oti.Key == nil || oti.Key.IsZero()) &&
+		(oti.Value == nil || oti.Value.IsZero()) &&
+		!oti.Static &&
+		oti.Variance.IsZero()
+}
+
+func (oti ObjectTypeIndexer) Walk(v estree.Visitor) {
+	if v = v.Visit(oti); v != nil {
+		defer v.Visit(nil)
+		if !oti.Variance.IsZero() {
+			oti.Variance.Walk(v)
+		}
+		if !oti.ID.IsZero() {
+			oti.ID.Walk(v)
+		}
+		if oti.Key != nil {
+			oti.Key.Walk(v)
+		}
+		if oti.Value != nil {
+			oti.Value.Walk(v)
+		}
+	}
+}
+
+func (oti ObjectTypeIndexer) Errors() []error {
+	c := estree.NewChecker(oti)
+	c.Optional(oti.ID)
+	c.Require(oti.Key, "indexer key type")
+	c.Require(oti.Value, "indexer value type")
+	c.Optional(oti.Variance)
+	return c.Errors()
+}
+
+func (oti ObjectTypeIndexer) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(oti)
+	if oti.ID.IsZero() {
+		x["id"] = nil
+	} else {
+		x["id"] = oti.ID
+	}
+	x["key"] = oti.Key
+	x["value"] = oti.Value
+	x["static"] = oti.Static
+	x["variance"] = varianceToJSON(oti.Variance)
+	return json.Marshal(x)
+}
+
+func (oti *ObjectTypeIndexer) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type     string            `json:"type"`
+		ID       estree.Identifier `json:"id"`
+		Key      json.RawMessage   `json:"key"`
+		Value    json.RawMessage   `json:"value"`
+		Static   bool              `json:"static"`
+		Variance Variance          `json:"variance"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != oti.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, oti.Type(), x.Type)
+	}
+	if err == nil {
+		oti.Loc, oti.ID, oti.Static, oti.Variance = x.Location(), x.ID, x.Static, x.Variance
+		oti.Key, err = unmarshalType(x.Key)
+		var err2 error
+		if oti.Value, err2 = unmarshalType(x.Value); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// ObjectTypeCallProperty makes an ObjectTypeAnnotation callable, e.g. the
+// (x: number): string in { (x: number): string }.
+type ObjectTypeCallProperty struct {
+	Loc    estree.SourceLocation
+	Value  FunctionTypeAnnotation
+	Static bool // only in a DeclareClass body
+}
+
+func (ObjectTypeCallProperty) Type() string { return "ObjectTypeCallProperty" }
+func (otcp ObjectTypeCallProperty) Location() estree.SourceLocation {
+	return otcp.Loc
+}
+func (ObjectTypeCallProperty) MinVersion() estree.Version { return estree.ES5 }
+
+func (otcp ObjectTypeCallProperty) IsZero() bool {
+	return otcp.Loc.IsZero() && otcp.Value.IsZero() && !otcp.Static
+}
+
+func (otcp ObjectTypeCallProperty) Walk(v estree.Visitor) {
+	if v = v.Visit(otcp); v != nil {
+		defer v.Visit(nil)
+		otcp.Value.Walk(v)
+	}
+}
+
+func (otcp ObjectTypeCallProperty) Errors() []error {
+	c := estree.NewChecker(otcp)
+	c.Require(otcp.Value, "call signature")
+	return c.Errors()
+}
+
+func (otcp ObjectTypeCallProperty) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(otcp)
+	x["value"] = otcp.Value
+	x["static"] = otcp.Static
+	return json.Marshal(x)
+}
+
+func (otcp *ObjectTypeCallProperty) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type   string                 `json:"type"`
+		Value  FunctionTypeAnnotation `json:"value"`
+		Static bool                   `json:"static"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != otcp.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, otcp.Type(), x.Type)
+	}
+	if err == nil {
+		otcp.Loc, otcp.Value, otcp.Static = x.Location(), x.Value, x.Static
+	}
+	return err
+}
+
+// ObjectTypeInternalSlot is an internal slot of an ObjectTypeAnnotation,
+// e.g. [[call]]: () => void.
+type ObjectTypeInternalSlot struct {
+	Loc      estree.SourceLocation
+	ID       estree.Identifier
+	Value    FlowType
+	Optional bool
+	Static   bool
+	Method   bool
+}
+
+func (ObjectTypeInternalSlot) Type() string { return "ObjectTypeInternalSlot" }
+func (otis ObjectTypeInternalSlot) Location() estree.SourceLocation {
+	return otis.Loc
+}
+func (ObjectTypeInternalSlot) MinVersion() estree.Version { return estree.ES5 }
+
+func (otis ObjectTypeInternalSlot) IsZero() bool {
+	return otis.Loc.IsZero() &&
+		otis.ID.IsZero() &&
+		(otis.Value == nil || otis.Value.IsZero()) &&
+		!otis.Optional &&
+		!otis.Static &&
+		!otis.Method
+}
+
+func (otis ObjectTypeInternalSlot) Walk(v estree.Visitor) {
+	if v = v.Visit(otis); v != nil {
+		defer v.Visit(nil)
+		otis.ID.Walk(v)
+		if otis.Value != nil {
+			otis.Value.Walk(v)
+		}
+	}
+}
+
+func (otis ObjectTypeInternalSlot) Errors() []error {
+	c := estree.NewChecker(otis)
+	c.Require(otis.ID, "internal slot name")
+	c.Require(otis.Value, "internal slot type")
+	if _, ok := otis.Value.(FunctionTypeAnnotation); !ok && otis.Value != nil && otis.Method {
+		c.Appendf("%w method type %s", estree.ErrWrongValue, otis.Value.Type())
+	}
+	return c.Errors()
+}
+
+func (otis ObjectTypeInternalSlot) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(otis)
+	x["id"] = otis.ID
+	x["value"] = otis.Value
+	x["optional"] = otis.Optional
+	x["static"] = otis.Static
+	x["method"] = otis.Method
+	return json.Marshal(x)
+}
+
+func (otis *ObjectTypeInternalSlot) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type     string            `json:"type"`
+		ID       estree.Identifier `json:"id"`
+		Value    json.RawMessage   `json:"value"`
+		Optional bool              `json:"optional"`
+		Static   bool              `json:"static"`
+		Method   bool              `json:"method"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != otis.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, otis.Type(), x.Type)
+	}
+	if err == nil {
+		otis.Loc, otis.ID, otis.Optional = x.Location(), x.ID, x.Optional
+		otis.Static, otis.Method = x.Static, x.Method
+		otis.Value, err = unmarshalType(x.Value)
+	}
+	return err
+}
+
+// FunctionTypeAnnotation is a function type, e.g. (x: number) => string.
+type FunctionTypeAnnotation struct {
+	baseType
+	Loc        estree.SourceLocation
+	Params     []FunctionTypeParam
+	Rest       FunctionTypeParam // possibly zero
+	ReturnType FlowType
+}
+
+func (FunctionTypeAnnotation) Type() string                        { return "FunctionTypeAnnotation" }
+func (fta FunctionTypeAnnotation) Location() estree.SourceLocation { return fta.Loc }
+
+func (fta FunctionTypeAnnotation) IsZero() bool {
+	return fta.Loc.IsZero() &&
+		len(fta.Params) == 0 &&
+		fta.Rest.IsZero() &&
+		(fta.ReturnType == nil || fta.ReturnType.IsZero())
+}
+
+func (fta FunctionTypeAnnotation) Walk(v estree.Visitor) {
+	if v = v.Visit(fta); v != nil {
+		defer v.Visit(nil)
+		for _, p := range fta.Params {
+			p.Walk(v)
+		}
+		if !fta.Rest.IsZero() {
+			fta.Rest.Walk(v)
+		}
+		if fta.ReturnType != nil {
+			fta.ReturnType.Walk(v)
+		}
+	}
+}
+
+func (fta FunctionTypeAnnotation) Errors() []error {
+	c := estree.NewChecker(fta)
+	c.RequireEach(len(fta.Params), func(i int) estree.Node { return fta.Params[i] }, "function parameter")
+	c.Optional(fta.Rest)
+	c.Require(fta.ReturnType, "return type")
+	return c.Errors()
+}
+
+func (fta FunctionTypeAnnotation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(fta)
+	if len(fta.Params) == 0 {
+		x["params"] = []FunctionTypeParam{}
+	} else {
+		x["params"] = fta.Params
+	}
+	if fta.Rest.IsZero() {
+		x["rest"] = nil
+	} else {
+		x["rest"] = fta.Rest
+	}
+	x["returnType"] = fta.ReturnType
+	x["typeParameters"] = nil
+	return json.Marshal(x)
+}
+
+func (fta *FunctionTypeAnnotation) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type       string              `json:"type"`
+		Params     []FunctionTypeParam `json:"params"`
+		Rest       FunctionTypeParam   `json:"rest"`
+		ReturnType json.RawMessage     `json:"returnType"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != fta.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, fta.Type(), x.Type)
+	}
+	if err == nil {
+		fta.Loc, fta.Rest = x.Location(), x.Rest
+		if len(x.Params) == 0 {
+			fta.Params = nil
+		} else {
+			fta.Params = x.Params
+		}
+		fta.ReturnType, err = unmarshalType(x.ReturnType)
+	}
+	return err
+}
+
+// FunctionTypeParam is a parameter of a FunctionTypeAnnotation, e.g.
+// x?: number.  Parameter names are optional in function types.
+type FunctionTypeParam struct {
+	Loc            estree.SourceLocation
+	Name           estree.Identifier // possibly zero
+	TypeAnnotation FlowType
+	Optional       bool
+}
+
+func (FunctionTypeParam) Type() string                        { return "FunctionTypeParam" }
+func (ftp FunctionTypeParam) Location() estree.SourceLocation { return ftp.Loc }
+func (FunctionTypeParam) MinVersion() estree.Version          { return estree.ES5 }
+
+func (ftp FunctionTypeParam) IsZero() bool {
+	return ftp.Loc.IsZero() &&
+		ftp.Name.IsZero() &&
+		(ftp.TypeAnnotation == nil || ftp.TypeAnnotation.IsZero()) &&
+		!ftp.Optional
+}
+
+func (ftp FunctionTypeParam) Walk(v estree.Visitor) {
+	if v = v.Visit(ftp); v != nil {
+		defer v.Visit(nil)
+		if !ftp.Name.IsZero() {
+			ftp.Name.Walk(v)
+		}
+		if ftp.TypeAnnotation != nil {
+			ftp.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (ftp FunctionTypeParam) Errors() []error {
+	c := estree.NewChecker(ftp)
+	c.Optional(ftp.Name)
+	c.Require(ftp.TypeAnnotation, "parameter type")
+	return c.Errors()
+}
+
+func (ftp FunctionTypeParam) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ftp)
+	if ftp.Name.IsZero() {
+		x["name"] = nil
+	} else {
+		x["name"] = ftp.Name
+	}
+	x["typeAnnotation"] = ftp.TypeAnnotation
+	x["optional"] = ftp.Optional
+	return json.Marshal(x)
+}
+
+func (ftp *FunctionTypeParam) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		return nil // optional rest parameters are null when absent
+	}
+	var x struct {
+		estree.NodeFields
+
+		Type           string            `json:"type"`
+		Name           estree.Identifier `json:"name"`
+		TypeAnnotation json.RawMessage   `json:"typeAnnotation"`
+		Optional       bool              `json:"optional"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ftp.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ftp.Type(), x.Type)
+	}
+	if err == nil {
+		ftp.Loc, ftp.Name, ftp.Optional = x.Location(), x.Name, x.Optional
+		ftp.TypeAnnotation, err = unmarshalType(x.TypeAnnotation)
+	}
+	return err
+}
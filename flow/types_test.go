@@ -0,0 +1,330 @@
+package flow
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"pifke.org/estree"
+)
+
+func TestPrimitiveTypeAnnotation(t *testing.T) {
+	var pta PrimitiveTypeAnnotation
+	if !pta.IsZero() {
+		t.Error("expected IsZero()")
+	}
+	if !hasError(estree.ErrWrongValue, pta.Errors()...) {
+		t.Error("expected ErrWrongValue for empty Kind")
+	}
+
+	for _, k := range []PrimitiveKind{Any, Mixed, Empty, Void, Null, Boolean, Number, BigInt, String, Symbol} {
+		pta.Kind = k
+		if pta.IsZero() {
+			t.Error("expected !IsZero()")
+		}
+		if pta.Type() != string(k) {
+			t.Errorf("expected %s, got %s", k, pta.Type())
+		}
+		if errs := pta.Errors(); len(errs) != 0 {
+			t.Errorf("unexpected errors: %v", errs)
+		}
+		testRoundtripJSON(t, pta, new(PrimitiveTypeAnnotation))
+	}
+
+	var v mockVisitor
+	pta.Walk(&v)
+	v.expect(t, pta, nil)
+}
+
+func TestGenericTypeAnnotation(t *testing.T) {
+	var gta GenericTypeAnnotation
+	if !gta.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// React.Element<"div">
+	gta.ID = QualifiedTypeIdentifier{
+		Qualification: estree.Identifier{Name: "React"},
+		ID:            estree.Identifier{Name: "Element"},
+	}
+	gta.TypeParameters.Params = []FlowType{StringLiteralTypeAnnotation{Value: "div", Raw: `"div"`}}
+	if gta.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	qti := gta.ID.(QualifiedTypeIdentifier)
+	var v mockVisitor
+	gta.Walk(&v)
+	v.expect(t, gta,
+		qti, qti.Qualification, nil, qti.ID, nil, nil,
+		gta.TypeParameters, gta.TypeParameters.Params[0], nil, nil, nil)
+
+	testRoundtripJSON(t, gta, new(GenericTypeAnnotation))
+	testRoundtripJSON(t, GenericTypeAnnotation{ID: estree.Identifier{Name: "Foo"}}, new(GenericTypeAnnotation))
+
+	if errs := estree.Validate(gta); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	gta.ID = estree.StringLiteral{Value: "Foo"}
+	if !hasError(estree.ErrWrongValue, gta.Errors()...) {
+		t.Error("expected ErrWrongValue for StringLiteral type name")
+	}
+	gta.ID = nil
+	if !hasError(estree.ErrMissingNode, gta.Errors()...) {
+		t.Error("expected ErrMissingNode for nil ID")
+	}
+}
+
+func TestUnionTypeAnnotation(t *testing.T) {
+	var uta UnionTypeAnnotation
+	if !uta.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// ?string | Array<number>
+	uta.Types = []FlowType{
+		NullableTypeAnnotation{TypeAnnotation: PrimitiveTypeAnnotation{Kind: String}},
+		ArrayTypeAnnotation{ElementType: PrimitiveTypeAnnotation{Kind: Number}},
+	}
+	if uta.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	var v mockVisitor
+	uta.Walk(&v)
+	v.expect(t, uta,
+		uta.Types[0], PrimitiveTypeAnnotation{Kind: String}, nil, nil,
+		uta.Types[1], PrimitiveTypeAnnotation{Kind: Number}, nil, nil, nil)
+
+	testRoundtripJSON(t, uta, new(UnionTypeAnnotation))
+	if errs := estree.Validate(uta); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	ita := IntersectionTypeAnnotation{Types: uta.Types}
+	testRoundtripJSON(t, ita, new(IntersectionTypeAnnotation))
+	if errs := estree.Validate(ita); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ita.Types = nil
+	if !hasError(estree.ErrMissingNode, ita.Errors()...) {
+		t.Error("expected ErrMissingNode for empty intersection")
+	}
+}
+
+func TestObjectTypeAnnotation(t *testing.T) {
+	var ota ObjectTypeAnnotation
+	if ota.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	// {| foo?: string, "bar": (x: number, ...rest: Array<mixed>) => void |}
+	ota.Exact = true
+	ota.Properties = []ObjectTypeMember{
+		ObjectTypeProperty{
+			Key:      estree.Identifier{Name: "foo"},
+			Value:    PrimitiveTypeAnnotation{Kind: String},
+			Optional: true,
+		},
+		ObjectTypeProperty{
+			Key: estree.StringLiteral{Value: "bar"},
+			Value: FunctionTypeAnnotation{
+				Params: []FunctionTypeParam{
+					FunctionTypeParam{
+						Name:           estree.Identifier{Name: "x"},
+						TypeAnnotation: PrimitiveTypeAnnotation{Kind: Number},
+					},
+				},
+				Rest: FunctionTypeParam{
+					Name: estree.Identifier{Name: "rest"},
+					TypeAnnotation: GenericTypeAnnotation{
+						ID: estree.Identifier{Name: "Array"},
+						TypeParameters: TypeParameterInstantiation{
+							Params: []FlowType{PrimitiveTypeAnnotation{Kind: Mixed}},
+						},
+					},
+				},
+				ReturnType: PrimitiveTypeAnnotation{Kind: Void},
+			},
+		},
+	}
+
+	testRoundtripJSON(t, ota, new(ObjectTypeAnnotation))
+	testRoundtripJSON(t, ObjectTypeAnnotation{}, new(ObjectTypeAnnotation))
+	testRoundtripJSON(t, FunctionTypeAnnotation{ReturnType: PrimitiveTypeAnnotation{Kind: Void}}, new(FunctionTypeAnnotation))
+
+	if errs := estree.Validate(ota); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	otp := ota.Properties[0].(ObjectTypeProperty)
+	otp.Key = estree.NumberLiteral{Value: 1}
+	if !hasError(estree.ErrWrongValue, otp.Errors()...) {
+		t.Error("expected ErrWrongValue for NumberLiteral key")
+	}
+	ota.Properties[0] = ObjectTypeProperty{}
+	if !hasError(estree.ErrMissingNode, ota.Errors()...) {
+		t.Error("expected ErrMissingNode for zero property")
+	}
+	if !hasError(estree.ErrMissingNode, FunctionTypeAnnotation{}.Errors()...) {
+		t.Error("expected ErrMissingNode for missing return type")
+	}
+}
+
+func TestObjectTypeMembers(t *testing.T) {
+	// {
+	//   ...Foo,
+	//   +[key: string]: number,
+	//   (x: number): string,
+	//   [[call]](): void,
+	//   get bar(): string,
+	//   baz(): void,
+	//   ...
+	// }
+	call := FunctionTypeAnnotation{
+		Params: []FunctionTypeParam{
+			FunctionTypeParam{
+				Name:           estree.Identifier{Name: "x"},
+				TypeAnnotation: PrimitiveTypeAnnotation{Kind: Number},
+			},
+		},
+		ReturnType: PrimitiveTypeAnnotation{Kind: String},
+	}
+	method := FunctionTypeAnnotation{ReturnType: PrimitiveTypeAnnotation{Kind: Void}}
+	ota := ObjectTypeAnnotation{
+		Properties: []ObjectTypeMember{
+			ObjectTypeSpreadProperty{Argument: GenericTypeAnnotation{ID: estree.Identifier{Name: "Foo"}}},
+			ObjectTypeProperty{
+				Key:   estree.Identifier{Name: "bar"},
+				Value: FunctionTypeAnnotation{ReturnType: PrimitiveTypeAnnotation{Kind: String}},
+				Kind:  estree.Get,
+			},
+			ObjectTypeProperty{
+				Key:    estree.Identifier{Name: "baz"},
+				Value:  method,
+				Method: true,
+			},
+		},
+		Indexers: []ObjectTypeIndexer{
+			ObjectTypeIndexer{
+				ID:       estree.Identifier{Name: "key"},
+				Key:      PrimitiveTypeAnnotation{Kind: String},
+				Value:    PrimitiveTypeAnnotation{Kind: Number},
+				Variance: Variance{Kind: Covariant},
+			},
+		},
+		CallProperties: []ObjectTypeCallProperty{ObjectTypeCallProperty{Value: call}},
+		InternalSlots: []ObjectTypeInternalSlot{
+			ObjectTypeInternalSlot{ID: estree.Identifier{Name: "call"}, Value: method, Method: true},
+		},
+		Inexact: true,
+	}
+
+	var v mockVisitor
+	ota.Indexers[0].Walk(&v)
+	oti := ota.Indexers[0]
+	v.expect(t, oti, oti.Variance, nil, oti.ID, nil, oti.Key, nil, oti.Value, nil, nil)
+
+	testRoundtripJSON(t, ota, new(ObjectTypeAnnotation))
+	testRoundtripJSON(t, ota.Properties[0], new(ObjectTypeSpreadProperty))
+	testRoundtripJSON(t, ota.Properties[1], new(ObjectTypeProperty))
+	testRoundtripJSON(t, oti, new(ObjectTypeIndexer))
+	testRoundtripJSON(t, ObjectTypeIndexer{Key: oti.Key, Value: oti.Value}, new(ObjectTypeIndexer))
+	testRoundtripJSON(t, ota.CallProperties[0], new(ObjectTypeCallProperty))
+	testRoundtripJSON(t, ota.InternalSlots[0], new(ObjectTypeInternalSlot))
+	testRoundtripJSON(t, oti.Variance, new(Variance))
+
+	if errs := estree.Validate(ota); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ota.Exact = true
+	if !hasError(estree.ErrNotAllowed, ota.Errors()...) {
+		t.Error("expected ErrNotAllowed for exact and inexact")
+	}
+	ota.Properties[2] = ObjectTypeProperty{
+		Key:    estree.Identifier{Name: "baz"},
+		Value:  PrimitiveTypeAnnotation{Kind: Void},
+		Method: true,
+	}
+	if !hasError(estree.ErrWrongValue, ota.Properties[2].Errors()...) {
+		t.Error("expected ErrWrongValue for method without function type")
+	}
+	ota.Indexers[0].Variance.Kind = "both"
+	if !hasError(estree.ErrWrongValue, estree.Validate(ota)...) {
+		t.Error("expected ErrWrongValue for invalid Variance")
+	}
+	ota.Properties[0] = ObjectTypeSpreadProperty{}
+	if !hasError(estree.ErrMissingNode, ota.Properties[0].Errors()...) {
+		t.Error("expected ErrMissingNode for missing spread argument")
+	}
+	if !hasError(estree.ErrMissingNode, ObjectTypeCallProperty{}.Errors()...) {
+		t.Error("expected ErrMissingNode for missing call signature")
+	}
+
+	b := []byte(`{"type":"ObjectTypeAnnotation","properties":[{"type":"ObjectTypeFoo"}],` +
+		`"indexers":[],"callProperties":[],"internalSlots":[],"exact":false}`)
+	if err := json.Unmarshal(b, new(ObjectTypeAnnotation)); !hasError(estree.ErrWrongType, err) {
+		t.Errorf("expected ErrWrongType, got %v", err)
+	}
+	b = []byte(`{"type":"ObjectTypeAnnotation","properties":[],` +
+		`"indexers":[{"type":"ObjectTypeFoo"}],"callProperties":[],"internalSlots":[],"exact":false}`)
+	if err := json.Unmarshal(b, new(ObjectTypeAnnotation)); !hasError(estree.ErrWrongType, err) {
+		t.Errorf("expected ErrWrongType, got %v", err)
+	}
+
+	tp := TypeParameter{Name: "T", Variance: Variance{Kind: Contravariant}}
+	testRoundtripJSON(t, tp, new(TypeParameter))
+}
+
+func TestLiteralTypes(t *testing.T) {
+	// [42, true, typeof foo]
+	tta := TupleTypeAnnotation{Types: []FlowType{
+		NumberLiteralTypeAnnotation{Value: 42, Raw: "42"},
+		BooleanLiteralTypeAnnotation{Value: true, Raw: "true"},
+		TypeofTypeAnnotation{Argument: GenericTypeAnnotation{ID: estree.Identifier{Name: "foo"}}},
+	}}
+	if tta.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	var v mockVisitor
+	tta.Walk(&v)
+	typeof := tta.Types[2].(TypeofTypeAnnotation)
+	v.expect(t, tta,
+		tta.Types[0], nil,
+		tta.Types[1], nil,
+		typeof, typeof.Argument, typeof.Argument.(GenericTypeAnnotation).ID, nil, nil, nil, nil)
+
+	testRoundtripJSON(t, tta, new(TupleTypeAnnotation))
+	testRoundtripJSON(t, TupleTypeAnnotation{}, new(TupleTypeAnnotation))
+	testRoundtripJSON(t, tta.Types[0], new(NumberLiteralTypeAnnotation))
+	testRoundtripJSON(t, tta.Types[1], new(BooleanLiteralTypeAnnotation))
+	testRoundtripJSON(t, typeof, new(TypeofTypeAnnotation))
+
+	if errs := estree.Validate(tta); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	tta.Types[2] = TypeofTypeAnnotation{}
+	if !hasError(estree.ErrMissingNode, tta.Types[2].Errors()...) {
+		t.Error("expected ErrMissingNode for missing typeof argument")
+	}
+	tta.Types[1] = nil
+	if !hasError(estree.ErrMissingNode, tta.Errors()...) {
+		t.Error("expected ErrMissingNode for nil element")
+	}
+}
+
+func TestUnmarshalType(t *testing.T) {
+	b := []byte(`{"type":"ArrayTypeAnnotation","elementType":{"type":"GenericTypeAnnotation",` +
+		`"id":{"type":"Identifier","name":"Foo"},"typeParameters":null}}`)
+	typ, err := unmarshalType(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := ArrayTypeAnnotation{ElementType: GenericTypeAnnotation{ID: estree.Identifier{Name: "Foo"}}}
+	if !reflect.DeepEqual(typ, expect) {
+		t.Errorf("expected %#v, got %#v", expect, typ)
+	}
+	if _, err := unmarshalType([]byte(`{"type":"Identifier","name":"Foo"}`)); !hasError(estree.ErrWrongType, err) {
+		t.Errorf("expected ErrWrongType, got %v", err)
+	}
+}
@@ -6,12 +6,97 @@ type Function interface {
 	FunctionID() Identifier // or nil
 	FunctionParams() []Pattern
 	FunctionBody() FunctionBody
+	FunctionGenerator() bool
+	FunctionAsync() bool
 }
 
 func (fd FunctionDeclaration) FunctionID() Identifier     { return fd.ID }
 func (fd FunctionDeclaration) FunctionParams() []Pattern  { return fd.Params }
 func (fd FunctionDeclaration) FunctionBody() FunctionBody { return fd.Body }
+func (fd FunctionDeclaration) FunctionGenerator() bool    { return fd.Generator }
+func (fd FunctionDeclaration) FunctionAsync() bool        { return fd.Async }
 
 func (fe FunctionExpression) FunctionID() Identifier     { return fe.ID }
 func (fe FunctionExpression) FunctionParams() []Pattern  { return fe.Params }
 func (fe FunctionExpression) FunctionBody() FunctionBody { return fe.Body }
+func (fe FunctionExpression) FunctionGenerator() bool    { return fe.Generator }
+func (fe FunctionExpression) FunctionAsync() bool        { return fe.Async }
+
+func (afe ArrowFunctionExpression) FunctionID() Identifier    { return Identifier{} }
+func (afe ArrowFunctionExpression) FunctionParams() []Pattern { return afe.Params }
+func (afe ArrowFunctionExpression) FunctionGenerator() bool   { return afe.Generator }
+func (afe ArrowFunctionExpression) FunctionAsync() bool       { return afe.Async }
+
+// FunctionBody returns the body of the arrow function.  If the body is an
+// Expression, it is returned as the argument of a ReturnStatement, which is
+// equivalent.
+func (afe ArrowFunctionExpression) FunctionBody() FunctionBody {
+	switch b := afe.Body.(type) {
+	case FunctionBody:
+		return b
+	case Expression:
+		return FunctionBody{
+			Loc: b.Location(),
+			Body: []DirectiveOrStatement{
+				ReturnStatement{Loc: b.Location(), Argument: b},
+			},
+		}
+	}
+	return FunctionBody{}
+}
+
+// enclosingFunction returns the innermost Function in ancestors, or nil if
+// there is none.
+func enclosingFunction(ancestors []Node) Function {
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		if f, ok := ancestors[i].(Function); ok {
+			return f
+		}
+	}
+	return nil
+}
+
+// functionMinVersion returns the MinVersion implied by a Function's
+// Generator and Async flags.
+func functionMinVersion(generator, async bool) Version {
+	switch {
+	case generator && async:
+		return ES2018
+	case async:
+		return ES2017
+	case generator:
+		return ES2015
+	}
+	return ES5
+}
+
+// awaitAllowed indicates whether ancestors describe a position where await
+// may appear, i.e. inside an async function or at the top level of a module.
+// Class field initializers and static blocks are never async.
+func awaitAllowed(ancestors []Node) bool {
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		switch a := ancestors[i].(type) {
+		case Function:
+			return a.FunctionAsync()
+		case PropertyDefinition, StaticBlock:
+			return false
+		}
+	}
+	p, ok := rootNode(ancestors).(Program)
+	return ok && p.SourceType == Module
+}
+
+// yieldAllowed indicates whether ancestors describe a position where yield
+// may appear, i.e. inside a generator function.  Class field initializers and
+// static blocks are never generators.
+func yieldAllowed(ancestors []Node) bool {
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		switch a := ancestors[i].(type) {
+		case Function:
+			return a.FunctionGenerator()
+		case PropertyDefinition, StaticBlock:
+			return false
+		}
+	}
+	return false
+}
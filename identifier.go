@@ -10,6 +10,9 @@ type Identifier struct {
 	baseExpression
 	Loc  SourceLocation
 	Name string
+
+	TypeAnnotation TypeAnnotation // or nil
+	Optional       bool           // e.g. x?: T, in TypeScript or Flow
 }
 
 func (Identifier) Type() string               { return "Identifier" }
@@ -19,12 +22,16 @@ func (Identifier) isLiteralOrIdentifier()     {}
 func (Identifier) isPatternOrExpression()     {}
 
 func (i Identifier) IsZero() bool {
-	return i.Loc.IsZero() && i.Name == ""
+	return i.Loc.IsZero() && i.Name == "" && i.TypeAnnotation == nil &&
+		!i.Optional
 }
 
 func (i Identifier) Walk(v Visitor) {
 	if v = v.Visit(i); v != nil {
-		v.Visit(nil)
+		defer v.Visit(nil)
+		if i.TypeAnnotation != nil {
+			i.TypeAnnotation.Walk(v)
+		}
 	}
 }
 
@@ -34,27 +41,42 @@ func (i Identifier) Errors() []error {
 		c.appendf("%w empty identifier not allowed", ErrWrongValue)
 	}
 	// TODO: other validity checks?
+	c.optional(i.TypeAnnotation)
 	return c.errors()
 }
 
 func (i Identifier) MarshalJSON() ([]byte, error) {
 	x := nodeToMap(i)
 	x["name"] = i.Name
+	if i.TypeAnnotation != nil {
+		x["typeAnnotation"] = i.TypeAnnotation
+	}
+	if i.Optional {
+		x["optional"] = true
+	}
 	return json.Marshal(x)
 }
 
 func (i *Identifier) UnmarshalJSON(b []byte) error {
+	if isNullOrEmptyRawMessage(b) {
+		return nil // optional Identifiers are null when absent
+	}
 	var x struct {
-		Type string         `json:"type"`
-		Loc  SourceLocation `json:"loc"`
-		Name string         `json:"name"`
+		NodeFields
+
+		Type string `json:"type"`
+		Name string `json:"name"`
+
+		TypeAnnotation json.RawMessage `json:"typeAnnotation"`
+		Optional       bool            `json:"optional"`
 	}
 	err := json.Unmarshal(b, &x)
 	if err == nil && x.Type != i.Type() {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, i.Type(), x.Type)
 	}
 	if err == nil {
-		i.Loc, i.Name = x.Loc, x.Name
+		i.Loc, i.Name, i.Optional = x.Location(), x.Name, x.Optional
+		i.TypeAnnotation, err = unmarshalTypeAnnotation(x.TypeAnnotation)
 	}
 	return err
 }
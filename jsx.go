@@ -0,0 +1,1053 @@
+package estree
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// This file implements the JSX extension to ESTree, as described at
+// https://github.com/facebook/jsx/blob/main/AST.md.  JSX is not part of any
+// ECMAScript version, so these Nodes report ES5 from MinVersion.
+
+// JSXElementName is the name of a JSXOpeningElement or JSXClosingElement.
+type JSXElementName interface {
+	Node
+	isJSXElementName()
+}
+
+func (JSXIdentifier) isJSXElementName()       {}
+func (JSXMemberExpression) isJSXElementName() {}
+func (JSXNamespacedName) isJSXElementName()   {}
+
+func unmarshalJSXElementName(m json.RawMessage) (n JSXElementName, err error) {
+	if isNullOrEmptyRawMessage(m) {
+		return nil, nil
+	}
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err = json.Unmarshal(m, &x); err == nil {
+		switch x.Type {
+		case JSXIdentifier{}.Type():
+			var ji JSXIdentifier
+			err, n = json.Unmarshal(m, &ji), ji
+		case JSXMemberExpression{}.Type():
+			var jme JSXMemberExpression
+			err, n = json.Unmarshal(m, &jme), jme
+		case JSXNamespacedName{}.Type():
+			var jnn JSXNamespacedName
+			err, n = json.Unmarshal(m, &jnn), jnn
+		default:
+			err = fmt.Errorf("%w JSXIdentifier, JSXMemberExpression, or JSXNamespacedName, got %v", ErrWrongType, string(m))
+		}
+	}
+	return
+}
+
+// jsxName returns the source representation of a JSXElementName, e.g.
+// "foo.bar" or "foo:bar", for comparing opening and closing tags.
+func jsxName(n Node) string {
+	switch x := n.(type) {
+	case JSXIdentifier:
+		return x.Name
+	case JSXMemberExpression:
+		return jsxName(x.Object) + "." + x.Property.Name
+	case JSXNamespacedName:
+		return x.Namespace.Name + ":" + x.Name.Name
+	}
+	return ""
+}
+
+// JSXIdentifierOrMemberExpression is the Object of a JSXMemberExpression.
+type JSXIdentifierOrMemberExpression interface {
+	JSXElementName
+	isJSXIdentifierOrMemberExpression()
+}
+
+func (JSXIdentifier) isJSXIdentifierOrMemberExpression()       {}
+func (JSXMemberExpression) isJSXIdentifierOrMemberExpression() {}
+
+func unmarshalJSXIdentifierOrMemberExpression(m json.RawMessage) (JSXIdentifierOrMemberExpression, error) {
+	n, err := unmarshalJSXElementName(m)
+	if err != nil || n == nil {
+		return nil, err
+	}
+	if o, ok := n.(JSXIdentifierOrMemberExpression); ok {
+		return o, nil
+	}
+	return nil, fmt.Errorf("%w JSXIdentifier or JSXMemberExpression, got %v", ErrWrongType, string(m))
+}
+
+// JSXIdentifierOrNamespacedName is the Name of a JSXAttribute.
+type JSXIdentifierOrNamespacedName interface {
+	JSXElementName
+	isJSXIdentifierOrNamespacedName()
+}
+
+func (JSXIdentifier) isJSXIdentifierOrNamespacedName()     {}
+func (JSXNamespacedName) isJSXIdentifierOrNamespacedName() {}
+
+func unmarshalJSXIdentifierOrNamespacedName(m json.RawMessage) (JSXIdentifierOrNamespacedName, error) {
+	n, err := unmarshalJSXElementName(m)
+	if err != nil || n == nil {
+		return nil, err
+	}
+	if o, ok := n.(JSXIdentifierOrNamespacedName); ok {
+		return o, nil
+	}
+	return nil, fmt.Errorf("%w JSXIdentifier or JSXNamespacedName, got %v", ErrWrongType, string(m))
+}
+
+// JSXAttributeValue is the Value of a JSXAttribute, which is either a
+// Literal, JSXExpressionContainer, JSXElement, or JSXFragment.
+type JSXAttributeValue interface {
+	Node
+	isJSXAttributeValue()
+}
+
+func (JSXExpressionContainer) isJSXAttributeValue() {}
+func (JSXElement) isJSXAttributeValue()             {}
+func (JSXFragment) isJSXAttributeValue()            {}
+
+func unmarshalJSXAttributeValue(m json.RawMessage) (v JSXAttributeValue, err error) {
+	if isNullOrEmptyRawMessage(m) {
+		return nil, nil
+	}
+	if l, match, err := unmarshalLiteral(m); match {
+		return l, err
+	}
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err = json.Unmarshal(m, &x); err == nil {
+		switch x.Type {
+		case JSXExpressionContainer{}.Type():
+			var jec JSXExpressionContainer
+			err, v = json.Unmarshal(m, &jec), jec
+		case JSXElement{}.Type():
+			var je JSXElement
+			err, v = json.Unmarshal(m, &je), je
+		case JSXFragment{}.Type():
+			var jf JSXFragment
+			err, v = json.Unmarshal(m, &jf), jf
+		default:
+			err = fmt.Errorf("%w JSXAttributeValue, got %v", ErrWrongType, string(m))
+		}
+	}
+	return
+}
+
+// JSXAttributeOrSpread is an element of JSXOpeningElement.Attributes.
+type JSXAttributeOrSpread interface {
+	Node
+	isJSXAttributeOrSpread()
+}
+
+func (JSXAttribute) isJSXAttributeOrSpread()       {}
+func (JSXSpreadAttribute) isJSXAttributeOrSpread() {}
+
+func unmarshalJSXAttributeOrSpread(m json.RawMessage) (a JSXAttributeOrSpread, err error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err = json.Unmarshal(m, &x); err == nil {
+		switch x.Type {
+		case JSXAttribute{}.Type():
+			var ja JSXAttribute
+			err, a = json.Unmarshal(m, &ja), ja
+		case JSXSpreadAttribute{}.Type():
+			var jsa JSXSpreadAttribute
+			err, a = json.Unmarshal(m, &jsa), jsa
+		default:
+			err = fmt.Errorf("%w JSXAttribute or JSXSpreadAttribute, got %v", ErrWrongType, string(m))
+		}
+	}
+	return
+}
+
+// JSXChild is a child of a JSXElement or JSXFragment.
+type JSXChild interface {
+	Node
+	isJSXChild()
+}
+
+func (JSXText) isJSXChild()                {}
+func (JSXExpressionContainer) isJSXChild() {}
+func (JSXElement) isJSXChild()             {}
+func (JSXFragment) isJSXChild()            {}
+
+func unmarshalJSXChild(m json.RawMessage) (c JSXChild, err error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err = json.Unmarshal(m, &x); err == nil {
+		switch x.Type {
+		case JSXText{}.Type():
+			var jt JSXText
+			err, c = json.Unmarshal(m, &jt), jt
+		case JSXExpressionContainer{}.Type():
+			var jec JSXExpressionContainer
+			err, c = json.Unmarshal(m, &jec), jec
+		case JSXElement{}.Type():
+			var je JSXElement
+			err, c = json.Unmarshal(m, &je), je
+		case JSXFragment{}.Type():
+			var jf JSXFragment
+			err, c = json.Unmarshal(m, &jf), jf
+		default:
+			err = fmt.Errorf("%w JSXChild, got %v", ErrWrongType, string(m))
+		}
+	}
+	return
+}
+
+func unmarshalJSXChildren(m []json.RawMessage) (children []JSXChild, err error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	children = make([]JSXChild, len(m))
+	for i := range m {
+		var err2 error
+		children[i], err2 = unmarshalJSXChild(m[i])
+		if err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return
+}
+
+// ExpressionOrJSXEmptyExpression is the Expression of a
+// JSXExpressionContainer.
+type ExpressionOrJSXEmptyExpression interface {
+	Node
+	isExpressionOrJSXEmptyExpression()
+}
+
+func unmarshalExpressionOrJSXEmptyExpression(m json.RawMessage) (ExpressionOrJSXEmptyExpression, error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(m, &x); err == nil && x.Type == (JSXEmptyExpression{}).Type() {
+		var jee JSXEmptyExpression
+		if err = json.Unmarshal(m, &jee); err != nil {
+			return nil, err
+		}
+		return jee, nil
+	}
+	e, _, err := unmarshalExpression(m)
+	if err != nil || e == nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// JSXIdentifier is an identifier within a JSX element or attribute name.
+// Unlike Identifier, it may contain dashes, e.g. data-foo.
+type JSXIdentifier struct {
+	Loc  SourceLocation
+	Name string
+}
+
+func (JSXIdentifier) Type() string                { return "JSXIdentifier" }
+func (ji JSXIdentifier) Location() SourceLocation { return ji.Loc }
+func (JSXIdentifier) MinVersion() Version         { return ES5 }
+
+func (ji JSXIdentifier) IsZero() bool {
+	return ji.Loc.IsZero() && ji.Name == ""
+}
+
+func (ji JSXIdentifier) Walk(v Visitor) {
+	if v = v.Visit(ji); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (ji JSXIdentifier) Errors() []error {
+	c := nodeChecker{Node: ji}
+	if ji.Name == "" {
+		c.appendf("%w empty identifier not allowed", ErrWrongValue)
+	}
+	return c.errors()
+}
+
+func (ji JSXIdentifier) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(ji)
+	x["name"] = ji.Name
+	return json.Marshal(x)
+}
+
+func (ji *JSXIdentifier) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type string `json:"type"`
+		Name string `json:"name"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ji.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ji.Type(), x.Type)
+	}
+	if err == nil {
+		ji.Loc, ji.Name = x.Location(), x.Name
+	}
+	return err
+}
+
+// JSXMemberExpression is a dotted element name, e.g. <Foo.Bar>.
+type JSXMemberExpression struct {
+	Loc      SourceLocation
+	Object   JSXIdentifierOrMemberExpression
+	Property JSXIdentifier
+}
+
+func (JSXMemberExpression) Type() string                 { return "JSXMemberExpression" }
+func (jme JSXMemberExpression) Location() SourceLocation { return jme.Loc }
+func (JSXMemberExpression) MinVersion() Version          { return ES5 }
+
+func (jme JSXMemberExpression) IsZero() bool {
+	return jme.Loc.IsZero() &&
+		(jme.Object == nil || jme.Object.IsZero()) &&
+		jme.Property.IsZero()
+}
+
+func (jme JSXMemberExpression) Walk(v Visitor) {
+	if v = v.Visit(jme); v != nil {
+		defer v.Visit(nil)
+		if jme.Object != nil {
+			jme.Object.Walk(v)
+		}
+		jme.Property.Walk(v)
+	}
+}
+
+func (jme JSXMemberExpression) Errors() []error {
+	c := nodeChecker{Node: jme}
+	c.require(jme.Object, "object in JSX member expression")
+	c.require(jme.Property, "property in JSX member expression")
+	return c.errors()
+}
+
+func (jme JSXMemberExpression) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(jme)
+	x["object"] = jme.Object
+	x["property"] = jme.Property
+	return json.Marshal(x)
+}
+
+func (jme *JSXMemberExpression) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type     string          `json:"type"`
+		Object   json.RawMessage `json:"object"`
+		Property JSXIdentifier   `json:"property"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != jme.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, jme.Type(), x.Type)
+	}
+	if err == nil {
+		jme.Loc, jme.Property = x.Location(), x.Property
+		jme.Object, err = unmarshalJSXIdentifierOrMemberExpression(x.Object)
+	}
+	return err
+}
+
+// JSXNamespacedName is a namespaced element or attribute name, e.g.
+// <svg:rect> or xlink:href.
+type JSXNamespacedName struct {
+	Loc       SourceLocation
+	Namespace JSXIdentifier
+	Name      JSXIdentifier
+}
+
+func (JSXNamespacedName) Type() string                 { return "JSXNamespacedName" }
+func (jnn JSXNamespacedName) Location() SourceLocation { return jnn.Loc }
+func (JSXNamespacedName) MinVersion() Version          { return ES5 }
+
+func (jnn JSXNamespacedName) IsZero() bool {
+	return jnn.Loc.IsZero() && jnn.Namespace.IsZero() && jnn.Name.IsZero()
+}
+
+func (jnn JSXNamespacedName) Walk(v Visitor) {
+	if v = v.Visit(jnn); v != nil {
+		defer v.Visit(nil)
+		jnn.Namespace.Walk(v)
+		jnn.Name.Walk(v)
+	}
+}
+
+func (jnn JSXNamespacedName) Errors() []error {
+	c := nodeChecker{Node: jnn}
+	c.require(jnn.Namespace, "namespace")
+	c.require(jnn.Name, "name")
+	return c.errors()
+}
+
+func (jnn JSXNamespacedName) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(jnn)
+	x["namespace"] = jnn.Namespace
+	x["name"] = jnn.Name
+	return json.Marshal(x)
+}
+
+func (jnn *JSXNamespacedName) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type      string        `json:"type"`
+		Namespace JSXIdentifier `json:"namespace"`
+		Name      JSXIdentifier `json:"name"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != jnn.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, jnn.Type(), x.Type)
+	}
+	if err == nil {
+		jnn.Loc, jnn.Namespace, jnn.Name = x.Location(), x.Namespace, x.Name
+	}
+	return err
+}
+
+// JSXEmptyExpression is the contents of an empty JSXExpressionContainer,
+// e.g. {} or {/* comment */}.
+type JSXEmptyExpression struct {
+	Loc SourceLocation
+}
+
+func (JSXEmptyExpression) Type() string                      { return "JSXEmptyExpression" }
+func (jee JSXEmptyExpression) Location() SourceLocation      { return jee.Loc }
+func (JSXEmptyExpression) MinVersion() Version               { return ES5 }
+func (JSXEmptyExpression) IsZero() bool                      { return false }
+func (JSXEmptyExpression) Errors() []error                   { return nil }
+func (JSXEmptyExpression) isExpressionOrJSXEmptyExpression() {}
+
+func (jee JSXEmptyExpression) Walk(v Visitor) {
+	if v = v.Visit(jee); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (jee JSXEmptyExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nodeToMap(jee))
+}
+
+func (jee *JSXEmptyExpression) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != jee.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, jee.Type(), x.Type)
+	}
+	if err == nil {
+		jee.Loc = x.Location()
+	}
+	return err
+}
+
+// JSXExpressionContainer is an Expression in braces, used as a JSXChild or
+// JSXAttributeValue.
+type JSXExpressionContainer struct {
+	Loc        SourceLocation
+	Expression ExpressionOrJSXEmptyExpression
+}
+
+func (JSXExpressionContainer) Type() string                 { return "JSXExpressionContainer" }
+func (jec JSXExpressionContainer) Location() SourceLocation { return jec.Loc }
+func (JSXExpressionContainer) MinVersion() Version          { return ES5 }
+
+func (jec JSXExpressionContainer) IsZero() bool {
+	return jec.Loc.IsZero() && (jec.Expression == nil || jec.Expression.IsZero())
+}
+
+func (jec JSXExpressionContainer) Walk(v Visitor) {
+	if v = v.Visit(jec); v != nil {
+		defer v.Visit(nil)
+		if jec.Expression != nil {
+			jec.Expression.Walk(v)
+		}
+	}
+}
+
+func (jec JSXExpressionContainer) Errors() []error {
+	c := nodeChecker{Node: jec}
+	c.require(jec.Expression, "JSX expression")
+	return c.errors()
+}
+
+func (jec JSXExpressionContainer) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(jec)
+	x["expression"] = jec.Expression
+	return json.Marshal(x)
+}
+
+func (jec *JSXExpressionContainer) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type       string          `json:"type"`
+		Expression json.RawMessage `json:"expression"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != jec.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, jec.Type(), x.Type)
+	}
+	if err == nil {
+		jec.Loc = x.Location()
+		jec.Expression, err = unmarshalExpressionOrJSXEmptyExpression(x.Expression)
+	}
+	return err
+}
+
+// JSXText is literal text between JSX tags.  Value is the text with HTML
+// entities decoded, and Raw is the text as it appears in the source.
+type JSXText struct {
+	Loc   SourceLocation
+	Value string
+	Raw   string
+}
+
+func (JSXText) Type() string                { return "JSXText" }
+func (jt JSXText) Location() SourceLocation { return jt.Loc }
+func (JSXText) MinVersion() Version         { return ES5 }
+func (JSXText) IsZero() bool                { return false }
+func (JSXText) Errors() []error             { return nil }
+
+func (jt JSXText) Walk(v Visitor) {
+	if v = v.Visit(jt); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (jt JSXText) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(jt)
+	x["value"] = jt.Value
+	x["raw"] = jt.Raw
+	return json.Marshal(x)
+}
+
+func (jt *JSXText) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type  string `json:"type"`
+		Value string `json:"value"`
+		Raw   string `json:"raw"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != jt.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, jt.Type(), x.Type)
+	}
+	if err == nil {
+		jt.Loc, jt.Value, jt.Raw = x.Location(), x.Value, x.Raw
+	}
+	return err
+}
+
+// JSXAttribute is an attribute of a JSXOpeningElement, e.g. foo="bar" or
+// disabled.  Value is nil when the attribute has no value.
+type JSXAttribute struct {
+	Loc   SourceLocation
+	Name  JSXIdentifierOrNamespacedName
+	Value JSXAttributeValue // or nil
+}
+
+func (JSXAttribute) Type() string                { return "JSXAttribute" }
+func (ja JSXAttribute) Location() SourceLocation { return ja.Loc }
+func (JSXAttribute) MinVersion() Version         { return ES5 }
+
+func (ja JSXAttribute) IsZero() bool {
+	return ja.Loc.IsZero() &&
+		(ja.Name == nil || ja.Name.IsZero()) &&
+		(ja.Value == nil || ja.Value.IsZero())
+}
+
+func (ja JSXAttribute) Walk(v Visitor) {
+	if v = v.Visit(ja); v != nil {
+		defer v.Visit(nil)
+		if ja.Name != nil {
+			ja.Name.Walk(v)
+		}
+		if ja.Value != nil {
+			ja.Value.Walk(v)
+		}
+	}
+}
+
+func (ja JSXAttribute) Errors() []error {
+	c := nodeChecker{Node: ja}
+	c.require(ja.Name, "attribute name")
+	c.optional(ja.Value)
+	switch v := ja.Value.(type) {
+	case nil, StringLiteral:
+	case Literal:
+		c.appendf("%w attribute value %T", ErrWrongValue, v)
+	case JSXExpressionContainer:
+		if _, ok := v.Expression.(JSXEmptyExpression); ok {
+			c.appendf("empty expression as attribute value %w", ErrNotAllowed)
+		}
+	}
+	return c.errors()
+}
+
+func (ja JSXAttribute) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(ja)
+	x["name"] = ja.Name
+	x["value"] = ja.Value
+	return json.Marshal(x)
+}
+
+func (ja *JSXAttribute) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type  string          `json:"type"`
+		Name  json.RawMessage `json:"name"`
+		Value json.RawMessage `json:"value"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ja.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ja.Type(), x.Type)
+	}
+	if err == nil {
+		ja.Loc = x.Location()
+		ja.Name, err = unmarshalJSXIdentifierOrNamespacedName(x.Name)
+		var err2 error
+		if ja.Value, err2 = unmarshalJSXAttributeValue(x.Value); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// JSXSpreadAttribute is a spread attribute of a JSXOpeningElement, e.g.
+// {...props}.
+type JSXSpreadAttribute struct {
+	Loc      SourceLocation
+	Argument Expression
+}
+
+func (JSXSpreadAttribute) Type() string                 { return "JSXSpreadAttribute" }
+func (jsa JSXSpreadAttribute) Location() SourceLocation { return jsa.Loc }
+func (JSXSpreadAttribute) MinVersion() Version          { return ES5 }
+
+func (jsa JSXSpreadAttribute) IsZero() bool {
+	return jsa.Loc.IsZero() && (jsa.Argument == nil || jsa.Argument.IsZero())
+}
+
+func (jsa JSXSpreadAttribute) Walk(v Visitor) {
+	if v = v.Visit(jsa); v != nil {
+		defer v.Visit(nil)
+		if jsa.Argument != nil {
+			jsa.Argument.Walk(v)
+		}
+	}
+}
+
+func (jsa JSXSpreadAttribute) Errors() []error {
+	c := nodeChecker{Node: jsa}
+	c.require(jsa.Argument, "spread attribute argument")
+	return c.errors()
+}
+
+func (jsa JSXSpreadAttribute) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(jsa)
+	x["argument"] = jsa.Argument
+	return json.Marshal(x)
+}
+
+func (jsa *JSXSpreadAttribute) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type     string          `json:"type"`
+		Argument json.RawMessage `json:"argument"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != jsa.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, jsa.Type(), x.Type)
+	}
+	if err == nil {
+		jsa.Loc = x.Location()
+		jsa.Argument, _, err = unmarshalExpression(x.Argument)
+	}
+	return err
+}
+
+// JSXOpeningElement is the opening tag of a JSXElement, e.g. <div id="foo">.
+type JSXOpeningElement struct {
+	Loc         SourceLocation
+	Name        JSXElementName
+	Attributes  []JSXAttributeOrSpread
+	SelfClosing bool
+}
+
+func (JSXOpeningElement) Type() string                 { return "JSXOpeningElement" }
+func (joe JSXOpeningElement) Location() SourceLocation { return joe.Loc }
+func (JSXOpeningElement) MinVersion() Version          { return ES5 }
+
+func (joe JSXOpeningElement) IsZero() bool {
+	return joe.Loc.IsZero() &&
+		(joe.Name == nil || joe.Name.IsZero()) &&
+		len(joe.Attributes) == 0 &&
+		!joe.SelfClosing
+}
+
+func (joe JSXOpeningElement) Walk(v Visitor) {
+	if v = v.Visit(joe); v != nil {
+		defer v.Visit(nil)
+		if joe.Name != nil {
+			joe.Name.Walk(v)
+		}
+		for _, a := range joe.Attributes {
+			a.Walk(v)
+		}
+	}
+}
+
+func (joe JSXOpeningElement) Errors() []error {
+	c := nodeChecker{Node: joe}
+	c.require(joe.Name, "element name")
+	c.requireEach(nodeSlice{
+		Index: func(i int) Node { return joe.Attributes[i] },
+		Len:   len(joe.Attributes),
+	}, "attribute")
+	return c.errors()
+}
+
+func (joe JSXOpeningElement) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(joe)
+	x["name"] = joe.Name
+	if len(joe.Attributes) > 0 {
+		x["attributes"] = joe.Attributes
+	} else {
+		x["attributes"] = []JSXAttributeOrSpread{}
+	}
+	x["selfClosing"] = joe.SelfClosing
+	return json.Marshal(x)
+}
+
+func (joe *JSXOpeningElement) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type        string            `json:"type"`
+		Name        json.RawMessage   `json:"name"`
+		Attributes  []json.RawMessage `json:"attributes"`
+		SelfClosing bool              `json:"selfClosing"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != joe.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, joe.Type(), x.Type)
+	}
+	if err == nil {
+		joe.Loc, joe.SelfClosing = x.Location(), x.SelfClosing
+		joe.Name, err = unmarshalJSXElementName(x.Name)
+		if len(x.Attributes) == 0 {
+			joe.Attributes = nil
+		} else {
+			joe.Attributes = make([]JSXAttributeOrSpread, len(x.Attributes))
+			for i := range x.Attributes {
+				var err2 error
+				joe.Attributes[i], err2 = unmarshalJSXAttributeOrSpread(x.Attributes[i])
+				if err == nil && err2 != nil {
+					err = err2
+				}
+			}
+		}
+	}
+	return err
+}
+
+// JSXClosingElement is the closing tag of a JSXElement, e.g. </div>.
+type JSXClosingElement struct {
+	Loc  SourceLocation
+	Name JSXElementName
+}
+
+func (JSXClosingElement) Type() string                 { return "JSXClosingElement" }
+func (jce JSXClosingElement) Location() SourceLocation { return jce.Loc }
+func (JSXClosingElement) MinVersion() Version          { return ES5 }
+
+func (jce JSXClosingElement) IsZero() bool {
+	return jce.Loc.IsZero() && (jce.Name == nil || jce.Name.IsZero())
+}
+
+func (jce JSXClosingElement) Walk(v Visitor) {
+	if v = v.Visit(jce); v != nil {
+		defer v.Visit(nil)
+		if jce.Name != nil {
+			jce.Name.Walk(v)
+		}
+	}
+}
+
+func (jce JSXClosingElement) Errors() []error {
+	c := nodeChecker{Node: jce}
+	c.require(jce.Name, "element name")
+	return c.errors()
+}
+
+func (jce JSXClosingElement) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(jce)
+	x["name"] = jce.Name
+	return json.Marshal(x)
+}
+
+func (jce *JSXClosingElement) UnmarshalJSON(b []byte) error {
+	if isNullOrEmptyRawMessage(b) {
+		return nil // self-closing elements have a null closingElement
+	}
+	var x struct {
+		NodeFields
+
+		Type string          `json:"type"`
+		Name json.RawMessage `json:"name"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != jce.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, jce.Type(), x.Type)
+	}
+	if err == nil {
+		jce.Loc = x.Location()
+		jce.Name, err = unmarshalJSXElementName(x.Name)
+	}
+	return err
+}
+
+// JSXElement is a JSX element, e.g. <div>...</div> or <br />.  ClosingElement
+// is zero if and only if OpeningElement is SelfClosing.
+type JSXElement struct {
+	baseExpression
+	Loc            SourceLocation
+	OpeningElement JSXOpeningElement
+	Children       []JSXChild
+	ClosingElement JSXClosingElement // possibly zero
+}
+
+func (JSXElement) Type() string                { return "JSXElement" }
+func (je JSXElement) Location() SourceLocation { return je.Loc }
+
+func (je JSXElement) IsZero() bool {
+	return je.Loc.IsZero() &&
+		je.OpeningElement.IsZero() &&
+		len(je.Children) == 0 &&
+		je.ClosingElement.IsZero()
+}
+
+func (je JSXElement) Walk(v Visitor) {
+	if v = v.Visit(je); v != nil {
+		defer v.Visit(nil)
+		je.OpeningElement.Walk(v)
+		for _, c := range je.Children {
+			c.Walk(v)
+		}
+		if !je.ClosingElement.IsZero() {
+			je.ClosingElement.Walk(v)
+		}
+	}
+}
+
+func (je JSXElement) Errors() []error {
+	c := nodeChecker{Node: je}
+	c.require(je.OpeningElement, "opening element")
+	c.requireEach(nodeSlice{
+		Index: func(i int) Node { return je.Children[i] },
+		Len:   len(je.Children),
+	}, "child")
+	if je.OpeningElement.SelfClosing {
+		if len(je.Children) > 0 {
+			c.appendf("children of self-closing element %w", ErrNotAllowed)
+		}
+		if !je.ClosingElement.IsZero() {
+			c.appendf("closing element of self-closing element %w", ErrNotAllowed)
+		}
+	} else {
+		c.require(je.ClosingElement, "closing element")
+		opening := jsxName(je.OpeningElement.Name)
+		closing := jsxName(je.ClosingElement.Name)
+		if opening != "" && closing != "" && opening != closing {
+			c.appendf("%w closing element </%s> for <%s>", ErrWrongValue, closing, opening)
+		}
+	}
+	return c.errors()
+}
+
+func (je JSXElement) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(je)
+	x["openingElement"] = je.OpeningElement
+	if len(je.Children) > 0 {
+		x["children"] = je.Children
+	} else {
+		x["children"] = []JSXChild{}
+	}
+	if je.ClosingElement.IsZero() {
+		x["closingElement"] = nil
+	} else {
+		x["closingElement"] = je.ClosingElement
+	}
+	return json.Marshal(x)
+}
+
+func (je *JSXElement) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type           string            `json:"type"`
+		OpeningElement JSXOpeningElement `json:"openingElement"`
+		Children       []json.RawMessage `json:"children"`
+		ClosingElement JSXClosingElement `json:"closingElement"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != je.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, je.Type(), x.Type)
+	}
+	if err == nil {
+		je.Loc, je.OpeningElement, je.ClosingElement = x.Location(), x.OpeningElement, x.ClosingElement
+		je.Children, err = unmarshalJSXChildren(x.Children)
+	}
+	return err
+}
+
+// JSXOpeningFragment is the opening tag of a JSXFragment, i.e. <>.
+type JSXOpeningFragment struct {
+	Loc SourceLocation
+}
+
+func (JSXOpeningFragment) Type() string                 { return "JSXOpeningFragment" }
+func (jof JSXOpeningFragment) Location() SourceLocation { return jof.Loc }
+func (JSXOpeningFragment) MinVersion() Version          { return ES5 }
+func (JSXOpeningFragment) IsZero() bool                 { return false }
+func (JSXOpeningFragment) Errors() []error              { return nil }
+
+func (jof JSXOpeningFragment) Walk(v Visitor) {
+	if v = v.Visit(jof); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (jof JSXOpeningFragment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nodeToMap(jof))
+}
+
+func (jof *JSXOpeningFragment) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != jof.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, jof.Type(), x.Type)
+	}
+	if err == nil {
+		jof.Loc = x.Location()
+	}
+	return err
+}
+
+// JSXClosingFragment is the closing tag of a JSXFragment, i.e. </>.
+type JSXClosingFragment struct {
+	Loc SourceLocation
+}
+
+func (JSXClosingFragment) Type() string                 { return "JSXClosingFragment" }
+func (jcf JSXClosingFragment) Location() SourceLocation { return jcf.Loc }
+func (JSXClosingFragment) MinVersion() Version          { return ES5 }
+func (JSXClosingFragment) IsZero() bool                 { return false }
+func (JSXClosingFragment) Errors() []error              { return nil }
+
+func (jcf JSXClosingFragment) Walk(v Visitor) {
+	if v = v.Visit(jcf); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (jcf JSXClosingFragment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nodeToMap(jcf))
+}
+
+func (jcf *JSXClosingFragment) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != jcf.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, jcf.Type(), x.Type)
+	}
+	if err == nil {
+		jcf.Loc = x.Location()
+	}
+	return err
+}
+
+// JSXFragment is a JSX fragment, i.e. <>...</>, which groups its Children
+// without a wrapping element.
+type JSXFragment struct {
+	baseExpression
+	Loc             SourceLocation
+	OpeningFragment JSXOpeningFragment
+	Children        []JSXChild
+	ClosingFragment JSXClosingFragment
+}
+
+func (JSXFragment) Type() string                { return "JSXFragment" }
+func (jf JSXFragment) Location() SourceLocation { return jf.Loc }
+func (JSXFragment) IsZero() bool                { return false }
+
+func (jf JSXFragment) Walk(v Visitor) {
+	if v = v.Visit(jf); v != nil {
+		defer v.Visit(nil)
+		jf.OpeningFragment.Walk(v)
+		for _, c := range jf.Children {
+			c.Walk(v)
+		}
+		jf.ClosingFragment.Walk(v)
+	}
+}
+
+func (jf JSXFragment) Errors() []error {
+	c := nodeChecker{Node: jf}
+	c.requireEach(nodeSlice{
+		Index: func(i int) Node { return jf.Children[i] },
+		Len:   len(jf.Children),
+	}, "child")
+	return c.errors()
+}
+
+func (jf JSXFragment) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(jf)
+	x["openingFragment"] = jf.OpeningFragment
+	if len(jf.Children) > 0 {
+		x["children"] = jf.Children
+	} else {
+		x["children"] = []JSXChild{}
+	}
+	x["closingFragment"] = jf.ClosingFragment
+	return json.Marshal(x)
+}
+
+func (jf *JSXFragment) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type            string             `json:"type"`
+		OpeningFragment JSXOpeningFragment `json:"openingFragment"`
+		Children        []json.RawMessage  `json:"children"`
+		ClosingFragment JSXClosingFragment `json:"closingFragment"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != jf.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, jf.Type(), x.Type)
+	}
+	if err == nil {
+		jf.Loc, jf.OpeningFragment, jf.ClosingFragment = x.Location(), x.OpeningFragment, x.ClosingFragment
+		jf.Children, err = unmarshalJSXChildren(x.Children)
+	}
+	return err
+}
@@ -0,0 +1,225 @@
+package estree
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSXElement(t *testing.T) {
+	var je JSXElement
+	if !je.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// <Foo.Bar id="baz" {...props}>hello {name}</Foo.Bar>
+	name := JSXMemberExpression{
+		Object:   JSXIdentifier{Name: "Foo"},
+		Property: JSXIdentifier{Name: "Bar"},
+	}
+	je.OpeningElement = JSXOpeningElement{
+		Name: name,
+		Attributes: []JSXAttributeOrSpread{
+			JSXAttribute{
+				Name:  JSXIdentifier{Name: "id"},
+				Value: StringLiteral{Value: "baz"},
+			},
+			JSXSpreadAttribute{Argument: Identifier{Name: "props"}},
+		},
+	}
+	je.Children = []JSXChild{
+		JSXText{Value: "hello ", Raw: "hello "},
+		JSXExpressionContainer{Expression: Identifier{Name: "name"}},
+	}
+	je.ClosingElement = JSXClosingElement{Name: name}
+	if je.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if je.MinVersion() != ES5 {
+		t.Errorf("expected ES5, got %s", je.MinVersion())
+	}
+
+	var v mockVisitor
+	je.Walk(&v)
+	v.expect(t, je,
+		je.OpeningElement,
+		name, name.Object, nil, name.Property, nil, nil,
+		je.OpeningElement.Attributes[0],
+		je.OpeningElement.Attributes[0].(JSXAttribute).Name, nil,
+		je.OpeningElement.Attributes[0].(JSXAttribute).Value, nil, nil,
+		je.OpeningElement.Attributes[1],
+		je.OpeningElement.Attributes[1].(JSXSpreadAttribute).Argument, nil, nil, nil,
+		je.Children[0], nil,
+		je.Children[1],
+		je.Children[1].(JSXExpressionContainer).Expression, nil, nil,
+		je.ClosingElement,
+		name, name.Object, nil, name.Property, nil, nil, nil, nil)
+
+	testRoundtripJSON(t, je, new(JSXElement))
+
+	if errs := Validate(je); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	je.ClosingElement.Name = JSXIdentifier{Name: "Foo"}
+	if !hasError(ErrWrongValue, je.Errors()...) {
+		t.Error("expected ErrWrongValue for mismatched closing element")
+	}
+	je.ClosingElement = JSXClosingElement{}
+	if !hasError(ErrMissingNode, je.Errors()...) {
+		t.Error("expected ErrMissingNode for missing closing element")
+	}
+	je.OpeningElement.SelfClosing = true
+	if !hasError(ErrNotAllowed, je.Errors()...) {
+		t.Error("expected ErrNotAllowed for children of self-closing element")
+	}
+	je.Children = nil
+	if errs := je.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	testRoundtripJSON(t, je, new(JSXElement))
+	je.ClosingElement = JSXClosingElement{Name: name}
+	if !hasError(ErrNotAllowed, je.Errors()...) {
+		t.Error("expected ErrNotAllowed for closing element of self-closing element")
+	}
+
+	b := []byte(`{"type":"ExpressionStatement","expression":{"type":"JSXElement",` +
+		`"openingElement":{"type":"JSXOpeningElement","name":{"type":"JSXNamespacedName",` +
+		`"namespace":{"type":"JSXIdentifier","name":"svg"},"name":{"type":"JSXIdentifier","name":"rect"}},` +
+		`"attributes":[],"selfClosing":true},"children":[],"closingElement":null}}`)
+	var es ExpressionStatement
+	if err := json.Unmarshal(b, &es); err != nil {
+		t.Fatal(err)
+	}
+	if jsxName(es.Expression.(JSXElement).OpeningElement.Name) != "svg:rect" {
+		t.Errorf("expected svg:rect, got %#v", es.Expression)
+	}
+}
+
+func TestJSXFragment(t *testing.T) {
+	var jf JSXFragment
+	if jf.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	jf.Children = []JSXChild{
+		JSXExpressionContainer{Expression: JSXEmptyExpression{}},
+		JSXElement{
+			OpeningElement: JSXOpeningElement{
+				Name:        JSXIdentifier{Name: "br"},
+				SelfClosing: true,
+			},
+		},
+	}
+	if jf.MinVersion() != ES5 {
+		t.Errorf("expected ES5, got %s", jf.MinVersion())
+	}
+
+	var v mockVisitor
+	jf.Walk(&v)
+	v.expect(t, jf,
+		jf.OpeningFragment, nil,
+		jf.Children[0],
+		jf.Children[0].(JSXExpressionContainer).Expression, nil, nil,
+		jf.Children[1],
+		jf.Children[1].(JSXElement).OpeningElement,
+		jf.Children[1].(JSXElement).OpeningElement.Name, nil, nil, nil,
+		jf.ClosingFragment, nil, nil)
+
+	testRoundtripJSON(t, jf, new(JSXFragment))
+
+	if errs := Validate(jf); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	jf.Children[1] = JSXElement{}
+	if !hasError(ErrMissingNode, jf.Errors()...) {
+		t.Error("expected ErrMissingNode for zero child")
+	}
+}
+
+func TestJSXAttribute(t *testing.T) {
+	var ja JSXAttribute
+	if !ja.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	ja.Name = JSXNamespacedName{
+		Namespace: JSXIdentifier{Name: "xlink"},
+		Name:      JSXIdentifier{Name: "href"},
+	}
+	if ja.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	testRoundtripJSON(t, ja, new(JSXAttribute))
+	if errs := ja.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	ja.Value = JSXExpressionContainer{Expression: Identifier{Name: "foo"}}
+	testRoundtripJSON(t, ja, new(JSXAttribute))
+	if errs := ja.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ja.Value = JSXExpressionContainer{Expression: JSXEmptyExpression{}}
+	if !hasError(ErrNotAllowed, ja.Errors()...) {
+		t.Error("expected ErrNotAllowed for empty expression")
+	}
+	ja.Value = NumberLiteral{Value: 1}
+	if !hasError(ErrWrongValue, ja.Errors()...) {
+		t.Error("expected ErrWrongValue for NumberLiteral")
+	}
+	ja.Name = nil
+	if !hasError(ErrMissingNode, ja.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Name")
+	}
+}
+
+func TestJSXNames(t *testing.T) {
+	for _, n := range []JSXElementName{
+		JSXIdentifier{Name: "foo"},
+		JSXMemberExpression{
+			Object: JSXMemberExpression{
+				Object:   JSXIdentifier{Name: "a"},
+				Property: JSXIdentifier{Name: "b"},
+			},
+			Property: JSXIdentifier{Name: "c"},
+		},
+		JSXNamespacedName{
+			Namespace: JSXIdentifier{Name: "a"},
+			Name:      JSXIdentifier{Name: "b"},
+		},
+	} {
+		if n.IsZero() {
+			t.Errorf("expected !IsZero() for %T", n)
+		}
+		if errs := Validate(n); len(errs) != 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+		b, err := json.Marshal(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out, err := unmarshalJSXElementName(b); err != nil {
+			t.Error(err)
+		} else if jsxName(out) != jsxName(n) {
+			t.Errorf("expected %s, got %s", jsxName(n), jsxName(out))
+		}
+	}
+	if jsxName(JSXMemberExpression{
+		Object:   JSXIdentifier{Name: "a"},
+		Property: JSXIdentifier{Name: "b"},
+	}) != "a.b" {
+		t.Error("expected a.b")
+	}
+
+	testRoundtripJSON(t, JSXIdentifier{Name: "data-foo"}, new(JSXIdentifier))
+	testRoundtripJSON(t, JSXMemberExpression{
+		Object:   JSXIdentifier{Name: "a"},
+		Property: JSXIdentifier{Name: "b"},
+	}, new(JSXMemberExpression))
+	if !hasError(ErrWrongValue, JSXIdentifier{}.Errors()...) {
+		t.Error("expected ErrWrongValue for empty JSXIdentifier")
+	}
+	if !hasError(ErrMissingNode, JSXMemberExpression{}.Errors()...) {
+		t.Error("expected ErrMissingNode for zero JSXMemberExpression")
+	}
+}
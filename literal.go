@@ -4,6 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
 )
 
 // Literal is a literal token.  Note that a literal can be an expression.
@@ -12,14 +17,18 @@ type Literal interface {
 	isLiteral()
 	isLiteralOrIdentifier()
 	isVariableDeclarationOrLiteral()
+	isJSXAttributeValue()
 }
 
 func unmarshalLiteral(m json.RawMessage) (l Literal, match bool, err error) {
 	var x struct {
-		Type  string         `json:"type"`
-		Loc   SourceLocation `json:"loc"`
-		Value interface{}    `json:"value"`
-		Regex struct {
+		NodeFields
+
+		Type   string      `json:"type"`
+		Value  interface{} `json:"value"`
+		Raw    string      `json:"raw"`
+		Bigint *string     `json:"bigint"`
+		Regex  struct {
 			Pattern string `json:"pattern"`
 			Flags   string `json:"flags"`
 		} `json:"regex"`
@@ -30,19 +39,30 @@ func unmarshalLiteral(m json.RawMessage) (l Literal, match bool, err error) {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, baseLiteral{}.Type(), x.Type)
 	} else if x.Regex.Pattern != "" || x.Regex.Flags != "" {
 		match = true
-		l = RegExpLiteral{Loc: x.Loc, Pattern: x.Regex.Pattern, Flags: x.Regex.Flags}
+		l = RegExpLiteral{Loc: x.Location(), Pattern: x.Regex.Pattern, Flags: x.Regex.Flags, Raw: x.Raw}
 		// TODO: complain if Value is non-nil?
+	} else if x.Bigint != nil {
+		match = true
+		if v, ok := new(big.Int).SetString(*x.Bigint, 0); ok {
+			bil := BigIntLiteral{Loc: x.Location(), Value: v, Raw: x.Raw}
+			if *x.Bigint != v.String() {
+				bil.Bigint = *x.Bigint
+			}
+			l = bil
+		} else {
+			err = fmt.Errorf("%w BigInt %q", ErrWrongValue, *x.Bigint)
+		}
 	} else {
 		match = true
 		switch v := x.Value.(type) {
 		case string:
-			l = StringLiteral{Loc: x.Loc, Value: v}
+			l = StringLiteral{Loc: x.Location(), Value: v, Raw: x.Raw}
 		case bool:
-			l = BoolLiteral{Loc: x.Loc, Value: v}
+			l = BoolLiteral{Loc: x.Location(), Value: v, Raw: x.Raw}
 		case nil:
-			l = NullLiteral{Loc: x.Loc}
+			l = NullLiteral{Loc: x.Location(), Raw: x.Raw}
 		case float64:
-			l = NumberLiteral{Loc: x.Loc, Value: v}
+			l = NumberLiteral{Loc: x.Location(), Value: v, Raw: x.Raw}
 		default:
 			err = fmt.Errorf("%w string, bool, null, number, or regexp got %v", ErrWrongType, v)
 		}
@@ -77,24 +97,53 @@ func (baseLiteral) Errors() []error                 { return nil }
 func (baseLiteral) isLiteral()                      {}
 func (baseLiteral) isLiteralOrIdentifier()          {}
 func (baseLiteral) isVariableDeclarationOrLiteral() {}
+func (baseLiteral) isJSXAttributeValue()            {}
 
 type StringLiteral struct {
 	baseLiteral
 	Loc   SourceLocation
 	Value string
+
+	// Raw is the source text of the literal, including quotes and escapes,
+	// e.g. 'it\'s', or empty if unknown.  If set, it must decode to Value.
+	Raw string
 }
 
 func (sl StringLiteral) Location() SourceLocation { return sl.Loc }
 
+func (sl StringLiteral) MinVersion() Version {
+	// Unescaped line and paragraph separators were not allowed in string
+	// literals prior to ES2019.  Without Raw, assume they are unescaped.
+	s := sl.Raw
+	if s == "" {
+		s = sl.Value
+	}
+	if strings.ContainsAny(s, "\u2028\u2029") {
+		return ES2019
+	}
+	return ES5
+}
+
 func (sl StringLiteral) Walk(v Visitor) {
 	if v = v.Visit(sl); v != nil {
 		v.Visit(nil)
 	}
 }
 
+func (sl StringLiteral) Errors() []error {
+	c := nodeChecker{Node: sl}
+	if v, ok := unquoteString(sl.Raw); sl.Raw != "" && (!ok || v != sl.Value) {
+		c.appendf("%w raw %s for string %q", ErrWrongValue, sl.Raw, sl.Value)
+	}
+	return c.errors()
+}
+
 func (sl StringLiteral) MarshalJSON() ([]byte, error) {
 	x := nodeToMap(sl)
 	x["value"] = sl.Value
+	if sl.Raw != "" {
+		x["raw"] = sl.Raw
+	}
 	return json.Marshal(x)
 }
 
@@ -102,6 +151,7 @@ type BoolLiteral struct {
 	baseLiteral
 	Loc   SourceLocation
 	Value bool
+	Raw   string // source text, or empty if unknown
 }
 
 func (bl BoolLiteral) Location() SourceLocation { return bl.Loc }
@@ -112,15 +162,27 @@ func (bl BoolLiteral) Walk(v Visitor) {
 	}
 }
 
+func (bl BoolLiteral) Errors() []error {
+	c := nodeChecker{Node: bl}
+	if bl.Raw != "" && bl.Raw != fmt.Sprint(bl.Value) {
+		c.appendf("%w raw %s for %t", ErrWrongValue, bl.Raw, bl.Value)
+	}
+	return c.errors()
+}
+
 func (bl BoolLiteral) MarshalJSON() ([]byte, error) {
 	x := nodeToMap(bl)
 	x["value"] = bl.Value
+	if bl.Raw != "" {
+		x["raw"] = bl.Raw
+	}
 	return json.Marshal(x)
 }
 
 type NullLiteral struct {
 	baseLiteral
 	Loc SourceLocation
+	Raw string // source text, or empty if unknown
 }
 
 func (nl NullLiteral) Location() SourceLocation { return nl.Loc }
@@ -131,14 +193,30 @@ func (nl NullLiteral) Walk(v Visitor) {
 	}
 }
 
+func (nl NullLiteral) Errors() []error {
+	c := nodeChecker{Node: nl}
+	if nl.Raw != "" && nl.Raw != "null" {
+		c.appendf("%w raw %s for null", ErrWrongValue, nl.Raw)
+	}
+	return c.errors()
+}
+
 func (nl NullLiteral) MarshalJSON() ([]byte, error) {
-	return json.Marshal(nodeToMap(nl))
+	x := nodeToMap(nl)
+	if nl.Raw != "" {
+		x["raw"] = nl.Raw
+	}
+	return json.Marshal(x)
 }
 
 type NumberLiteral struct {
 	baseLiteral
 	Loc   SourceLocation
 	Value float64
+
+	// Raw is the source text of the literal, e.g. 0x1F or 1_000, or empty if
+	// unknown.  If set, it must evaluate to Value.
+	Raw string
 }
 
 func (nl NumberLiteral) Location() SourceLocation { return nl.Loc }
@@ -149,9 +227,80 @@ func (nl NumberLiteral) Walk(v Visitor) {
 	}
 }
 
+func (nl NumberLiteral) MinVersion() Version {
+	switch {
+	case strings.ContainsRune(nl.Raw, '_'):
+		return ES2021
+	case len(nl.Raw) > 1 && nl.Raw[0] == '0' && strings.ContainsAny(nl.Raw[1:2], "oObB"):
+		return ES2015
+	}
+	return ES5
+}
+
+func (nl NumberLiteral) Errors() []error {
+	c := nodeChecker{Node: nl}
+	if v, ok := parseNumber(nl.Raw); nl.Raw != "" && (!ok || v != nl.Value) {
+		c.appendf("%w raw %s for number %v", ErrWrongValue, nl.Raw, nl.Value)
+	}
+	return c.errors()
+}
+
 func (nl NumberLiteral) MarshalJSON() ([]byte, error) {
 	x := nodeToMap(nl)
 	x["value"] = nl.Value
+	if nl.Raw != "" {
+		x["raw"] = nl.Raw
+	}
+	return json.Marshal(x)
+}
+
+// BigIntLiteral is an arbitrary-precision integer literal, e.g. 123n.
+type BigIntLiteral struct {
+	baseLiteral
+	Loc   SourceLocation
+	Value *big.Int
+
+	// Bigint is the bigint property as decoded, e.g. 0x1f, or empty if it was
+	// the decimal form of Value.  It is encoded in place of Value as long as
+	// the two agree.
+	Bigint string
+
+	Raw string // source text, e.g. 0x1Fn, or empty if unknown
+}
+
+func (bil BigIntLiteral) Location() SourceLocation { return bil.Loc }
+func (BigIntLiteral) MinVersion() Version          { return ES2020 }
+
+func (bil BigIntLiteral) Walk(v Visitor) {
+	if v = v.Visit(bil); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (bil BigIntLiteral) Errors() []error {
+	c := nodeChecker{Node: bil}
+	if bil.Value == nil {
+		c.appendf("%w BigInt value", ErrMissingNode)
+	} else if v, ok := parseBigInt(bil.Raw); bil.Raw != "" && (!ok || v.Cmp(bil.Value) != 0) {
+		c.appendf("%w raw %s for BigInt %s", ErrWrongValue, bil.Raw, bil.Value)
+	}
+	return c.errors()
+}
+
+// MarshalJSON encodes the value in the bigint property, as it was decoded
+// or else as a decimal string.  Since JSON cannot represent BigInt values,
+// value is always null.
+func (bil BigIntLiteral) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(bil)
+	x["value"] = nil
+	if v, ok := new(big.Int).SetString(bil.Bigint, 0); ok && bil.Value != nil && v.Cmp(bil.Value) == 0 {
+		x["bigint"] = bil.Bigint
+	} else if bil.Value != nil {
+		x["bigint"] = bil.Value.String()
+	}
+	if bil.Raw != "" {
+		x["raw"] = bil.Raw
+	}
 	return json.Marshal(x)
 }
 
@@ -160,6 +309,10 @@ type RegExpLiteral struct {
 	Loc     SourceLocation
 	Pattern string
 	Flags   string
+
+	// Raw is the source text of the literal, e.g. /foo/g, or empty if
+	// unknown.  If set, it must be consistent with Pattern and Flags.
+	Raw string
 }
 
 func (rel RegExpLiteral) Location() SourceLocation { return rel.Loc }
@@ -173,11 +326,187 @@ func (rel RegExpLiteral) Walk(v Visitor) {
 	}
 }
 
+func (rel RegExpLiteral) Errors() []error {
+	c := nodeChecker{Node: rel}
+	if rel.Raw != "" && rel.Raw != "/"+rel.Pattern+"/"+rel.Flags {
+		c.appendf("%w raw %s for /%s/%s", ErrWrongValue, rel.Raw, rel.Pattern, rel.Flags)
+	}
+	return c.errors()
+}
+
 func (rel RegExpLiteral) MarshalJSON() ([]byte, error) {
 	x := nodeToMap(rel)
 	x["regex"] = map[string]interface{}{
 		"pattern": rel.Pattern,
 		"flags":   rel.Flags,
 	}
+	if rel.Raw != "" {
+		x["raw"] = rel.Raw
+	}
 	return json.Marshal(x)
 }
+
+// unquoteString returns the value of a string literal's source text, e.g.
+// 'it\'s' or "\x41\u{42}", and whether it is a valid string literal.  Legacy
+// octal escapes are accepted.
+func unquoteString(raw string) (string, bool) {
+	if len(raw) < 2 || (raw[0] != '"' && raw[0] != '\'') || raw[len(raw)-1] != raw[0] {
+		return "", false
+	}
+	quote, s := rune(raw[0]), []rune(raw[1:len(raw)-1])
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch r := s[i]; {
+		case r == quote, r == '\n', r == '\r':
+			return "", false
+		case r != '\\':
+			b.WriteRune(r)
+			continue
+		}
+		if i++; i == len(s) {
+			return "", false
+		}
+		switch r := s[i]; r {
+		case 'b':
+			b.WriteRune('\b')
+		case 'f':
+			b.WriteRune('\f')
+		case 'n':
+			b.WriteRune('\n')
+		case 'r':
+			b.WriteRune('\r')
+		case 't':
+			b.WriteRune('\t')
+		case 'v':
+			b.WriteRune('\v')
+		case '\r':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				i++
+			}
+		case '\n', '\u2028', '\u2029':
+			// Line continuation.
+		case 'x':
+			if i+2 >= len(s) {
+				return "", false
+			}
+			v, err := strconv.ParseUint(string(s[i+1:i+3]), 16, 8)
+			if err != nil {
+				return "", false
+			}
+			b.WriteRune(rune(v))
+			i += 2
+		case 'u':
+			v, n, ok := unicodeEscape(s[i+1:])
+			if !ok {
+				return "", false
+			}
+			i += n
+			if utf16.IsSurrogate(v) && i+2 < len(s) && s[i+1] == '\\' && s[i+2] == 'u' {
+				if v2, n2, ok := unicodeEscape(s[i+3:]); ok && utf16.DecodeRune(v, v2) != unicode.ReplacementChar {
+					v = utf16.DecodeRune(v, v2)
+					i += n2 + 2
+				}
+			}
+			b.WriteRune(v)
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			// Legacy octal escape, e.g. \0 or \377.
+			v, n := int(r-'0'), 1
+			for ; n < 3 && i+n < len(s) && s[i+n] >= '0' && s[i+n] <= '7' && v*8+int(s[i+n]-'0') <= 0377; n++ {
+				v = v*8 + int(s[i+n]-'0')
+			}
+			b.WriteRune(rune(v))
+			i += n - 1
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), true
+}
+
+// unicodeEscape parses the part of a \u escape following the u, i.e. either
+// four hex digits or a code point in braces.  It returns the rune and the
+// number of runes consumed.
+func unicodeEscape(s []rune) (rune, int, bool) {
+	if len(s) > 0 && s[0] == '{' {
+		for n := 1; n < len(s); n++ {
+			if s[n] == '}' {
+				v, err := strconv.ParseUint(string(s[1:n]), 16, 32)
+				return rune(v), n + 1, err == nil && n > 1 && v <= unicode.MaxRune
+			}
+		}
+		return 0, 0, false
+	}
+	if len(s) < 4 {
+		return 0, 0, false
+	}
+	v, err := strconv.ParseUint(string(s[:4]), 16, 16)
+	return rune(v), 4, err == nil
+}
+
+// parseNumber returns the value of a numeric literal's source text, e.g.
+// 0x1F or 1_000.5e3, and whether it is a valid numeric literal.  Legacy octal
+// literals, e.g. 0777, are accepted.
+func parseNumber(raw string) (float64, bool) {
+	s := strings.ReplaceAll(raw, "_", "")
+	if s == "" || (s[0] != '.' && (s[0] < '0' || s[0] > '9')) {
+		return 0, false
+	}
+	base := 0
+	if len(s) > 1 && s[0] == '0' {
+		switch s[1] {
+		case 'x', 'X':
+			base = 16
+		case 'o', 'O':
+			base = 8
+		case 'b', 'B':
+			base = 2
+		default:
+			if strings.Trim(s, "01234567") == "" {
+				base, s = 8, "00"+s
+			}
+		}
+	}
+	if !validSeparators(raw, base) {
+		return 0, false
+	}
+	if base != 0 {
+		v, ok := new(big.Int).SetString(s[2:], base)
+		if !ok || strings.ContainsAny(s[2:], "+-") {
+			return 0, false
+		}
+		f, _ := new(big.Float).SetInt(v).Float64()
+		return f, true
+	}
+	if strings.Trim(s, "0123456789.eE+-") != "" {
+		return 0, false // reject Inf, NaN, and hex floats
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	return v, err == nil
+}
+
+// parseBigInt returns the value of a BigInt literal's source text, e.g. 0x1Fn,
+// and whether it is a valid BigInt literal.
+func parseBigInt(raw string) (*big.Int, bool) {
+	s := strings.TrimSuffix(raw, "n")
+	if s == raw || s == "" || s[0] < '0' || s[0] > '9' ||
+		(len(s) > 1 && s[0] == '0' && s[1] >= '0' && s[1] <= '9') ||
+		strings.ContainsAny(s, "+-") || !validSeparators(s, 16) {
+		return nil, false
+	}
+	return new(big.Int).SetString(s, 0)
+}
+
+// validSeparators indicates each underscore in the source text of a numeric
+// literal is between two digits.  Letters count as digits if base is 16.
+func validSeparators(raw string, base int) bool {
+	isDigit := func(c byte) bool {
+		return (c >= '0' && c <= '9') ||
+			(base == 16 && ((c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')))
+	}
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '_' && (i == 0 || i == len(raw)-1 || !isDigit(raw[i-1]) || !isDigit(raw[i+1])) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,8 +1,10 @@
 package estree
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"math/big"
 	"reflect"
 	"testing"
 )
@@ -50,7 +52,19 @@ func TestStringLiteral(t *testing.T) {
 		t.Error("expected !IsZero()")
 	}
 
-	sl.Value = "foo"
+	sl.Value = "foo\u2028"
+	if sl.MinVersion() != ES2019 {
+		t.Errorf("expected ES2019, got %s", sl.MinVersion())
+	}
+	sl.Raw = `'foo\u2028'`
+	if sl.MinVersion() != ES5 {
+		t.Errorf("expected ES5 for escaped separator, got %s", sl.MinVersion())
+	}
+	sl.Raw = "'foo\u2028'"
+	if sl.MinVersion() != ES2019 {
+		t.Errorf("expected ES2019 for unescaped separator, got %s", sl.MinVersion())
+	}
+	sl.Value, sl.Raw = "foo", ""
 	if sl.MinVersion() != ES5 {
 		t.Errorf("expected ES5, got %s", sl.MinVersion())
 	}
@@ -131,6 +145,54 @@ func TestNumberLiteral(t *testing.T) {
 	}
 }
 
+func TestBigIntLiteral(t *testing.T) {
+	var bil BigIntLiteral
+	if bil.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if !hasError(ErrMissingNode, bil.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Value")
+	}
+
+	bil.Value, _ = new(big.Int).SetString("123456789012345678901234567890", 10)
+	if bil.MinVersion() != ES2020 {
+		t.Errorf("expected ES2020, got %s", bil.MinVersion())
+	}
+
+	var v mockVisitor
+	bil.Walk(&v)
+	v.expect(t, bil, nil)
+
+	testRoundtripLiteralJSON(t, bil)
+
+	if errs := bil.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	l, match, err := unmarshalLiteral([]byte(`{"type":"Literal","value":null,"bigint":"0x1f"}`))
+	if !match || err != nil {
+		t.Errorf("match is %t, err is %v", match, err)
+	} else if l.(BigIntLiteral).Value.Int64() != 31 {
+		t.Errorf("expected 31, got %s", l.(BigIntLiteral).Value)
+	} else if b, err := json.Marshal(l); err != nil {
+		t.Error(err)
+	} else if !bytes.Contains(b, []byte(`"bigint":"0x1f"`)) {
+		t.Errorf("expected bigint 0x1f, got %s", b)
+	}
+
+	// A stale Bigint is ignored in favor of Value.
+	bil.Bigint = "0x1f"
+	if b, err := json.Marshal(bil); err != nil {
+		t.Error(err)
+	} else if !bytes.Contains(b, []byte(`"bigint":"123456789012345678901234567890"`)) {
+		t.Errorf("expected decimal bigint, got %s", b)
+	}
+	_, _, err = unmarshalLiteral([]byte(`{"type":"Literal","value":null,"bigint":"1.5"}`))
+	if !errors.Is(err, ErrWrongValue) {
+		t.Errorf("expected ErrWrongValue, got %v", err)
+	}
+}
+
 func TestRegExpLiteral(t *testing.T) {
 	var rl RegExpLiteral
 	if rl.IsZero() {
@@ -153,3 +215,80 @@ func TestRegExpLiteral(t *testing.T) {
 		t.Fatalf("unexpected errors: %v", errs)
 	}
 }
+
+func TestLiteralRaw(t *testing.T) {
+	for _, test := range []struct {
+		l     Literal
+		valid bool
+	}{
+		{StringLiteral{Value: "it's", Raw: `'it\'s'`}, true},
+		{StringLiteral{Value: "foo", Raw: `"foo"`}, true},
+		{StringLiteral{Value: "ABé\U0001F600", Raw: `"\x41\u{42}é😀"`}, true},
+		{StringLiteral{Value: "a\nb\x00\u00ff", Raw: "'a\\nb\\0\\377'"}, true},
+		{StringLiteral{Value: "ab", Raw: "'a\\\nb'"}, true},
+		{StringLiteral{Value: "foo", Raw: `'foo"`}, false},
+		{StringLiteral{Value: "foo", Raw: `'bar'`}, false},
+		{StringLiteral{Value: "it's", Raw: `'it's'`}, false},
+		{StringLiteral{Value: "a", Raw: `"\x4"`}, false},
+		{NumberLiteral{Value: 31, Raw: "0x1F"}, true},
+		{NumberLiteral{Value: 31, Raw: "0o37"}, true},
+		{NumberLiteral{Value: 31, Raw: "0b11111"}, true},
+		{NumberLiteral{Value: 31, Raw: "037"}, true},
+		{NumberLiteral{Value: 89, Raw: "089"}, true},
+		{NumberLiteral{Value: 1000000.5, Raw: "1_000_000.5"}, true},
+		{NumberLiteral{Value: 0.5, Raw: ".5e0"}, true},
+		{NumberLiteral{Value: 1e21, Raw: "1E21"}, true},
+		{NumberLiteral{Value: 31, Raw: "31.0"}, true},
+		{NumberLiteral{Value: 31, Raw: "0x1E"}, false},
+		{NumberLiteral{Value: 1000, Raw: "1__000"}, false},
+		{NumberLiteral{Value: 1000, Raw: "1000_"}, false},
+		{NumberLiteral{Value: 1, Raw: "+1"}, false},
+		{NumberLiteral{Value: 0, Raw: "0x"}, false},
+		{BoolLiteral{Value: true, Raw: "true"}, true},
+		{BoolLiteral{Value: true, Raw: "false"}, false},
+		{NullLiteral{Raw: "null"}, true},
+		{NullLiteral{Raw: "undefined"}, false},
+		{BigIntLiteral{Value: big.NewInt(31), Raw: "0x1Fn"}, true},
+		{BigIntLiteral{Value: big.NewInt(1000), Raw: "1_000n"}, true},
+		{BigIntLiteral{Value: big.NewInt(31), Raw: "31"}, false},
+		{BigIntLiteral{Value: big.NewInt(31), Raw: "037n"}, false},
+		{RegExpLiteral{Pattern: "a|b", Flags: "gi", Raw: "/a|b/gi"}, true},
+		{RegExpLiteral{Pattern: "a|b", Flags: "gi", Raw: "/a|b/g"}, false},
+	} {
+		errs := test.l.Errors()
+		if test.valid && len(errs) != 0 {
+			t.Errorf("unexpected errors for %#v: %v", test.l, errs)
+		} else if !test.valid && !hasError(ErrWrongValue, errs...) {
+			t.Errorf("expected ErrWrongValue for %#v", test.l)
+		}
+		if test.valid {
+			testRoundtripLiteralJSON(t, test.l)
+		}
+	}
+
+	if v := (NumberLiteral{Value: 1000, Raw: "1_000"}).MinVersion(); v != ES2021 {
+		t.Errorf("expected ES2021, got %s", v)
+	}
+	if v := (NumberLiteral{Value: 31, Raw: "0o37"}).MinVersion(); v != ES2015 {
+		t.Errorf("expected ES2015, got %s", v)
+	}
+
+	l, _, err := unmarshalLiteral([]byte(`{"type":"Literal","value":"foo","raw":"'foo'"}`))
+	if err != nil {
+		t.Fatal(err)
+	} else if l.(StringLiteral).Raw != "'foo'" {
+		t.Errorf("expected raw 'foo', got %#v", l)
+	}
+
+	d := Directive{
+		Expression: StringLiteral{Value: "use strict", Raw: "'use strict'"},
+		Directive:  "use strict",
+	}
+	if errs := d.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	d.Directive = "use\\x20strict"
+	if !hasError(ErrWrongValue, d.Errors()...) {
+		t.Error("expected ErrWrongValue for Directive inconsistent with Raw")
+	}
+}
@@ -38,6 +38,9 @@ func (ws WhileStatement) Errors() []error {
 	c := nodeChecker{Node: ws}
 	c.require(ws.Test, "while expression")
 	c.require(ws.Body, "while body")
+	if isLexicalDeclaration(ws.Body) {
+		c.appendf("lexical declaration as while body %w", ErrNotAllowed)
+	}
 	return c.errors()
 }
 
@@ -50,8 +53,9 @@ func (ws WhileStatement) MarshalJSON() ([]byte, error) {
 
 func (ws *WhileStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type string          `json:"type"`
-		Loc  SourceLocation  `json:"loc"`
 		Test json.RawMessage `json:"test"`
 		Body json.RawMessage `json:"body"`
 	}
@@ -60,7 +64,7 @@ func (ws *WhileStatement) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ws.Type(), x.Type)
 	}
 	if err == nil {
-		ws.Loc = x.Loc
+		ws.Loc = x.Location()
 		ws.Test, _, err = unmarshalExpression(x.Test)
 		var err2 error
 		if ws.Body, _, err2 = unmarshalStatement(x.Body); err == nil && err2 != nil {
@@ -102,6 +106,9 @@ func (dws DoWhileStatement) Walk(v Visitor) {
 func (dws DoWhileStatement) Errors() []error {
 	c := nodeChecker{Node: dws}
 	c.require(dws.Body, "while body")
+	if isLexicalDeclaration(dws.Body) {
+		c.appendf("lexical declaration as while body %w", ErrNotAllowed)
+	}
 	c.require(dws.Test, "while expression")
 	return c.errors()
 }
@@ -115,8 +122,9 @@ func (dws DoWhileStatement) MarshalJSON() ([]byte, error) {
 
 func (dws *DoWhileStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type string          `json:"type"`
-		Loc  SourceLocation  `json:"loc"`
 		Body json.RawMessage `json:"body"`
 		Test json.RawMessage `json:"test"`
 	}
@@ -125,7 +133,7 @@ func (dws *DoWhileStatement) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, dws.Type(), x.Type)
 	}
 	if err == nil {
-		dws.Loc = x.Loc
+		dws.Loc = x.Location()
 		dws.Body, _, err = unmarshalStatement(x.Body)
 		var err2 error
 		if dws.Test, _, err = unmarshalExpression(x.Test); err == nil && err2 != nil {
@@ -180,6 +188,9 @@ func (fs ForStatement) Errors() []error {
 	c.optional(fs.Test)
 	c.optional(fs.Update)
 	c.require(fs.Body, "for body")
+	if isLexicalDeclaration(fs.Body) {
+		c.appendf("lexical declaration as for body %w", ErrNotAllowed)
+	}
 	return c.errors()
 }
 
@@ -200,8 +211,9 @@ func (fs ForStatement) MarshalJSON() ([]byte, error) {
 
 func (fs *ForStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type   string          `json:"type"`
-		Loc    SourceLocation  `json:"loc"`
 		Init   json.RawMessage `json:"init"`
 		Test   json.RawMessage `json:"test"`
 		Update json.RawMessage `json:"update"`
@@ -212,7 +224,7 @@ func (fs *ForStatement) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, fs.Type(), x.Type)
 	}
 	if err == nil && len(x.Init) > 0 {
-		fs.Loc = x.Loc
+		fs.Loc = x.Location()
 		fs.Init, err = unmarshalVariableDeclarationOrExpression(x.Init)
 		var err2 error
 		fs.Test, _, err2 = unmarshalExpression(x.Test)
@@ -269,6 +281,9 @@ func (fis ForInStatement) Errors() []error {
 	c.require(fis.Left, "left-hand for...in expression")
 	c.require(fis.Right, "right-hand for...in expression")
 	c.require(fis.Body, "for...in body")
+	if isLexicalDeclaration(fis.Body) {
+		c.appendf("lexical declaration as for...in body %w", ErrNotAllowed)
+	}
 	return c.errors()
 }
 
@@ -282,8 +297,9 @@ func (fis ForInStatement) MarshalJSON() ([]byte, error) {
 
 func (fis *ForInStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type  string          `json:"type"`
-		Loc   SourceLocation  `json:"loc"`
 		Left  json.RawMessage `json:"left"`
 		Right json.RawMessage `json:"right"`
 		Body  json.RawMessage `json:"body"`
@@ -293,7 +309,7 @@ func (fis *ForInStatement) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, fis.Type(), x.Type)
 	}
 	if err == nil {
-		fis.Loc = x.Loc
+		fis.Loc = x.Location()
 		fis.Left, err = unmarshalVariableDeclarationOrPattern(x.Left)
 		var err2 error
 		if fis.Right, _, err = unmarshalExpression(x.Right); err == nil && err2 != nil {
@@ -305,3 +321,122 @@ func (fis *ForInStatement) UnmarshalJSON(b []byte) error {
 	}
 	return err
 }
+
+// ForOfStatement iterates over the values of an iterable object, e.g.
+// for (const x of y) {}.  If Await is set, it iterates over an async
+// iterable, e.g. for await (const x of y) {}.
+type ForOfStatement struct {
+	baseStatement
+	Loc   SourceLocation
+	Left  VariableDeclarationOrPattern
+	Right Expression
+	Body  Statement
+	Await bool
+}
+
+func (ForOfStatement) Type() string                 { return "ForOfStatement" }
+func (fos ForOfStatement) Location() SourceLocation { return fos.Loc }
+
+func (fos ForOfStatement) MinVersion() Version {
+	if fos.Await {
+		return ES2018
+	}
+	return ES2015
+}
+
+func (fos ForOfStatement) IsZero() bool {
+	return fos.Loc.IsZero() &&
+		(fos.Left == nil || fos.Left.IsZero()) &&
+		(fos.Right == nil || fos.Right.IsZero()) &&
+		(fos.Body == nil || fos.Body.IsZero()) &&
+		!fos.Await
+}
+
+func (fos ForOfStatement) Walk(v Visitor) {
+	if v = v.Visit(fos); v != nil {
+		defer v.Visit(nil)
+		if fos.Left != nil {
+			fos.Left.Walk(v)
+		}
+		if fos.Right != nil {
+			fos.Right.Walk(v)
+		}
+		if fos.Body != nil {
+			fos.Body.Walk(v)
+		}
+	}
+}
+
+// Errors checks the ForOfStatement.  A for await...of loop is only allowed
+// in an async function or at the top level of a module; since that can't be
+// determined without the ancestor Nodes, use Validate to check it.
+func (fos ForOfStatement) Errors() []error {
+	return fos.errors(true)
+}
+
+func (fos ForOfStatement) contextErrors(ctx validationContext) []error {
+	return fos.errors(awaitAllowed(ctx.ancestors))
+}
+
+func (fos ForOfStatement) errors(allowAwait bool) []error {
+	c := nodeChecker{Node: fos}
+	if fos.Await && !allowAwait {
+		c.appendf("for await outside of async function %w", ErrNotAllowed)
+	}
+	c.require(fos.Left, "left-hand for...of expression")
+	if vd, ok := fos.Left.(VariableDeclaration); ok {
+		if len(vd.Declarations) > 1 {
+			c.appendf("multiple declarations in for...of %w", ErrNotAllowed)
+		}
+		for _, d := range vd.Declarations {
+			if d.Init != nil {
+				c.appendf("initializer in for...of declaration %w", ErrNotAllowed)
+			}
+		}
+	}
+	c.require(fos.Right, "right-hand for...of expression")
+	c.require(fos.Body, "for...of body")
+	if isLexicalDeclaration(fos.Body) {
+		c.appendf("lexical declaration as for...of body %w", ErrNotAllowed)
+	}
+	return c.errors()
+}
+
+func (fos ForOfStatement) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(fos)
+	x["left"] = fos.Left
+	x["right"] = fos.Right
+	x["body"] = fos.Body
+	x["await"] = fos.Await
+	return json.Marshal(x)
+}
+
+func (fos *ForOfStatement) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type  string          `json:"type"`
+		Left  json.RawMessage `json:"left"`
+		Right json.RawMessage `json:"right"`
+		Body  json.RawMessage `json:"body"`
+		Await bool            `json:"await"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != fos.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, fos.Type(), x.Type)
+	}
+	if err == nil {
+		fos.Loc, fos.Await = x.Location(), x.Await
+		fos.Left, err = unmarshalVariableDeclarationOrPattern(x.Left)
+		var err2 error
+		fos.Right, _, err2 = unmarshalExpression(x.Right)
+		if err == nil && err2 != nil {
+			err = err2
+		}
+		fos.Body, _, err2 = unmarshalStatement(x.Body)
+		if err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
@@ -228,3 +228,85 @@ func TestForInStatement(t *testing.T) {
 		t.Error("expected ErrMissingNode for nil Body")
 	}
 }
+
+func TestForOfStatement(t *testing.T) {
+	var fos ForOfStatement
+	if !fos.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	fos.Left = VariableDeclaration{
+		Declarations: []VariableDeclarator{
+			VariableDeclarator{ID: Identifier{Name: "foo"}},
+		},
+		Kind: Const,
+	}
+	fos.Right = Identifier{Name: "bar"}
+	fos.Body = EmptyStatement{}
+	if fos.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if fos.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", fos.MinVersion())
+	}
+
+	var v mockVisitor
+	fos.Walk(&v)
+	v.expect(t, fos,
+		fos.Left,
+		fos.Left.(VariableDeclaration).Declarations[0],
+		fos.Left.(VariableDeclaration).Declarations[0].ID, nil, nil, nil,
+		fos.Right, nil,
+		fos.Body, nil, nil)
+
+	testRoundtripJSON(t, fos, new(ForOfStatement))
+
+	if errs := Validate(fos); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !hasError(ErrMissingNode, Validate(fos.Left)...) {
+		t.Error("expected ErrMissingNode for const without initializer outside for...of")
+	}
+
+	fos.Await = true
+	if fos.MinVersion() != ES2018 {
+		t.Errorf("expected ES2018, got %s", fos.MinVersion())
+	}
+	testRoundtripJSON(t, fos, new(ForOfStatement))
+	if errs := fos.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !hasError(ErrNotAllowed, Validate(fos)...) {
+		t.Error("expected ErrNotAllowed for for await outside of module")
+	}
+	p := Program{Body: []DirectiveOrStatement{fos}, SourceType: Module}
+	if errs := Validate(p); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	p.Body[0] = FunctionDeclaration{
+		ID:   Identifier{Name: "baz"},
+		Body: FunctionBody{Body: []DirectiveOrStatement{fos}},
+	}
+	if !hasError(ErrNotAllowed, Validate(p)...) {
+		t.Error("expected ErrNotAllowed for for await outside of async function")
+	}
+
+	fos.Left.(VariableDeclaration).Declarations[0].Init = NullLiteral{}
+	if !hasError(ErrNotAllowed, fos.Errors()...) {
+		t.Error("expected ErrNotAllowed for initializer in for...of")
+	}
+	fos.Left = nil
+	if !hasError(ErrMissingNode, fos.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Left")
+	}
+	fos.Left = Identifier{Name: "foo"}
+	fos.Body = VariableDeclaration{
+		Declarations: []VariableDeclarator{
+			VariableDeclarator{ID: Identifier{Name: "baz"}},
+		},
+		Kind: Let,
+	}
+	if !hasError(ErrNotAllowed, fos.Errors()...) {
+		t.Error("expected ErrNotAllowed for lexical declaration as Body")
+	}
+}
@@ -0,0 +1,62 @@
+package estree
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MarshalOptions controls how Marshal encodes a Node as JSON.  The zero value
+// produces the same output as json.Marshal.
+type MarshalOptions struct {
+	// Ranges adds a range property, [start, end], to each Node with a known
+	// SourceLocation.Range, as Espree does.  The start and end properties, as
+	// used by Acorn, are always included.
+	Ranges bool
+
+	// LegacyDirectives encodes each Directive with a "Directive" type, as
+	// earlier versions of this package did, rather than as an
+	// ExpressionStatement with a directive property.
+	LegacyDirectives bool
+}
+
+// Marshal returns the JSON encoding of n, according to the options.
+func (mo MarshalOptions) Marshal(n Node) ([]byte, error) {
+	b, err := json.Marshal(n)
+	if err != nil || mo == (MarshalOptions{}) {
+		return b, err
+	}
+	var x interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&x); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mo.rewrite(x))
+}
+
+// rewrite applies the options to the decoded JSON value x, which is modified
+// in place.
+func (mo MarshalOptions) rewrite(x interface{}) interface{} {
+	switch x := x.(type) {
+	case map[string]interface{}:
+		for _, v := range x {
+			mo.rewrite(v)
+		}
+		if _, isNode := x["type"].(string); !isNode {
+			break
+		}
+		start, hasStart := x["start"].(json.Number)
+		end, hasEnd := x["end"].(json.Number)
+		if mo.Ranges && hasStart && hasEnd {
+			x["range"] = []json.Number{start, end}
+		}
+		if _, isDirective := x["directive"].(string); mo.LegacyDirectives && isDirective && x["type"] == (ExpressionStatement{}).Type() {
+			x["type"] = Directive{}.Type()
+		}
+	case []interface{}:
+		for _, v := range x {
+			mo.rewrite(v)
+		}
+	}
+	return x
+}
@@ -0,0 +1,67 @@
+package estree
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRange(t *testing.T) {
+	// Acorn: x = 1
+	acorn := []byte(`{"type":"ExpressionStatement","start":0,"end":5,"expression":` +
+		`{"type":"AssignmentExpression","start":0,"end":5,"operator":"=",` +
+		`"left":{"type":"Identifier","start":0,"end":1,"name":"x"},` +
+		`"right":{"type":"Literal","start":4,"end":5,"value":1,"raw":"1"}}}`)
+	// Espree: x = 1
+	espree := []byte(`{"type":"ExpressionStatement","range":[0,5],"expression":` +
+		`{"type":"AssignmentExpression","range":[0,5],"operator":"=",` +
+		`"left":{"type":"Identifier","range":[0,1],"name":"x"},` +
+		`"right":{"type":"Literal","range":[4,5],"value":1,"raw":"1"}}}`)
+
+	var es ExpressionStatement
+	for _, b := range [][]byte{acorn, espree} {
+		es = ExpressionStatement{}
+		if err := json.Unmarshal(b, &es); err != nil {
+			t.Fatal(err)
+		}
+		if es.Loc.Range != (Range{Start: 0, End: 5}) || es.Loc.IsZero() {
+			t.Errorf("expected Range [0, 5], got %v", es.Loc.Range)
+		}
+		ae := es.Expression.(AssignmentExpression)
+		if r := ae.Right.Location().Range; r != (Range{Start: 4, End: 5}) {
+			t.Errorf("expected Range [4, 5], got %v", r)
+		}
+	}
+	testRoundtripJSON(t, es, new(ExpressionStatement))
+
+	b, err := MarshalOptions{}.Marshal(es)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b, []byte(`"start":4,`)) || bytes.Contains(b, []byte(`"range"`)) {
+		t.Errorf("expected start and end without range, got %s", b)
+	}
+	b, err = MarshalOptions{Ranges: true}.Marshal(es)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b, []byte(`"range":[4,5]`)) {
+		t.Errorf("expected range, got %s", b)
+	}
+	var out ExpressionStatement
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(es, out) {
+		t.Errorf("JSON roundtrip failed: %s", b)
+	}
+
+	if errs := Validate(es); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	es.Loc.Range = Range{Start: 5, End: 0}
+	if !hasError(ErrWrongValue, es.Errors()...) {
+		t.Error("expected ErrWrongValue for inverted Range")
+	}
+}
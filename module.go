@@ -0,0 +1,891 @@
+package estree
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SourceType indicates whether a Program was parsed as a script or an ES
+// module.
+type SourceType string
+
+var (
+	Script SourceType = "script"
+	Module SourceType = "module"
+)
+
+func (st SourceType) GoString() string {
+	switch st {
+	case Script:
+		return "Script"
+	case Module:
+		return "Module"
+	}
+	return fmt.Sprintf("%q", st)
+}
+
+func (st SourceType) IsValid() bool {
+	switch st {
+	case Script, Module:
+		return true
+	}
+	return false
+}
+
+func (st SourceType) MinVersion() Version {
+	if st == Module {
+		return ES2015
+	}
+	return ES5
+}
+
+// ModuleDeclaration is an import or export declaration.  These may only
+// appear at the top level of a Program whose SourceType is Module.
+type ModuleDeclaration interface {
+	DirectiveOrStatement
+	isModuleDeclaration()
+}
+
+func unmarshalModuleDeclaration(m json.RawMessage) (md ModuleDeclaration, match bool, err error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err = json.Unmarshal(m, &x); err != nil {
+		match = true
+	} else {
+		switch x.Type {
+		case ImportDeclaration{}.Type():
+			var id ImportDeclaration
+			err, match, md = json.Unmarshal(m, &id), true, id
+		case ExportNamedDeclaration{}.Type():
+			var end ExportNamedDeclaration
+			err, match, md = json.Unmarshal(m, &end), true, end
+		case ExportDefaultDeclaration{}.Type():
+			var edd ExportDefaultDeclaration
+			err, match, md = json.Unmarshal(m, &edd), true, edd
+		case ExportAllDeclaration{}.Type():
+			var ead ExportAllDeclaration
+			err, match, md = json.Unmarshal(m, &ead), true, ead
+		default:
+			err = fmt.Errorf("%w ModuleDeclaration, got %v", ErrWrongType, string(m))
+		}
+		if err != nil {
+			md = nil // don't return incomplete objects
+		}
+	}
+	return
+}
+
+type baseModuleDeclaration struct{}
+
+func (baseModuleDeclaration) MinVersion() Version     { return ES2015 }
+func (baseModuleDeclaration) isModuleDeclaration()    {}
+func (baseModuleDeclaration) isDirectiveOrStatement() {}
+
+// exportedNames returns the names exported by a ModuleDeclaration.
+func exportedNames(md ModuleDeclaration) []string {
+	switch md := md.(type) {
+	case ExportNamedDeclaration:
+		switch d := md.Declaration.(type) {
+		case FunctionDeclaration:
+			return []string{d.ID.Name}
+		case ClassDeclaration:
+			return []string{d.ID.Name}
+		case VariableDeclaration:
+			var names []string
+			for _, vd := range d.Declarations {
+				names = append(names, boundNames(vd.ID)...)
+			}
+			return names
+		}
+		names := make([]string, len(md.Specifiers))
+		for i, es := range md.Specifiers {
+			names[i] = es.Exported.Name
+		}
+		return names
+	case ExportDefaultDeclaration:
+		return []string{"default"}
+	case ExportAllDeclaration:
+		if !md.Exported.IsZero() {
+			return []string{md.Exported.Name}
+		}
+	}
+	return nil
+}
+
+// unmarshalModuleSource decodes the source of an import or export
+// declaration, which may be null.
+func unmarshalModuleSource(m json.RawMessage) (Literal, error) {
+	if isNullOrEmptyRawMessage(m) {
+		return nil, nil
+	}
+	l, _, err := unmarshalLiteral(m)
+	return l, err
+}
+
+// requireModuleSource appends a SyntaxError if the source of an import or
+// export declaration is missing or is not a StringLiteral.
+func (c *nodeChecker) requireModuleSource(l Literal) {
+	c.require(l, "module source")
+	if l != nil {
+		if _, ok := l.(StringLiteral); !ok {
+			c.appendf("%w module source %T", ErrWrongValue, l)
+		}
+	}
+}
+
+// ImportDeclarationSpecifier is an ImportSpecifier, ImportDefaultSpecifier,
+// or ImportNamespaceSpecifier.
+type ImportDeclarationSpecifier interface {
+	Node
+	isImportDeclarationSpecifier()
+}
+
+func unmarshalImportDeclarationSpecifier(m json.RawMessage) (ids ImportDeclarationSpecifier, err error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err = json.Unmarshal(m, &x); err == nil {
+		switch x.Type {
+		case ImportSpecifier{}.Type():
+			var is ImportSpecifier
+			err, ids = json.Unmarshal(m, &is), is
+		case ImportDefaultSpecifier{}.Type():
+			var ids2 ImportDefaultSpecifier
+			err, ids = json.Unmarshal(m, &ids2), ids2
+		case ImportNamespaceSpecifier{}.Type():
+			var ins ImportNamespaceSpecifier
+			err, ids = json.Unmarshal(m, &ins), ins
+		default:
+			err = fmt.Errorf("%w ImportSpecifier, ImportDefaultSpecifier, or ImportNamespaceSpecifier, got %v", ErrWrongType, string(m))
+		}
+		if err != nil {
+			ids = nil // don't return incomplete objects
+		}
+	}
+	return
+}
+
+// BindingKind indicates whether an import or export is of values, or only of
+// types, e.g. import type {Foo} from "mod".  It is not part of ESTree, but is
+// used by the TypeScript and Flow extensions.  An empty BindingKind is
+// equivalent to ValueBinding.
+type BindingKind string
+
+const (
+	ValueBinding  BindingKind = "value"
+	TypeBinding   BindingKind = "type"
+	TypeofBinding BindingKind = "typeof" // Flow only, and only for imports
+)
+
+func (bk BindingKind) IsValid() bool {
+	switch bk {
+	case ValueBinding, TypeBinding, TypeofBinding:
+		return true
+	}
+	return false
+}
+
+// checkBindingKind reports ErrWrongValue for a non-empty, invalid BindingKind.
+func (c *nodeChecker) checkBindingKind(bk BindingKind, what string) {
+	if bk != "" && !bk.IsValid() {
+		c.appendf("%w %s %q", ErrWrongValue, what, bk)
+	}
+}
+
+// checkExportKind is checkBindingKind for exports, which cannot be typeof.
+func (c *nodeChecker) checkExportKind(bk BindingKind, what string) {
+	c.checkBindingKind(bk, what)
+	if bk == TypeofBinding {
+		c.appendf("%s %q %w", what, bk, ErrNotAllowed)
+	}
+}
+
+// ImportDeclaration imports bindings from another module, e.g.
+// import foo, {bar as baz} from "mod".
+type ImportDeclaration struct {
+	baseModuleDeclaration
+	Loc        SourceLocation
+	Specifiers []ImportDeclarationSpecifier
+	Source     Literal
+	ImportKind BindingKind // possibly empty
+}
+
+func (ImportDeclaration) Type() string                { return "ImportDeclaration" }
+func (id ImportDeclaration) Location() SourceLocation { return id.Loc }
+
+func (id ImportDeclaration) IsZero() bool {
+	return id.Loc.IsZero() &&
+		len(id.Specifiers) == 0 &&
+		id.Source == nil &&
+		id.ImportKind == ""
+}
+
+func (id ImportDeclaration) Walk(v Visitor) {
+	if v = v.Visit(id); v != nil {
+		defer v.Visit(nil)
+		for _, s := range id.Specifiers {
+			s.Walk(v)
+		}
+		if id.Source != nil {
+			id.Source.Walk(v)
+		}
+	}
+}
+
+func (id ImportDeclaration) Errors() []error {
+	c := nodeChecker{Node: id}
+	c.requireEach(nodeSlice{
+		Index: func(i int) Node { return id.Specifiers[i] },
+		Len:   len(id.Specifiers),
+	}, "import specifier")
+	var named bool
+	var namespaces int
+	for i, s := range id.Specifiers {
+		switch s.(type) {
+		case ImportDefaultSpecifier:
+			if i > 0 {
+				c.appendf("default import after other specifiers %w", ErrNotAllowed)
+			}
+		case ImportNamespaceSpecifier:
+			namespaces++
+		case ImportSpecifier:
+			named = true
+		}
+	}
+	if namespaces > 1 {
+		c.appendf("multiple namespace imports %w", ErrNotAllowed)
+	} else if namespaces == 1 && (named || len(id.Specifiers) > 2) {
+		c.appendf("namespace import with other named imports %w", ErrNotAllowed)
+	}
+	c.requireModuleSource(id.Source)
+	c.checkBindingKind(id.ImportKind, "ImportDeclaration.ImportKind")
+	return c.errors()
+}
+
+func (id ImportDeclaration) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(id)
+	if len(id.Specifiers) > 0 {
+		x["specifiers"] = id.Specifiers
+	} else {
+		x["specifiers"] = []ImportDeclarationSpecifier{}
+	}
+	x["source"] = id.Source
+	if id.ImportKind != "" {
+		x["importKind"] = id.ImportKind
+	}
+	return json.Marshal(x)
+}
+
+func (id *ImportDeclaration) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type       string            `json:"type"`
+		Specifiers []json.RawMessage `json:"specifiers"`
+		Source     json.RawMessage   `json:"source"`
+		ImportKind BindingKind       `json:"importKind"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != id.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, id.Type(), x.Type)
+	}
+	if err == nil {
+		id.Loc, id.ImportKind = x.Location(), x.ImportKind
+		if len(x.Specifiers) == 0 {
+			id.Specifiers = nil
+		} else {
+			id.Specifiers = make([]ImportDeclarationSpecifier, len(x.Specifiers))
+			for i := range x.Specifiers {
+				var err2 error
+				id.Specifiers[i], err2 = unmarshalImportDeclarationSpecifier(x.Specifiers[i])
+				if err == nil && err2 != nil {
+					err = err2
+				}
+			}
+		}
+		var err2 error
+		id.Source, err2 = unmarshalModuleSource(x.Source)
+		if err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// ImportSpecifier imports a single named binding, e.g. {foo} or
+// {foo as bar}.  Imported and Local are the same name when not renamed.
+type ImportSpecifier struct {
+	Loc        SourceLocation
+	Imported   Identifier
+	Local      Identifier
+	ImportKind BindingKind // possibly empty, e.g. {type foo}
+}
+
+func (ImportSpecifier) Type() string                  { return "ImportSpecifier" }
+func (is ImportSpecifier) Location() SourceLocation   { return is.Loc }
+func (ImportSpecifier) MinVersion() Version           { return ES2015 }
+func (ImportSpecifier) isImportDeclarationSpecifier() {}
+
+func (is ImportSpecifier) IsZero() bool {
+	return is.Loc.IsZero() &&
+		is.Imported.IsZero() &&
+		is.Local.IsZero() &&
+		is.ImportKind == ""
+}
+
+func (is ImportSpecifier) Walk(v Visitor) {
+	if v = v.Visit(is); v != nil {
+		defer v.Visit(nil)
+		is.Imported.Walk(v)
+		is.Local.Walk(v)
+	}
+}
+
+func (is ImportSpecifier) Errors() []error {
+	c := nodeChecker{Node: is}
+	c.require(is.Imported, "imported name")
+	c.require(is.Local, "local name")
+	c.checkBindingKind(is.ImportKind, "ImportSpecifier.ImportKind")
+	return c.errors()
+}
+
+func (is ImportSpecifier) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(is)
+	x["imported"] = is.Imported
+	x["local"] = is.Local
+	if is.ImportKind != "" {
+		x["importKind"] = is.ImportKind
+	}
+	return json.Marshal(x)
+}
+
+func (is *ImportSpecifier) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type       string      `json:"type"`
+		Imported   Identifier  `json:"imported"`
+		Local      Identifier  `json:"local"`
+		ImportKind BindingKind `json:"importKind"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != is.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, is.Type(), x.Type)
+	}
+	if err == nil {
+		is.Loc, is.Imported, is.Local = x.Location(), x.Imported, x.Local
+		is.ImportKind = x.ImportKind
+	}
+	return err
+}
+
+// ImportDefaultSpecifier imports the default export of a module, e.g. foo in
+// import foo from "mod".
+type ImportDefaultSpecifier struct {
+	Loc   SourceLocation
+	Local Identifier
+}
+
+func (ImportDefaultSpecifier) Type() string                  { return "ImportDefaultSpecifier" }
+func (ids ImportDefaultSpecifier) Location() SourceLocation  { return ids.Loc }
+func (ImportDefaultSpecifier) MinVersion() Version           { return ES2015 }
+func (ImportDefaultSpecifier) isImportDeclarationSpecifier() {}
+
+func (ids ImportDefaultSpecifier) IsZero() bool {
+	return ids.Loc.IsZero() && ids.Local.IsZero()
+}
+
+func (ids ImportDefaultSpecifier) Walk(v Visitor) {
+	if v = v.Visit(ids); v != nil {
+		defer v.Visit(nil)
+		ids.Local.Walk(v)
+	}
+}
+
+func (ids ImportDefaultSpecifier) Errors() []error {
+	c := nodeChecker{Node: ids}
+	c.require(ids.Local, "local name")
+	return c.errors()
+}
+
+func (ids ImportDefaultSpecifier) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(ids)
+	x["local"] = ids.Local
+	return json.Marshal(x)
+}
+
+func (ids *ImportDefaultSpecifier) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type  string     `json:"type"`
+		Local Identifier `json:"local"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ids.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ids.Type(), x.Type)
+	}
+	if err == nil {
+		ids.Loc, ids.Local = x.Location(), x.Local
+	}
+	return err
+}
+
+// ImportNamespaceSpecifier imports a module namespace object, e.g. * as foo.
+type ImportNamespaceSpecifier struct {
+	Loc   SourceLocation
+	Local Identifier
+}
+
+func (ImportNamespaceSpecifier) Type() string                  { return "ImportNamespaceSpecifier" }
+func (ins ImportNamespaceSpecifier) Location() SourceLocation  { return ins.Loc }
+func (ImportNamespaceSpecifier) MinVersion() Version           { return ES2015 }
+func (ImportNamespaceSpecifier) isImportDeclarationSpecifier() {}
+
+func (ins ImportNamespaceSpecifier) IsZero() bool {
+	return ins.Loc.IsZero() && ins.Local.IsZero()
+}
+
+func (ins ImportNamespaceSpecifier) Walk(v Visitor) {
+	if v = v.Visit(ins); v != nil {
+		defer v.Visit(nil)
+		ins.Local.Walk(v)
+	}
+}
+
+func (ins ImportNamespaceSpecifier) Errors() []error {
+	c := nodeChecker{Node: ins}
+	c.require(ins.Local, "local name")
+	return c.errors()
+}
+
+func (ins ImportNamespaceSpecifier) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(ins)
+	x["local"] = ins.Local
+	return json.Marshal(x)
+}
+
+func (ins *ImportNamespaceSpecifier) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type  string     `json:"type"`
+		Local Identifier `json:"local"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ins.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ins.Type(), x.Type)
+	}
+	if err == nil {
+		ins.Loc, ins.Local = x.Location(), x.Local
+	}
+	return err
+}
+
+// ExportNamedDeclaration exports either a Declaration, e.g.
+// export var foo = 1, or a list of ExportSpecifiers, e.g.
+// export {foo, bar as baz}.  Source is non-nil when re-exporting from
+// another module.
+type ExportNamedDeclaration struct {
+	baseModuleDeclaration
+	Loc         SourceLocation
+	Declaration Declaration // or nil
+	Specifiers  []ExportSpecifier
+	Source      Literal     // or nil
+	ExportKind  BindingKind // possibly empty
+}
+
+func (ExportNamedDeclaration) Type() string                 { return "ExportNamedDeclaration" }
+func (end ExportNamedDeclaration) Location() SourceLocation { return end.Loc }
+
+func (end ExportNamedDeclaration) IsZero() bool {
+	return end.Loc.IsZero() &&
+		(end.Declaration == nil || end.Declaration.IsZero()) &&
+		len(end.Specifiers) == 0 &&
+		end.Source == nil &&
+		end.ExportKind == ""
+}
+
+func (end ExportNamedDeclaration) Walk(v Visitor) {
+	if v = v.Visit(end); v != nil {
+		defer v.Visit(nil)
+		if end.Declaration != nil {
+			end.Declaration.Walk(v)
+		}
+		for _, es := range end.Specifiers {
+			es.Walk(v)
+		}
+		if end.Source != nil {
+			end.Source.Walk(v)
+		}
+	}
+}
+
+func (end ExportNamedDeclaration) Errors() []error {
+	c := nodeChecker{Node: end}
+	c.optional(end.Declaration)
+	c.requireEach(nodeSlice{
+		Index: func(i int) Node { return end.Specifiers[i] },
+		Len:   len(end.Specifiers),
+	}, "export specifier")
+	if end.Declaration != nil {
+		if len(end.Specifiers) > 0 {
+			c.appendf("export specifiers with declaration %w", ErrNotAllowed)
+		}
+		if end.Source != nil {
+			c.appendf("module source with declaration %w", ErrNotAllowed)
+		}
+	} else if end.Source != nil {
+		c.requireModuleSource(end.Source)
+	}
+	c.checkExportKind(end.ExportKind, "ExportNamedDeclaration.ExportKind")
+	return c.errors()
+}
+
+func (end ExportNamedDeclaration) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(end)
+	x["declaration"] = end.Declaration
+	if len(end.Specifiers) > 0 {
+		x["specifiers"] = end.Specifiers
+	} else {
+		x["specifiers"] = []ExportSpecifier{}
+	}
+	x["source"] = end.Source
+	if end.ExportKind != "" {
+		x["exportKind"] = end.ExportKind
+	}
+	return json.Marshal(x)
+}
+
+func (end *ExportNamedDeclaration) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type        string            `json:"type"`
+		Declaration json.RawMessage   `json:"declaration"`
+		Specifiers  []ExportSpecifier `json:"specifiers"`
+		Source      json.RawMessage   `json:"source"`
+		ExportKind  BindingKind       `json:"exportKind"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != end.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, end.Type(), x.Type)
+	}
+	if err == nil {
+		end.Loc, end.ExportKind = x.Location(), x.ExportKind
+		if len(x.Specifiers) == 0 {
+			end.Specifiers = nil
+		} else {
+			end.Specifiers = x.Specifiers
+		}
+		var s Statement
+		s, _, err = unmarshalStatement(x.Declaration)
+		if d, ok := s.(Declaration); ok || s == nil {
+			end.Declaration = d
+		} else if err == nil {
+			err = fmt.Errorf("%w Declaration, got %v", ErrWrongType, string(x.Declaration))
+		}
+		var err2 error
+		end.Source, err2 = unmarshalModuleSource(x.Source)
+		if err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// ExportSpecifier exports a single local binding, e.g. {foo} or
+// {foo as bar}.  Local and Exported are the same name when not renamed.
+type ExportSpecifier struct {
+	Loc        SourceLocation
+	Local      Identifier
+	Exported   Identifier
+	ExportKind BindingKind // possibly empty, e.g. {type foo}
+}
+
+func (ExportSpecifier) Type() string                { return "ExportSpecifier" }
+func (es ExportSpecifier) Location() SourceLocation { return es.Loc }
+func (ExportSpecifier) MinVersion() Version         { return ES2015 }
+
+func (es ExportSpecifier) IsZero() bool {
+	return es.Loc.IsZero() &&
+		es.Local.IsZero() &&
+		es.Exported.IsZero() &&
+		es.ExportKind == ""
+}
+
+func (es ExportSpecifier) Walk(v Visitor) {
+	if v = v.Visit(es); v != nil {
+		defer v.Visit(nil)
+		es.Local.Walk(v)
+		es.Exported.Walk(v)
+	}
+}
+
+func (es ExportSpecifier) Errors() []error {
+	c := nodeChecker{Node: es}
+	c.require(es.Local, "local name")
+	c.require(es.Exported, "exported name")
+	c.checkExportKind(es.ExportKind, "ExportSpecifier.ExportKind")
+	return c.errors()
+}
+
+func (es ExportSpecifier) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(es)
+	x["local"] = es.Local
+	x["exported"] = es.Exported
+	if es.ExportKind != "" {
+		x["exportKind"] = es.ExportKind
+	}
+	return json.Marshal(x)
+}
+
+func (es *ExportSpecifier) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type       string      `json:"type"`
+		Local      Identifier  `json:"local"`
+		Exported   Identifier  `json:"exported"`
+		ExportKind BindingKind `json:"exportKind"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != es.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, es.Type(), x.Type)
+	}
+	if err == nil {
+		es.Loc, es.Local, es.Exported = x.Location(), x.Local, x.Exported
+		es.ExportKind = x.ExportKind
+	}
+	return err
+}
+
+// DeclarationOrExpression is the value of an ExportDefaultDeclaration: either
+// an Expression, or a FunctionDeclaration or ClassDeclaration (whose ID may
+// be zero in this position).
+type DeclarationOrExpression interface {
+	Node
+	isDeclarationOrExpression()
+}
+
+func unmarshalDeclarationOrExpression(m json.RawMessage) (DeclarationOrExpression, error) {
+	if e, match, err := unmarshalExpression(m); match {
+		return e, err
+	}
+	s, match, err := unmarshalStatement(m)
+	if d, ok := s.(Declaration); ok {
+		return d, err
+	} else if match && err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%w Declaration or Expression, got %v", ErrWrongType, string(m))
+}
+
+// ExportDefaultDeclaration exports the default value of a module, e.g.
+// export default function () {}.
+type ExportDefaultDeclaration struct {
+	baseModuleDeclaration
+	Loc         SourceLocation
+	Declaration DeclarationOrExpression
+}
+
+func (ExportDefaultDeclaration) Type() string                 { return "ExportDefaultDeclaration" }
+func (edd ExportDefaultDeclaration) Location() SourceLocation { return edd.Loc }
+
+func (edd ExportDefaultDeclaration) IsZero() bool {
+	return edd.Loc.IsZero() &&
+		(edd.Declaration == nil || edd.Declaration.IsZero())
+}
+
+func (edd ExportDefaultDeclaration) Walk(v Visitor) {
+	if v = v.Visit(edd); v != nil {
+		defer v.Visit(nil)
+		if edd.Declaration != nil {
+			edd.Declaration.Walk(v)
+		}
+	}
+}
+
+func (edd ExportDefaultDeclaration) Errors() []error {
+	c := nodeChecker{Node: edd}
+	c.require(edd.Declaration, "default export")
+	if _, ok := edd.Declaration.(VariableDeclaration); ok {
+		c.appendf("variable declaration as default export %w", ErrNotAllowed)
+	}
+	return c.errors()
+}
+
+func (edd ExportDefaultDeclaration) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(edd)
+	x["declaration"] = edd.Declaration
+	return json.Marshal(x)
+}
+
+func (edd *ExportDefaultDeclaration) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type        string          `json:"type"`
+		Declaration json.RawMessage `json:"declaration"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != edd.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, edd.Type(), x.Type)
+	}
+	if err == nil {
+		edd.Loc = x.Location()
+		edd.Declaration, err = unmarshalDeclarationOrExpression(x.Declaration)
+	}
+	return err
+}
+
+// ExportAllDeclaration re-exports every binding from another module, e.g.
+// export * from "mod".  Exported is non-zero when the bindings are
+// re-exported as a namespace object, e.g. export * as foo from "mod".
+type ExportAllDeclaration struct {
+	baseModuleDeclaration
+	Loc        SourceLocation
+	Exported   Identifier // possibly zero
+	Source     Literal
+	ExportKind BindingKind // possibly empty
+}
+
+func (ExportAllDeclaration) Type() string                 { return "ExportAllDeclaration" }
+func (ead ExportAllDeclaration) Location() SourceLocation { return ead.Loc }
+
+func (ead ExportAllDeclaration) MinVersion() Version {
+	if !ead.Exported.IsZero() {
+		return ES2020
+	}
+	return ES2015
+}
+
+func (ead ExportAllDeclaration) IsZero() bool {
+	return ead.Loc.IsZero() &&
+		ead.Exported.IsZero() &&
+		ead.Source == nil &&
+		ead.ExportKind == ""
+}
+
+func (ead ExportAllDeclaration) Walk(v Visitor) {
+	if v = v.Visit(ead); v != nil {
+		defer v.Visit(nil)
+		if !ead.Exported.IsZero() {
+			ead.Exported.Walk(v)
+		}
+		if ead.Source != nil {
+			ead.Source.Walk(v)
+		}
+	}
+}
+
+func (ead ExportAllDeclaration) Errors() []error {
+	c := nodeChecker{Node: ead}
+	c.optional(ead.Exported)
+	c.requireModuleSource(ead.Source)
+	c.checkExportKind(ead.ExportKind, "ExportAllDeclaration.ExportKind")
+	return c.errors()
+}
+
+func (ead ExportAllDeclaration) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(ead)
+	if ead.Exported.IsZero() {
+		x["exported"] = nil
+	} else {
+		x["exported"] = ead.Exported
+	}
+	x["source"] = ead.Source
+	if ead.ExportKind != "" {
+		x["exportKind"] = ead.ExportKind
+	}
+	return json.Marshal(x)
+}
+
+func (ead *ExportAllDeclaration) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type       string          `json:"type"`
+		Exported   Identifier      `json:"exported"`
+		Source     json.RawMessage `json:"source"`
+		ExportKind BindingKind     `json:"exportKind"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ead.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ead.Type(), x.Type)
+	}
+	if err == nil {
+		ead.Loc, ead.Exported, ead.ExportKind = x.Location(), x.Exported, x.ExportKind
+		ead.Source, err = unmarshalModuleSource(x.Source)
+	}
+	return err
+}
+
+// ImportExpression is a dynamic import, e.g. import("mod"), which loads a
+// module at runtime and evaluates to a Promise of its namespace object.
+type ImportExpression struct {
+	baseExpression
+	Loc     SourceLocation
+	Source  Expression
+	Options Expression // possibly nil
+}
+
+func (ImportExpression) Type() string                { return "ImportExpression" }
+func (ie ImportExpression) Location() SourceLocation { return ie.Loc }
+func (ImportExpression) MinVersion() Version         { return ES2020 }
+
+func (ie ImportExpression) IsZero() bool {
+	return ie.Loc.IsZero() &&
+		(ie.Source == nil || ie.Source.IsZero()) &&
+		(ie.Options == nil || ie.Options.IsZero())
+}
+
+func (ie ImportExpression) Walk(v Visitor) {
+	if v = v.Visit(ie); v != nil {
+		defer v.Visit(nil)
+		if ie.Source != nil {
+			ie.Source.Walk(v)
+		}
+		if ie.Options != nil {
+			ie.Options.Walk(v)
+		}
+	}
+}
+
+func (ie ImportExpression) Errors() []error {
+	c := nodeChecker{Node: ie}
+	c.require(ie.Source, "import source")
+	c.optional(ie.Options)
+	return c.errors()
+}
+
+func (ie ImportExpression) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(ie)
+	x["source"] = ie.Source
+	x["options"] = ie.Options
+	return json.Marshal(x)
+}
+
+func (ie *ImportExpression) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type    string          `json:"type"`
+		Source  json.RawMessage `json:"source"`
+		Options json.RawMessage `json:"options"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ie.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ie.Type(), x.Type)
+	}
+	if err == nil {
+		ie.Loc = x.Location()
+		ie.Source, _, err = unmarshalExpression(x.Source)
+		var err2 error
+		if ie.Options, _, err2 = unmarshalExpression(x.Options); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
@@ -0,0 +1,461 @@
+package estree
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestImportDeclaration(t *testing.T) {
+	var id ImportDeclaration
+	if !id.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	id.Specifiers = []ImportDeclarationSpecifier{
+		ImportDefaultSpecifier{Local: Identifier{Name: "foo"}},
+		ImportSpecifier{
+			Imported: Identifier{Name: "bar"},
+			Local:    Identifier{Name: "baz"},
+		},
+	}
+	id.Source = StringLiteral{Value: "mod"}
+	if id.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if id.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", id.MinVersion())
+	}
+
+	var v mockVisitor
+	id.Walk(&v)
+	v.expect(t, id,
+		id.Specifiers[0],
+		id.Specifiers[0].(ImportDefaultSpecifier).Local, nil, nil,
+		id.Specifiers[1],
+		id.Specifiers[1].(ImportSpecifier).Imported, nil,
+		id.Specifiers[1].(ImportSpecifier).Local, nil, nil,
+		id.Source, nil, nil)
+
+	testRoundtripJSON(t, id, new(ImportDeclaration))
+
+	if errs := id.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	id.ImportKind = TypeBinding
+	testRoundtripJSON(t, id, new(ImportDeclaration))
+	if errs := id.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	id.ImportKind = TypeofBinding
+	if errs := id.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	id.ImportKind = "foo"
+	if !hasError(ErrWrongValue, id.Errors()...) {
+		t.Error("expected ErrWrongValue for invalid ImportKind")
+	}
+	id.ImportKind = ""
+	id.Specifiers[0] = ImportNamespaceSpecifier{Local: Identifier{Name: "foo"}}
+	testRoundtripJSON(t, id, new(ImportDeclaration))
+	if !hasError(ErrNotAllowed, id.Errors()...) {
+		t.Error("expected ErrNotAllowed for namespace with named imports")
+	}
+	id.Specifiers[0], id.Specifiers[1] = id.Specifiers[1], ImportDefaultSpecifier{Local: Identifier{Name: "foo"}}
+	if !hasError(ErrNotAllowed, id.Errors()...) {
+		t.Error("expected ErrNotAllowed for default import after named imports")
+	}
+	// import * as a, * as b from "mod"
+	id.Specifiers = []ImportDeclarationSpecifier{
+		ImportNamespaceSpecifier{Local: Identifier{Name: "a"}},
+		ImportNamespaceSpecifier{Local: Identifier{Name: "b"}},
+	}
+	if !hasError(ErrNotAllowed, id.Errors()...) {
+		t.Error("expected ErrNotAllowed for two namespace imports")
+	}
+	// import "mod"
+	id.Specifiers = nil
+	testRoundtripJSON(t, id, new(ImportDeclaration))
+	if b, err := json.Marshal(id); err != nil {
+		t.Error(err)
+	} else if !bytes.Contains(b, []byte(`"specifiers":[]`)) {
+		t.Errorf("expected empty specifiers array, got %s", b)
+	}
+	id.Source = NumberLiteral{Value: 1}
+	if !hasError(ErrWrongValue, id.Errors()...) {
+		t.Error("expected ErrWrongValue for non-string Source")
+	}
+	id.Source = nil
+	if !hasError(ErrMissingNode, id.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Source")
+	}
+}
+
+func TestImportSpecifier(t *testing.T) {
+	var is ImportSpecifier
+	if !is.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	is.Imported = Identifier{Name: "foo"}
+	is.Local = Identifier{Name: "foo"}
+	if is.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if is.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", is.MinVersion())
+	}
+
+	testRoundtripJSON(t, is, new(ImportSpecifier))
+
+	if errs := is.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	is.ImportKind = TypeBinding
+	testRoundtripJSON(t, is, new(ImportSpecifier))
+	if errs := is.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	is.Local = Identifier{}
+	if !hasError(ErrMissingNode, is.Errors()...) {
+		t.Error("expected ErrMissingNode for zero Local")
+	}
+
+	var ids ImportDefaultSpecifier
+	if !ids.IsZero() {
+		t.Error("expected IsZero()")
+	}
+	if !hasError(ErrMissingNode, ids.Errors()...) {
+		t.Error("expected ErrMissingNode for zero Local")
+	}
+
+	var ins ImportNamespaceSpecifier
+	if !ins.IsZero() {
+		t.Error("expected IsZero()")
+	}
+	if !hasError(ErrMissingNode, ins.Errors()...) {
+		t.Error("expected ErrMissingNode for zero Local")
+	}
+}
+
+func TestExportNamedDeclaration(t *testing.T) {
+	var end ExportNamedDeclaration
+	if !end.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	end.Declaration = VariableDeclaration{
+		Declarations: []VariableDeclarator{
+			VariableDeclarator{
+				ID:   Identifier{Name: "foo"},
+				Init: NumberLiteral{Value: 1},
+			},
+		},
+		Kind: Const,
+	}
+	if end.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if end.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", end.MinVersion())
+	}
+
+	var v mockVisitor
+	end.Walk(&v)
+	v.expect(t, end,
+		end.Declaration,
+		end.Declaration.(VariableDeclaration).Declarations[0],
+		end.Declaration.(VariableDeclaration).Declarations[0].ID, nil,
+		end.Declaration.(VariableDeclaration).Declarations[0].Init, nil, nil, nil, nil)
+
+	testRoundtripJSON(t, end, new(ExportNamedDeclaration))
+	if b, err := json.Marshal(end); err != nil {
+		t.Error(err)
+	} else if !bytes.Contains(b, []byte(`"specifiers":[]`)) {
+		t.Errorf("expected empty specifiers array, got %s", b)
+	}
+
+	if errs := end.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	end.Specifiers = []ExportSpecifier{
+		ExportSpecifier{
+			Local:    Identifier{Name: "bar"},
+			Exported: Identifier{Name: "baz"},
+		},
+	}
+	end.Source = StringLiteral{Value: "mod"}
+	if !hasError(ErrNotAllowed, end.Errors()...) {
+		t.Error("expected ErrNotAllowed for Specifiers with Declaration")
+	}
+	end.Declaration = nil
+	testRoundtripJSON(t, end, new(ExportNamedDeclaration))
+	if errs := end.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	end.ExportKind = TypeBinding
+	end.Specifiers[0].ExportKind = TypeBinding
+	testRoundtripJSON(t, end, new(ExportNamedDeclaration))
+	if errs := end.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	end.ExportKind = TypeofBinding
+	if !hasError(ErrNotAllowed, end.Errors()...) {
+		t.Error("expected ErrNotAllowed for typeof ExportKind")
+	}
+	end.ExportKind = "foo"
+	if !hasError(ErrWrongValue, end.Errors()...) {
+		t.Error("expected ErrWrongValue for invalid ExportKind")
+	}
+	end.Specifiers[0] = ExportSpecifier{}
+	if !hasError(ErrMissingNode, end.Errors()...) {
+		t.Error("expected ErrMissingNode for zero Specifier")
+	}
+}
+
+func TestExportSpecifier(t *testing.T) {
+	var es ExportSpecifier
+	if !es.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	es.Local = Identifier{Name: "foo"}
+	es.Exported = Identifier{Name: "bar"}
+	if es.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if es.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", es.MinVersion())
+	}
+
+	var v mockVisitor
+	es.Walk(&v)
+	v.expect(t, es, es.Local, nil, es.Exported, nil, nil)
+
+	testRoundtripJSON(t, es, new(ExportSpecifier))
+
+	if errs := es.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	es.Exported = Identifier{}
+	if !hasError(ErrMissingNode, es.Errors()...) {
+		t.Error("expected ErrMissingNode for zero Exported")
+	}
+}
+
+func TestExportDefaultDeclaration(t *testing.T) {
+	var edd ExportDefaultDeclaration
+	if !edd.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	edd.Declaration = FunctionDeclaration{
+		Params: []Pattern{Identifier{Name: "foo"}},
+	}
+	if edd.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if edd.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", edd.MinVersion())
+	}
+
+	var v mockVisitor
+	edd.Walk(&v)
+	v.expect(t, edd,
+		edd.Declaration,
+		edd.Declaration.(FunctionDeclaration).Params[0], nil,
+		edd.Declaration.(FunctionDeclaration).Body, nil, nil, nil)
+
+	testRoundtripJSON(t, edd, new(ExportDefaultDeclaration))
+
+	if errs := Validate(edd); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !hasError(ErrMissingNode, Validate(edd.Declaration)...) {
+		t.Error("expected ErrMissingNode for anonymous function outside default export")
+	}
+	edd.Declaration = ClassDeclaration{SuperClass: Identifier{Name: "Bar"}}
+	testRoundtripJSON(t, edd, new(ExportDefaultDeclaration))
+	if errs := Validate(edd); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	edd.Declaration = BinaryExpression{
+		Operator: Add,
+		Left:     NumberLiteral{Value: 1},
+		Right:    NumberLiteral{Value: 2},
+	}
+	testRoundtripJSON(t, edd, new(ExportDefaultDeclaration))
+	if errs := Validate(edd); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	edd.Declaration = VariableDeclaration{Kind: Var}
+	if !hasError(ErrNotAllowed, edd.Errors()...) {
+		t.Error("expected ErrNotAllowed for VariableDeclaration")
+	}
+	edd.Declaration = nil
+	if !hasError(ErrMissingNode, edd.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Declaration")
+	}
+}
+
+func TestExportAllDeclaration(t *testing.T) {
+	var ead ExportAllDeclaration
+	if !ead.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	ead.Source = StringLiteral{Value: "mod"}
+	if ead.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if ead.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", ead.MinVersion())
+	}
+
+	var v mockVisitor
+	ead.Walk(&v)
+	v.expect(t, ead, ead.Source, nil, nil)
+
+	testRoundtripJSON(t, ead, new(ExportAllDeclaration))
+
+	ead.Exported = Identifier{Name: "foo"}
+	if ead.MinVersion() != ES2020 {
+		t.Errorf("expected ES2020, got %s", ead.MinVersion())
+	}
+	testRoundtripJSON(t, ead, new(ExportAllDeclaration))
+	ead.ExportKind = TypeBinding
+	testRoundtripJSON(t, ead, new(ExportAllDeclaration))
+
+	if errs := ead.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ead.Source = nil
+	if !hasError(ErrMissingNode, ead.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Source")
+	}
+}
+
+func TestModuleProgram(t *testing.T) {
+	// import * as foo from "foo";
+	// export function bar() {}
+	// export {foo as baz};
+	// export * as qux from "qux";
+	b := []byte(`{
+		"type": "Program",
+		"sourceType": "module",
+		"body": [
+			{
+				"type": "ImportDeclaration",
+				"specifiers": [
+					{
+						"type": "ImportNamespaceSpecifier",
+						"local": {"type": "Identifier", "name": "foo"}
+					}
+				],
+				"source": {"type": "Literal", "value": "foo"}
+			},
+			{
+				"type": "ExportNamedDeclaration",
+				"declaration": {
+					"type": "FunctionDeclaration",
+					"id": {"type": "Identifier", "name": "bar"},
+					"params": [],
+					"body": {"type": "BlockStatement", "body": []}
+				},
+				"specifiers": [],
+				"source": null
+			},
+			{
+				"type": "ExportNamedDeclaration",
+				"declaration": null,
+				"specifiers": [
+					{
+						"type": "ExportSpecifier",
+						"local": {"type": "Identifier", "name": "foo"},
+						"exported": {"type": "Identifier", "name": "baz"}
+					}
+				],
+				"source": null
+			},
+			{
+				"type": "ExportAllDeclaration",
+				"exported": {"type": "Identifier", "name": "qux"},
+				"source": {"type": "Literal", "value": "qux"}
+			}
+		]
+	}`)
+	var p Program
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.SourceType != Module {
+		t.Errorf("expected Module, got %#v", p.SourceType)
+	}
+	if p.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", p.MinVersion())
+	}
+	if len(p.Body) != 4 {
+		t.Fatalf("expected 4 statements, got %d", len(p.Body))
+	}
+	if errs := Validate(p); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	testRoundtripJSON(t, p, new(Program))
+
+	p.Body = append(p.Body, ExportNamedDeclaration{
+		Declaration: ClassDeclaration{ID: Identifier{Name: "baz"}},
+	})
+	if !hasError(ErrNotAllowed, p.Errors()...) {
+		t.Error("expected ErrNotAllowed for duplicate export")
+	}
+	p.Body = p.Body[:4]
+	p.SourceType = Script
+	if !hasError(ErrNotAllowed, p.Errors()...) {
+		t.Error("expected ErrNotAllowed for ModuleDeclaration in script")
+	}
+	p.SourceType = "not valid"
+	if !hasError(ErrWrongValue, p.Errors()...) {
+		t.Error("expected ErrWrongValue for invalid SourceType")
+	}
+
+	fb := FunctionBody{Body: []DirectiveOrStatement{p.Body[0]}}
+	if !hasError(ErrNotAllowed, fb.Errors()...) {
+		t.Error("expected ErrNotAllowed for ModuleDeclaration in function body")
+	}
+}
+
+func TestImportExpression(t *testing.T) {
+	var ie ImportExpression
+	if !ie.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	ie.Source = StringLiteral{Value: "foo"}
+	if ie.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if ie.MinVersion() != ES2020 {
+		t.Errorf("expected ES2020, got %s", ie.MinVersion())
+	}
+
+	var v mockVisitor
+	ie.Walk(&v)
+	v.expect(t, ie, ie.Source, nil, nil)
+
+	testRoundtripJSON(t, ie, new(ImportExpression))
+
+	if errs := ie.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	ie.Options = ObjectExpression{}
+	v = mockVisitor{}
+	ie.Walk(&v)
+	v.expect(t, ie, ie.Source, nil, ie.Options, nil, nil)
+	testRoundtripJSON(t, ie, new(ImportExpression))
+
+	ie.Source = nil
+	if !hasError(ErrMissingNode, ie.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Source")
+	}
+}
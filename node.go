@@ -48,8 +48,9 @@ type Node interface {
 	Errors() []error
 }
 
-// nodeToMap returns a map containing a Node's Type and Loc.  Loc is omitted
-// if it is the zero value.
+// nodeToMap returns a map containing a Node's Type and Loc, its start and end
+// offsets, and any comments attached to the Node.  Loc is omitted if it has no
+// position information, and the offsets are omitted if Loc.Range is zero.
 //
 // Maps are used instead of structs when marshaling to JSON, as encoding/json
 // doesn't have a good way to omit zero values (omitempty works with nil
@@ -57,12 +58,56 @@ type Node interface {
 func nodeToMap(n Node) map[string]interface{} {
 	m := make(map[string]interface{}, 10)
 	m["type"] = n.Type()
-	if !n.Location().IsZero() {
-		m["loc"] = n.Location()
+	loc := n.Location()
+	if !loc.isPositionZero() {
+		m["loc"] = loc
+	}
+	if !loc.Range.IsZero() {
+		m["start"] = loc.Range.Start
+		m["end"] = loc.Range.End
+	}
+	if loc.Comments != nil {
+		if len(loc.Comments.LeadingComments) > 0 {
+			m["leadingComments"] = loc.Comments.LeadingComments
+		}
+		if len(loc.Comments.TrailingComments) > 0 {
+			m["trailingComments"] = loc.Comments.TrailingComments
+		}
 	}
 	return m
 }
 
+// NodeFields decodes the JSON properties common to every Node: its loc, its
+// offsets (either start and end, or range), and any attached comments.  It is
+// embedded in the anonymous struct each UnmarshalJSON method decodes into, and
+// is exported for use by extensions.
+type NodeFields struct {
+	Loc              SourceLocation `json:"loc"`
+	Start            *int           `json:"start"`
+	End              *int           `json:"end"`
+	Range            *[2]int        `json:"range"`
+	LeadingComments  []Comment      `json:"leadingComments"`
+	TrailingComments []Comment      `json:"trailingComments"`
+}
+
+// Location returns the decoded SourceLocation, including offsets and attached
+// comments.
+func (nf NodeFields) Location() SourceLocation {
+	loc := nf.Loc
+	if nf.Range != nil {
+		loc.Range = Range{Start: nf.Range[0], End: nf.Range[1]}
+	} else if nf.Start != nil && nf.End != nil {
+		loc.Range = Range{Start: *nf.Start, End: *nf.End}
+	}
+	if len(nf.LeadingComments) > 0 || len(nf.TrailingComments) > 0 {
+		loc.Comments = &NodeComments{
+			LeadingComments:  nonEmptyComments(nf.LeadingComments),
+			TrailingComments: nonEmptyComments(nf.TrailingComments),
+		}
+	}
+	return loc
+}
+
 // SourceLocation contains the start and end positions of a Node.
 type SourceLocation struct {
 	// Source indicates the origin of the parsed source region, typically its
@@ -76,15 +121,31 @@ type SourceLocation struct {
 	// End is the position of the first character after the parsed source
 	// region.
 	End Position
+
+	// Range contains the offsets of the parsed source region, or is zero if
+	// they are unknown.
+	Range Range `json:"-"`
+
+	// Comments are the comments attached to the Node, e.g. by
+	// AttachComments, or nil.  In JSON, they are properties of the Node
+	// rather than of its loc.  A pointer is used so that SourceLocation (and
+	// most Nodes) remain comparable.
+	Comments *NodeComments `json:"-"`
 }
 
 // IsZero indicates sl contains no information about the source location.
 func (sl SourceLocation) IsZero() bool {
+	return sl.isPositionZero() && sl.Range.IsZero() && sl.Comments == nil
+}
+
+// isPositionZero indicates sl contains no source or position information,
+// regardless of attached comments.
+func (sl SourceLocation) isPositionZero() bool {
 	return sl.Source == "" && sl.Start.IsZero() && sl.End.IsZero()
 }
 
 func (sl SourceLocation) MarshalJSON() ([]byte, error) {
-	if sl.IsZero() {
+	if sl.isPositionZero() {
 		return json.Marshal(nil)
 	}
 	x := map[string]interface{}{
@@ -98,6 +159,27 @@ func (sl SourceLocation) MarshalJSON() ([]byte, error) {
 	return json.Marshal(x)
 }
 
+// Range contains the start and end offsets of a parsed source region, as
+// reported by Acorn and Espree's start, end, and range properties.  Offsets
+// count characters (UTF-16 code units, for Acorn and Espree) from the beginning
+// of the source.
+//
+// The zero Range indicates the offsets are unknown.  This is ambiguous only for
+// a region spanning no characters at the beginning of the source, such as an
+// empty Program.
+type Range struct {
+	// Start is the offset of the first character of the region.
+	Start int
+
+	// End is the offset of the first character after the region.
+	End int
+}
+
+// IsZero indicates r contains no information about the source offsets.
+func (r Range) IsZero() bool {
+	return r.Start == 0 && r.End == 0
+}
+
 // Position contains the line (1-indexed) and column (0-indexed) of a position
 // in the parsed source region.
 type Position struct {
@@ -127,6 +209,11 @@ var (
 	ES2019 Version = 10
 	ES2020 Version = 11
 	ES2021 Version = 12
+	ES2022 Version = 13
+
+	// Proposal is greater than any ratified Version.  It is reported by Nodes
+	// whose syntax is not yet part of the standard, such as Decorator.
+	Proposal Version = 1<<31 - 1
 )
 
 func (v Version) String() string {
@@ -147,6 +234,10 @@ func (v Version) String() string {
 		return "ES2020"
 	case ES2021:
 		return "ES2021"
+	case ES2022:
+		return "ES2022"
+	case Proposal:
+		return "Proposal"
 	}
 	return fmt.Sprintf("%d", int(v))
 }
@@ -2,7 +2,6 @@ package estree
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 )
 
@@ -11,18 +10,51 @@ type Pattern interface {
 	isPattern()
 	isVariableDeclarationOrPattern()
 	isPatternOrExpression()
+	isPatternOrArrayHole()
 }
 
-func unmarshalPattern(m json.RawMessage) (Pattern, bool, error) {
-	var i Identifier
-	err := json.Unmarshal(m, &i)
-	if err == nil {
-		return i, true, nil
+func unmarshalPattern(m json.RawMessage) (p Pattern, match bool, err error) {
+	if isNullOrEmptyRawMessage(m) {
+		return nil, true, nil
+	}
+	var x struct {
+		Type string `json:"type"`
 	}
-	if errors.Is(err, ErrWrongType) {
-		return nil, false, fmt.Errorf("%w Pattern, got %v", ErrWrongType, string(m))
+	if err = json.Unmarshal(m, &x); err != nil {
+		match = true
+	} else {
+		switch x.Type {
+		case Identifier{}.Type():
+			var i Identifier
+			err, match, p = json.Unmarshal(m, &i), true, i
+		case MemberExpression{}.Type():
+			var me MemberExpression
+			err, match, p = json.Unmarshal(m, &me), true, me
+		case ObjectPattern{}.Type():
+			var op ObjectPattern
+			err, match, p = json.Unmarshal(m, &op), true, op
+		case ArrayPattern{}.Type():
+			var ap ArrayPattern
+			err, match, p = json.Unmarshal(m, &ap), true, ap
+		case AssignmentPattern{}.Type():
+			var ap AssignmentPattern
+			err, match, p = json.Unmarshal(m, &ap), true, ap
+		case RestElement{}.Type():
+			var re RestElement
+			err, match, p = json.Unmarshal(m, &re), true, re
+		default:
+			if unmarshal, ok := extensionPatterns[x.Type]; ok {
+				p, err = unmarshal(m)
+				match = true
+			} else {
+				err = fmt.Errorf("%w Pattern, got %v", ErrWrongType, string(m))
+			}
+		}
+		if err != nil {
+			p = nil // don't return incomplete objects
+		}
 	}
-	return nil, true, err
+	return
 }
 
 type PatternOrExpression interface {
@@ -49,3 +81,503 @@ func (basePattern) MinVersion() Version             { return ES5 }
 func (basePattern) isPattern()                      {}
 func (basePattern) isVariableDeclarationOrPattern() {}
 func (basePattern) isPatternOrExpression()          {}
+func (basePattern) isPatternOrArrayHole()           {}
+
+// MemberExpression can be a Pattern when it is the target of an assignment,
+// e.g. [a.b] = c.
+func (MemberExpression) isPattern()                      {}
+func (MemberExpression) isVariableDeclarationOrPattern() {}
+func (MemberExpression) isPatternOrArrayHole()           {}
+
+// requireRestLast appends a SyntaxError for each RestElement in a nodeSlice
+// which is not the final element.
+func (c *nodeChecker) requireRestLast(ns nodeSlice, what string) {
+	for i := 0; i < ns.Len-1; i++ {
+		if _, ok := ns.Index(i).(RestElement); ok {
+			c.appendf("rest element before end of %s %w", what, ErrNotAllowed)
+		}
+	}
+}
+
+// boundNames returns the names of the Identifiers bound by a Pattern, in
+// source order.  MemberExpressions do not bind any names.
+func boundNames(p Pattern) []string {
+	switch p := p.(type) {
+	case Identifier:
+		return []string{p.Name}
+	case ObjectPattern:
+		var names []string
+		for _, prop := range p.Properties {
+			switch prop := prop.(type) {
+			case AssignmentProperty:
+				names = append(names, boundNames(prop.Value)...)
+			case RestElement:
+				names = append(names, boundNames(prop)...)
+			}
+		}
+		return names
+	case ArrayPattern:
+		var names []string
+		for _, e := range p.Elements {
+			if e, ok := e.(Pattern); ok {
+				names = append(names, boundNames(e)...)
+			}
+		}
+		return names
+	case AssignmentPattern:
+		return boundNames(p.Left)
+	case RestElement:
+		return boundNames(p.Argument)
+	}
+	return nil
+}
+
+// AssignmentPropertyOrRestElement is a member of an ObjectPattern.
+type AssignmentPropertyOrRestElement interface {
+	Node
+	isAssignmentPropertyOrRestElement()
+}
+
+func unmarshalAssignmentPropertyOrRestElement(m json.RawMessage) (AssignmentPropertyOrRestElement, error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(m, &x)
+	if err == nil && x.Type == (RestElement{}).Type() {
+		var re RestElement
+		if err = json.Unmarshal(m, &re); err == nil {
+			return re, nil
+		}
+	} else if err == nil {
+		var ap AssignmentProperty
+		if err = json.Unmarshal(m, &ap); err == nil {
+			return ap, nil
+		}
+	}
+	return nil, err
+}
+
+// ObjectPattern is a destructuring pattern for objects, e.g. {a, b: c}.
+type ObjectPattern struct {
+	basePattern
+	Loc            SourceLocation
+	Properties     []AssignmentPropertyOrRestElement
+	TypeAnnotation TypeAnnotation // or nil
+}
+
+func (ObjectPattern) Type() string                { return "ObjectPattern" }
+func (op ObjectPattern) Location() SourceLocation { return op.Loc }
+func (ObjectPattern) IsZero() bool                { return false }
+
+func (op ObjectPattern) MinVersion() Version {
+	for _, p := range op.Properties {
+		if _, ok := p.(RestElement); ok {
+			return ES2018
+		}
+	}
+	return ES2015
+}
+
+func (op ObjectPattern) Walk(v Visitor) {
+	if v = v.Visit(op); v != nil {
+		defer v.Visit(nil)
+		for _, p := range op.Properties {
+			if p != nil {
+				p.Walk(v)
+			}
+		}
+		if op.TypeAnnotation != nil {
+			op.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (op ObjectPattern) Errors() []error {
+	c := nodeChecker{Node: op}
+	ns := nodeSlice{
+		Index: func(i int) Node { return op.Properties[i] },
+		Len:   len(op.Properties),
+	}
+	c.requireEach(ns, "object pattern property")
+	c.requireRestLast(ns, "object pattern")
+	c.optional(op.TypeAnnotation)
+	return c.errors()
+}
+
+func (op ObjectPattern) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(op)
+	x["properties"] = op.Properties
+	if op.TypeAnnotation != nil {
+		x["typeAnnotation"] = op.TypeAnnotation
+	}
+	return json.Marshal(x)
+}
+
+func (op *ObjectPattern) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type       string            `json:"type"`
+		Properties []json.RawMessage `json:"properties"`
+
+		TypeAnnotation json.RawMessage `json:"typeAnnotation"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != op.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, op.Type(), x.Type)
+	}
+	if err == nil {
+		op.Loc = x.Location()
+		op.TypeAnnotation, err = unmarshalTypeAnnotation(x.TypeAnnotation)
+		if len(x.Properties) == 0 {
+			op.Properties = nil
+		} else {
+			op.Properties = make([]AssignmentPropertyOrRestElement, len(x.Properties))
+			for i := range x.Properties {
+				var err2 error
+				op.Properties[i], err2 = unmarshalAssignmentPropertyOrRestElement(x.Properties[i])
+				if err == nil && err2 != nil {
+					err = err2
+				}
+			}
+		}
+	}
+	return err
+}
+
+// AssignmentProperty is a property of an ObjectPattern.  It is represented
+// as a Property in JSON, with Kind "init".
+type AssignmentProperty struct {
+	Loc   SourceLocation
+	Key   Expression
+	Value Pattern
+
+	// Computed indicates Key is an arbitrary Expression ({[key]: value}).  If
+	// Computed is false, Key is an Identifier or Literal.
+	Computed bool
+
+	// Shorthand indicates the property was written as {key} or {key = init},
+	// in which case Value is the Identifier Key, or an AssignmentPattern
+	// with Key on the left.
+	Shorthand bool
+}
+
+func (AssignmentProperty) Type() string                       { return Property{}.Type() }
+func (ap AssignmentProperty) Location() SourceLocation        { return ap.Loc }
+func (AssignmentProperty) MinVersion() Version                { return ES2015 }
+func (AssignmentProperty) isAssignmentPropertyOrRestElement() {}
+
+func (ap AssignmentProperty) IsZero() bool {
+	return ap.Loc.IsZero() &&
+		(ap.Key == nil || ap.Key.IsZero()) &&
+		(ap.Value == nil || ap.Value.IsZero()) &&
+		!ap.Computed &&
+		!ap.Shorthand
+}
+
+func (ap AssignmentProperty) Walk(v Visitor) {
+	if v = v.Visit(ap); v != nil {
+		defer v.Visit(nil)
+		if ap.Key != nil {
+			ap.Key.Walk(v)
+		}
+		if ap.Value != nil {
+			ap.Value.Walk(v)
+		}
+	}
+}
+
+func (ap AssignmentProperty) Errors() []error {
+	c := nodeChecker{Node: ap}
+	c.require(ap.Key, "property name")
+	if _, ok := ap.Key.(LiteralOrIdentifier); ap.Key != nil && !ok && !ap.Computed {
+		c.appendf("%w property name %s without Computed", ErrWrongValue, ap.Key.Type())
+	}
+	c.require(ap.Value, "property pattern")
+	if ap.Shorthand {
+		key, _ := ap.Key.(Identifier)
+		value, _ := ap.Value.(Identifier)
+		if assign, ok := ap.Value.(AssignmentPattern); ok {
+			value, _ = assign.Left.(Identifier)
+		}
+		if key.Name == "" || key.Name != value.Name {
+			c.appendf("%w shorthand property with different key and value", ErrWrongValue)
+		}
+	}
+	return c.errors()
+}
+
+func (ap AssignmentProperty) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(ap)
+	x["key"] = ap.Key
+	x["value"] = ap.Value
+	x["kind"] = Init
+	x["method"] = false
+	x["computed"] = ap.Computed
+	x["shorthand"] = ap.Shorthand
+	return json.Marshal(x)
+}
+
+func (ap *AssignmentProperty) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type      string          `json:"type"`
+		Key       json.RawMessage `json:"key"`
+		Value     json.RawMessage `json:"value"`
+		Kind      PropertyKind    `json:"kind"`
+		Method    bool            `json:"method"`
+		Computed  bool            `json:"computed"`
+		Shorthand bool            `json:"shorthand"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ap.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ap.Type(), x.Type)
+	}
+	if err == nil && ((x.Kind != "" && x.Kind != Init) || x.Method) {
+		err = fmt.Errorf("%w AssignmentProperty.Kind %q", ErrWrongValue, x.Kind)
+	}
+	if err == nil {
+		ap.Loc, ap.Computed, ap.Shorthand = x.Location(), x.Computed, x.Shorthand
+		ap.Key, _, err = unmarshalExpression(x.Key)
+		var err2 error
+		if ap.Value, _, err2 = unmarshalPattern(x.Value); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// PatternOrArrayHole is an element of an ArrayPattern.
+type PatternOrArrayHole interface {
+	Node
+	isPatternOrArrayHole()
+}
+
+func (ArrayHole) isPatternOrArrayHole() {}
+
+// ArrayPattern is a destructuring pattern for arrays, e.g. [a, , b].
+type ArrayPattern struct {
+	basePattern
+	Loc            SourceLocation
+	Elements       []PatternOrArrayHole
+	TypeAnnotation TypeAnnotation // or nil
+}
+
+func (ArrayPattern) Type() string                { return "ArrayPattern" }
+func (ap ArrayPattern) Location() SourceLocation { return ap.Loc }
+func (ArrayPattern) MinVersion() Version         { return ES2015 }
+func (ArrayPattern) IsZero() bool                { return false }
+
+func (ap ArrayPattern) Walk(v Visitor) {
+	if v = v.Visit(ap); v != nil {
+		defer v.Visit(nil)
+		for _, e := range ap.Elements {
+			if e != nil {
+				e.Walk(v)
+			}
+		}
+		if ap.TypeAnnotation != nil {
+			ap.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (ap ArrayPattern) Errors() []error {
+	c := nodeChecker{Node: ap}
+	ns := nodeSlice{
+		Index: func(i int) Node { return ap.Elements[i] },
+		Len:   len(ap.Elements),
+	}
+	c.requireEach(ns, "array pattern element")
+	c.requireRestLast(ns, "array pattern")
+	c.optional(ap.TypeAnnotation)
+	return c.errors()
+}
+
+func (ap ArrayPattern) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(ap)
+	x["elements"] = ap.Elements
+	if ap.TypeAnnotation != nil {
+		x["typeAnnotation"] = ap.TypeAnnotation
+	}
+	return json.Marshal(x)
+}
+
+func (ap *ArrayPattern) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type     string            `json:"type"`
+		Elements []json.RawMessage `json:"elements"`
+
+		TypeAnnotation json.RawMessage `json:"typeAnnotation"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ap.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ap.Type(), x.Type)
+	}
+	if err == nil {
+		ap.Loc = x.Location()
+		ap.TypeAnnotation, err = unmarshalTypeAnnotation(x.TypeAnnotation)
+		if len(x.Elements) == 0 {
+			ap.Elements = nil
+		} else {
+			ap.Elements = make([]PatternOrArrayHole, len(x.Elements))
+			for i := range x.Elements {
+				if isNullOrEmptyRawMessage(x.Elements[i]) {
+					ap.Elements[i] = ArrayHole{}
+				} else {
+					var err2 error
+					ap.Elements[i], _, err2 = unmarshalPattern(x.Elements[i])
+					if err == nil && err2 != nil {
+						err = err2
+					}
+				}
+			}
+		}
+	}
+	return err
+}
+
+// AssignmentPattern is a Pattern with a default value, e.g. the parameter
+// a = 1.
+type AssignmentPattern struct {
+	basePattern
+	Loc   SourceLocation
+	Left  Pattern
+	Right Expression
+}
+
+func (AssignmentPattern) Type() string                { return "AssignmentPattern" }
+func (ap AssignmentPattern) Location() SourceLocation { return ap.Loc }
+func (AssignmentPattern) MinVersion() Version         { return ES2015 }
+
+func (ap AssignmentPattern) IsZero() bool {
+	return ap.Loc.IsZero() &&
+		(ap.Left == nil || ap.Left.IsZero()) &&
+		(ap.Right == nil || ap.Right.IsZero())
+}
+
+func (ap AssignmentPattern) Walk(v Visitor) {
+	if v = v.Visit(ap); v != nil {
+		defer v.Visit(nil)
+		if ap.Left != nil {
+			ap.Left.Walk(v)
+		}
+		if ap.Right != nil {
+			ap.Right.Walk(v)
+		}
+	}
+}
+
+func (ap AssignmentPattern) Errors() []error {
+	c := nodeChecker{Node: ap}
+	c.require(ap.Left, "left-hand pattern")
+	c.require(ap.Right, "default value")
+	return c.errors()
+}
+
+func (ap AssignmentPattern) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(ap)
+	x["left"] = ap.Left
+	x["right"] = ap.Right
+	return json.Marshal(x)
+}
+
+func (ap *AssignmentPattern) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type  string          `json:"type"`
+		Left  json.RawMessage `json:"left"`
+		Right json.RawMessage `json:"right"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ap.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ap.Type(), x.Type)
+	}
+	if err == nil {
+		ap.Loc = x.Location()
+		ap.Left, _, err = unmarshalPattern(x.Left)
+		var err2 error
+		if ap.Right, _, err2 = unmarshalExpression(x.Right); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// RestElement collects the remaining elements of an ArrayPattern, properties
+// of an ObjectPattern, or function parameters, e.g. ...rest.
+type RestElement struct {
+	basePattern
+	Loc            SourceLocation
+	Argument       Pattern
+	TypeAnnotation TypeAnnotation // or nil
+}
+
+func (RestElement) Type() string                       { return "RestElement" }
+func (re RestElement) Location() SourceLocation        { return re.Loc }
+func (RestElement) MinVersion() Version                { return ES2015 }
+func (RestElement) isAssignmentPropertyOrRestElement() {}
+
+func (re RestElement) IsZero() bool {
+	return re.Loc.IsZero() && (re.Argument == nil || re.Argument.IsZero()) &&
+		re.TypeAnnotation == nil
+}
+
+func (re RestElement) Walk(v Visitor) {
+	if v = v.Visit(re); v != nil {
+		defer v.Visit(nil)
+		if re.Argument != nil {
+			re.Argument.Walk(v)
+		}
+		if re.TypeAnnotation != nil {
+			re.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (re RestElement) Errors() []error {
+	c := nodeChecker{Node: re}
+	c.require(re.Argument, "rest argument")
+	if _, ok := re.Argument.(AssignmentPattern); ok {
+		c.appendf("default value for rest element %w", ErrNotAllowed)
+	}
+	c.optional(re.TypeAnnotation)
+	return c.errors()
+}
+
+func (re RestElement) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(re)
+	x["argument"] = re.Argument
+	if re.TypeAnnotation != nil {
+		x["typeAnnotation"] = re.TypeAnnotation
+	}
+	return json.Marshal(x)
+}
+
+func (re *RestElement) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type     string          `json:"type"`
+		Argument json.RawMessage `json:"argument"`
+
+		TypeAnnotation json.RawMessage `json:"typeAnnotation"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != re.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, re.Type(), x.Type)
+	}
+	if err == nil {
+		re.Loc = x.Location()
+		re.Argument, _, err = unmarshalPattern(x.Argument)
+		var err2 error
+		if re.TypeAnnotation, err2 = unmarshalTypeAnnotation(x.TypeAnnotation); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
@@ -0,0 +1,278 @@
+package estree
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestObjectPattern(t *testing.T) {
+	var op ObjectPattern
+	if op.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	op.Properties = []AssignmentPropertyOrRestElement{
+		AssignmentProperty{
+			Key:       Identifier{Name: "foo"},
+			Value:     Identifier{Name: "foo"},
+			Shorthand: true,
+		},
+	}
+	if op.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", op.MinVersion())
+	}
+	op.Properties = append(op.Properties, RestElement{
+		Argument: Identifier{Name: "bar"},
+	})
+	if op.MinVersion() != ES2018 {
+		t.Errorf("expected ES2018, got %s", op.MinVersion())
+	}
+
+	var v mockVisitor
+	op.Walk(&v)
+	v.expect(t, op,
+		op.Properties[0],
+		op.Properties[0].(AssignmentProperty).Key, nil,
+		op.Properties[0].(AssignmentProperty).Value, nil, nil,
+		op.Properties[1],
+		op.Properties[1].(RestElement).Argument, nil, nil, nil)
+
+	testRoundtripJSON(t, op, new(ObjectPattern))
+
+	if errs := op.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	op.Properties[0], op.Properties[1] = op.Properties[1], op.Properties[0]
+	if !hasError(ErrNotAllowed, op.Errors()...) {
+		t.Error("expected ErrNotAllowed for RestElement before end")
+	}
+	op.Properties[0] = nil
+	if !hasError(ErrMissingNode, op.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Property")
+	}
+}
+
+func TestAssignmentProperty(t *testing.T) {
+	var ap AssignmentProperty
+	if !ap.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	ap.Key = Identifier{Name: "foo"}
+	ap.Value = AssignmentPattern{
+		Left:  Identifier{Name: "foo"},
+		Right: NumberLiteral{Value: 1},
+	}
+	ap.Shorthand = true
+	if ap.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if ap.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", ap.MinVersion())
+	}
+
+	var v mockVisitor
+	ap.Walk(&v)
+	v.expect(t, ap,
+		ap.Key, nil,
+		ap.Value,
+		ap.Value.(AssignmentPattern).Left, nil,
+		ap.Value.(AssignmentPattern).Right, nil, nil, nil)
+
+	testRoundtripJSON(t, ap, new(AssignmentProperty))
+
+	if errs := ap.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ap.Value = Identifier{Name: "bar"}
+	if !hasError(ErrWrongValue, ap.Errors()...) {
+		t.Error("expected ErrWrongValue for Shorthand with different Key and Value")
+	}
+	ap.Shorthand = false
+	ap.Key = ThisExpression{}
+	if !hasError(ErrWrongValue, ap.Errors()...) {
+		t.Error("expected ErrWrongValue for Expression Key without Computed")
+	}
+	ap.Computed = true
+	if errs := ap.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ap.Value = nil
+	if !hasError(ErrMissingNode, ap.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Value")
+	}
+}
+
+func TestArrayPattern(t *testing.T) {
+	var ap ArrayPattern
+	if ap.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	ap.Elements = []PatternOrArrayHole{
+		Identifier{Name: "foo"},
+		ArrayHole{},
+		RestElement{
+			Argument: Identifier{Name: "bar"},
+		},
+	}
+	if ap.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", ap.MinVersion())
+	}
+
+	var v mockVisitor
+	ap.Walk(&v)
+	v.expect(t, ap,
+		ap.Elements[0], nil,
+		ap.Elements[1], nil,
+		ap.Elements[2],
+		ap.Elements[2].(RestElement).Argument, nil, nil, nil)
+
+	testRoundtripJSON(t, ap, new(ArrayPattern))
+
+	if errs := ap.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ap.Elements[0], ap.Elements[2] = ap.Elements[2], ap.Elements[0]
+	if !hasError(ErrNotAllowed, ap.Errors()...) {
+		t.Error("expected ErrNotAllowed for RestElement before end")
+	}
+	ap.Elements[0] = Identifier{}
+	if !hasError(ErrMissingNode, ap.Errors()...) {
+		t.Error("expected ErrMissingNode for zero Element")
+	}
+}
+
+func TestAssignmentPattern(t *testing.T) {
+	var ap AssignmentPattern
+	if !ap.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	ap.Left = Identifier{Name: "foo"}
+	ap.Right = StringLiteral{Value: "bar"}
+	if ap.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if ap.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", ap.MinVersion())
+	}
+
+	var v mockVisitor
+	ap.Walk(&v)
+	v.expect(t, ap,
+		ap.Left, nil,
+		ap.Right, nil, nil)
+
+	testRoundtripJSON(t, ap, new(AssignmentPattern))
+
+	if errs := ap.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ap.Left = nil
+	if !hasError(ErrMissingNode, ap.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Left")
+	}
+	ap.Left = Identifier{Name: "foo"}
+	ap.Right = nil
+	if !hasError(ErrMissingNode, ap.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Right")
+	}
+}
+
+func TestRestElement(t *testing.T) {
+	var re RestElement
+	if !re.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	re.Argument = ArrayPattern{}
+	if re.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if re.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", re.MinVersion())
+	}
+
+	var v mockVisitor
+	re.Walk(&v)
+	v.expect(t, re, re.Argument, nil, nil)
+
+	testRoundtripJSON(t, re, new(RestElement))
+
+	if errs := re.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	re.Argument = AssignmentPattern{
+		Left:  Identifier{Name: "foo"},
+		Right: NullLiteral{},
+	}
+	if !hasError(ErrNotAllowed, re.Errors()...) {
+		t.Error("expected ErrNotAllowed for AssignmentPattern Argument")
+	}
+	re.Argument = nil
+	if !hasError(ErrMissingNode, re.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Argument")
+	}
+}
+
+func TestUnmarshalDestructuredParams(t *testing.T) {
+	// function f({a, b: [c]} = {}, ...d) {}
+	b := []byte(`{
+		"type": "FunctionDeclaration",
+		"id": {"type": "Identifier", "name": "f"},
+		"params": [
+			{
+				"type": "AssignmentPattern",
+				"left": {
+					"type": "ObjectPattern",
+					"properties": [
+						{
+							"type": "Property",
+							"key": {"type": "Identifier", "name": "a"},
+							"value": {"type": "Identifier", "name": "a"},
+							"kind": "init",
+							"method": false,
+							"shorthand": true,
+							"computed": false
+						},
+						{
+							"type": "Property",
+							"key": {"type": "Identifier", "name": "b"},
+							"value": {
+								"type": "ArrayPattern",
+								"elements": [{"type": "Identifier", "name": "c"}]
+							},
+							"kind": "init",
+							"method": false,
+							"shorthand": false,
+							"computed": false
+						}
+					]
+				},
+				"right": {"type": "ObjectExpression", "properties": []}
+			},
+			{
+				"type": "RestElement",
+				"argument": {"type": "Identifier", "name": "d"}
+			}
+		],
+		"body": {"type": "BlockStatement", "body": []}
+	}`)
+	var fd FunctionDeclaration
+	if err := json.Unmarshal(b, &fd); err != nil {
+		t.Fatal(err)
+	}
+	if len(fd.Params) != 2 {
+		t.Fatalf("expected 2 params, got %d", len(fd.Params))
+	}
+	if _, ok := fd.Params[0].(AssignmentPattern).Left.(ObjectPattern); !ok {
+		t.Errorf("expected ObjectPattern, got %T", fd.Params[0].(AssignmentPattern).Left)
+	}
+	if _, ok := fd.Params[1].(RestElement); !ok {
+		t.Errorf("expected RestElement, got %T", fd.Params[1])
+	}
+	if errs := Validate(fd); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
@@ -6,37 +6,52 @@ import (
 	"fmt"
 )
 
-// DirectiveOrStatement is either a Directive or a Statement.
+// DirectiveOrStatement is either a Directive, a Statement, or (at the top
+// level of a module) a ModuleDeclaration.
 type DirectiveOrStatement interface {
 	Node
 	isDirectiveOrStatement()
 }
 
 func unmarshalDirectiveOrStatement(m json.RawMessage) (DirectiveOrStatement, error) {
-	if s, match, err := unmarshalStatement(m); match {
-		return s, err
-	}
+	// Directives are tried first, since they are also ExpressionStatements.
 	var d Directive
 	if err := json.Unmarshal([]byte(m), &d); err == nil {
 		return d, nil
 	} else if !errors.Is(err, ErrWrongType) {
 		return nil, err // don't return incomplete object
 	}
+	if s, match, err := unmarshalStatement(m); match {
+		return s, err
+	}
+	if md, match, err := unmarshalModuleDeclaration(m); match {
+		return md, err
+	}
 	return nil, fmt.Errorf("%w Directive or Statement, got %v", ErrWrongType, string(m))
 }
 
-// Program is a complete program source tree.
+// Program is a complete program source tree.  If SourceType is empty, the
+// Program is treated as a Script.
 type Program struct {
-	Loc  SourceLocation
-	Body []DirectiveOrStatement
+	Loc        SourceLocation
+	Body       []DirectiveOrStatement
+	SourceType SourceType
+
+	// Comments holds every comment in the source, in order.  Comments are
+	// not visited by Walk.  Use AttachComments to attach them to the nearest
+	// Nodes.
+	Comments []Comment
 }
 
 func (Program) Type() string               { return "Program" }
 func (p Program) Location() SourceLocation { return p.Loc }
-func (Program) MinVersion() Version        { return ES5 }
+func (p Program) MinVersion() Version      { return p.SourceType.MinVersion() }
 
 func (p Program) IsZero() bool {
-	return p.Loc.IsZero() && len(p.Body) == 0
+	return p.Loc.IsZero() &&
+		len(p.Body) == 0 &&
+		p.SourceType == "" &&
+		len(p.Comments) == 0
 }
 
 func (p Program) Walk(v Visitor) {
@@ -54,6 +69,29 @@ func (p Program) Errors() []error {
 		Index: func(i int) Node { return p.Body[i] },
 		Len:   len(p.Body),
 	}, "directive or statement")
+	c.requireEach(nodeSlice{
+		Index: func(i int) Node { return p.Comments[i] },
+		Len:   len(p.Comments),
+	}, "comment")
+	if p.SourceType != "" && !p.SourceType.IsValid() {
+		c.appendf("%w SourceType %q", ErrWrongValue, p.SourceType)
+	}
+	exported := make(map[string]bool)
+	for _, b := range p.Body {
+		md, ok := b.(ModuleDeclaration)
+		if !ok {
+			continue
+		}
+		if p.SourceType != Module {
+			c.appendf("%s in script %w", md.Type(), ErrNotAllowed)
+		}
+		for _, name := range exportedNames(md) {
+			if exported[name] {
+				c.appendf("duplicate export %q %w", name, ErrNotAllowed)
+			}
+			exported[name] = true
+		}
+	}
 	return c.errors()
 }
 
@@ -62,21 +100,36 @@ func (p Program) MarshalJSON() ([]byte, error) {
 	if len(p.Body) > 0 {
 		x["body"] = p.Body
 	}
+	if p.SourceType != "" {
+		x["sourceType"] = p.SourceType
+	}
+	if len(p.Comments) > 0 {
+		x["comments"] = p.Comments
+	}
 	return json.Marshal(x)
 }
 
 func (p *Program) UnmarshalJSON(b []byte) error {
 	var x struct {
-		Type string            `json:"type"`
-		Loc  SourceLocation    `json:"loc"`
-		Body []json.RawMessage `json:"body"`
+		NodeFields
+
+		Type       string            `json:"type"`
+		Body       []json.RawMessage `json:"body"`
+		SourceType SourceType        `json:"sourceType"`
+		Comments   []Comment         `json:"comments"`
 	}
 	err := json.Unmarshal(b, &x)
 	if err == nil && x.Type != p.Type() {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, p.Type(), x.Type)
 	}
+	if err == nil && x.SourceType != "" && !x.SourceType.IsValid() {
+		err = fmt.Errorf("%w Program.SourceType %q", ErrWrongValue, x.SourceType)
+	}
 	if err == nil {
-		p.Loc = x.Loc
+		p.Loc, p.SourceType = x.Location(), x.SourceType
+		if len(x.Comments) > 0 {
+			p.Comments = x.Comments
+		}
 		if len(x.Body) == 0 {
 			p.Body = nil
 		} else {
@@ -76,14 +76,25 @@ func unmarshalStatement(m json.RawMessage) (s Statement, match bool, err error)
 		case ForInStatement{}.Type():
 			var fis ForInStatement
 			err, match, s = json.Unmarshal(m, &fis), true, fis
+		case ForOfStatement{}.Type():
+			var fos ForOfStatement
+			err, match, s = json.Unmarshal(m, &fos), true, fos
 		case FunctionDeclaration{}.Type():
 			var fd FunctionDeclaration
 			err, match, s = json.Unmarshal(m, &fd), true, fd
+		case ClassDeclaration{}.Type():
+			var cd ClassDeclaration
+			err, match, s = json.Unmarshal(m, &cd), true, cd
 		case VariableDeclaration{}.Type():
 			var vd VariableDeclaration
 			err, match, s = json.Unmarshal(m, &vd), true, vd
 		default:
-			err = fmt.Errorf("%w Statement, got %v", ErrWrongType, string(m))
+			if unmarshal, ok := extensionStatements[x.Type]; ok {
+				s, err = unmarshal(m)
+				match = true
+			} else {
+				err = fmt.Errorf("%w Statement, got %v", ErrWrongType, string(m))
+			}
 		}
 		if err != nil {
 			s = nil // don't return incomplete objects
@@ -98,6 +109,8 @@ func (baseStatement) MinVersion() Version     { return ES5 }
 func (baseStatement) isStatement()            {}
 func (baseStatement) isDirectiveOrStatement() {}
 
+func (FunctionBody) isFunctionBodyOrExpression() {}
+
 // ExpressionStatement is a statement consisting of a single expression.
 type ExpressionStatement struct {
 	baseStatement
@@ -139,8 +152,9 @@ func (es ExpressionStatement) MarshalJSON() ([]byte, error) {
 
 func (es *ExpressionStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type       string          `json:"type"`
-		Loc        SourceLocation  `json:"loc"`
 		Expression json.RawMessage `json:"expression"`
 	}
 	err := json.Unmarshal(b, &x)
@@ -148,7 +162,7 @@ func (es *ExpressionStatement) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, es.Type(), x.Type)
 	}
 	if err == nil {
-		es.Loc = x.Loc
+		es.Loc = x.Location()
 		es.Expression, _, err = unmarshalExpression(x.Expression)
 	}
 	return err
@@ -192,8 +206,9 @@ func (bs BlockStatement) MarshalJSON() ([]byte, error) {
 
 func (bs *BlockStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type string            `json:"type"`
-		Loc  SourceLocation    `json:"loc"`
 		Body []json.RawMessage `json:"body"`
 	}
 	err := json.Unmarshal(b, &x)
@@ -201,7 +216,7 @@ func (bs *BlockStatement) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, bs.Type(), x.Type)
 	}
 	if err == nil {
-		bs.Loc = x.Loc
+		bs.Loc = x.Location()
 		if len(x.Body) == 0 {
 			bs.Body = nil
 		} else {
@@ -245,6 +260,11 @@ func (fb FunctionBody) Errors() []error {
 		Index: func(i int) Node { return fb.Body[i] },
 		Len:   len(fb.Body),
 	}, "directive or statement")
+	for _, b := range fb.Body {
+		if md, ok := b.(ModuleDeclaration); ok {
+			c.appendf("%s in function body %w", md.Type(), ErrNotAllowed)
+		}
+	}
 	// TODO: verify directives appear before statements?
 	return c.errors()
 }
@@ -257,8 +277,9 @@ func (fb FunctionBody) MarshalJSON() ([]byte, error) {
 
 func (fb *FunctionBody) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type string            `json:"type"`
-		Loc  SourceLocation    `json:"loc"`
 		Body []json.RawMessage `json:"body"`
 	}
 	err := json.Unmarshal(b, &x)
@@ -266,7 +287,7 @@ func (fb *FunctionBody) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, fb.Type(), x.Type)
 	}
 	if err == nil {
-		fb.Loc = x.Loc
+		fb.Loc = x.Location()
 		if len(x.Body) == 0 {
 			fb.Body = nil
 		} else {
@@ -306,15 +327,16 @@ func (es EmptyStatement) MarshalJSON() ([]byte, error) {
 
 func (es *EmptyStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
-		Type string         `json:"type"`
-		Loc  SourceLocation `json:"loc"`
+		NodeFields
+
+		Type string `json:"type"`
 	}
 	err := json.Unmarshal(b, &x)
 	if err == nil && x.Type != es.Type() {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, es.Type(), x.Type)
 	}
 	if err == nil {
-		es.Loc = x.Loc
+		es.Loc = x.Location()
 	}
 	return err
 }
@@ -342,15 +364,16 @@ func (ds DebuggerStatement) MarshalJSON() ([]byte, error) {
 
 func (ds *DebuggerStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
-		Type string         `json:"type"`
-		Loc  SourceLocation `json:"loc"`
+		NodeFields
+
+		Type string `json:"type"`
 	}
 	err := json.Unmarshal(b, &x)
 	if err == nil && x.Type != ds.Type() {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ds.Type(), x.Type)
 	}
 	if err == nil {
-		ds.Loc = x.Loc
+		ds.Loc = x.Location()
 	}
 	return err
 }
@@ -388,6 +411,9 @@ func (ws WithStatement) Errors() []error {
 	c := nodeChecker{Node: ws}
 	c.require(ws.Object, "with expression")
 	c.require(ws.Body, "with body")
+	if isLexicalDeclaration(ws.Body) {
+		c.appendf("lexical declaration as with body %w", ErrNotAllowed)
+	}
 	return c.errors()
 }
 
@@ -400,8 +426,9 @@ func (ws WithStatement) MarshalJSON() ([]byte, error) {
 
 func (ws *WithStatement) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type   string          `json:"type"`
-		Loc    SourceLocation  `json:"loc"`
 		Object json.RawMessage `json:"object"`
 		Body   json.RawMessage `json:"body"`
 	}
@@ -410,7 +437,7 @@ func (ws *WithStatement) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ws.Type(), x.Type)
 	}
 	if err == nil {
-		ws.Loc = x.Loc
+		ws.Loc = x.Location()
 		ws.Object, _, err = unmarshalExpression(x.Object)
 	}
 	if err == nil {
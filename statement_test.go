@@ -167,7 +167,7 @@ func TestWithStatement(t *testing.T) {
 	}
 
 	ws.Object = ObjectExpression{
-		Properties: []Property{
+		Properties: []PropertyOrSpread{
 			Property{
 				Key:   Identifier{Name: "foo"},
 				Kind:  Init,
@@ -180,7 +180,7 @@ func TestWithStatement(t *testing.T) {
 			ExpressionStatement{
 				Expression: CallExpression{
 					Callee: Identifier{Name: "setTimeout"},
-					Arguments: []Expression{
+					Arguments: []ExpressionOrSpread{
 						Identifier{Name: "foo"},
 					},
 				},
@@ -199,8 +199,8 @@ func TestWithStatement(t *testing.T) {
 	v.expect(t, ws,
 		ws.Object,
 		ws.Object.(ObjectExpression).Properties[0],
-		ws.Object.(ObjectExpression).Properties[0].Key, nil,
-		ws.Object.(ObjectExpression).Properties[0].Value, nil, nil, nil,
+		ws.Object.(ObjectExpression).Properties[0].(Property).Key, nil,
+		ws.Object.(ObjectExpression).Properties[0].(Property).Value, nil, nil, nil,
 		ws.Body,
 		ws.Body.(BlockStatement).Body[0],
 		ws.Body.(BlockStatement).Body[0].(ExpressionStatement).Expression,
@@ -0,0 +1,277 @@
+package estree
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TemplateLiteral is a template string, e.g. `foo ${bar} baz`.  Quasis
+// contains the string portions of the template, and Expressions the
+// interpolated values which appear between them.
+type TemplateLiteral struct {
+	baseExpression
+	Loc         SourceLocation
+	Quasis      []TemplateElement
+	Expressions []Expression
+}
+
+func (TemplateLiteral) Type() string                { return "TemplateLiteral" }
+func (tl TemplateLiteral) Location() SourceLocation { return tl.Loc }
+func (TemplateLiteral) MinVersion() Version         { return ES2015 }
+
+func (tl TemplateLiteral) IsZero() bool {
+	return tl.Loc.IsZero() && len(tl.Quasis) == 0 && len(tl.Expressions) == 0
+}
+
+// Walk visits Quasis and Expressions in the order they appear in the source.
+// Any Expressions without a following TemplateElement are visited last.
+func (tl TemplateLiteral) Walk(v Visitor) {
+	if v = v.Visit(tl); v != nil {
+		defer v.Visit(nil)
+		for i, q := range tl.Quasis {
+			q.Walk(v)
+			if i < len(tl.Expressions) && tl.Expressions[i] != nil {
+				tl.Expressions[i].Walk(v)
+			}
+		}
+		for i := len(tl.Quasis); i < len(tl.Expressions); i++ {
+			if tl.Expressions[i] != nil {
+				tl.Expressions[i].Walk(v)
+			}
+		}
+	}
+}
+
+// Errors checks the TemplateLiteral.  A TemplateElement with an invalid
+// escape sequence is only allowed in a TaggedTemplateExpression; since that
+// can't be determined without the parent Node, use Validate to check
+// TemplateLiterals in that position.
+func (tl TemplateLiteral) Errors() []error {
+	return tl.errors(false)
+}
+
+func (tl TemplateLiteral) contextErrors(ctx validationContext) []error {
+	_, tagged := parentNode(ctx.ancestors).(TaggedTemplateExpression)
+	return tl.errors(tagged)
+}
+
+func (tl TemplateLiteral) errors(tagged bool) []error {
+	c := nodeChecker{Node: tl}
+	if len(tl.Quasis) != len(tl.Expressions)+1 {
+		c.appendf("%w %d template elements for %d expressions", ErrWrongValue, len(tl.Quasis), len(tl.Expressions))
+	}
+	for i, q := range tl.Quasis {
+		if q.Tail != (i == len(tl.Quasis)-1) {
+			c.appendf("%w TemplateElement.Tail %t at index %d", ErrWrongValue, q.Tail, i)
+		}
+		if q.InvalidEscape && !tagged {
+			c.appendf("invalid escape sequence in untagged template %w", ErrNotAllowed)
+		}
+	}
+	c.requireEach(nodeSlice{
+		Index: func(i int) Node { return tl.Expressions[i] },
+		Len:   len(tl.Expressions),
+	}, "template expression")
+	return c.errors()
+}
+
+func (tl TemplateLiteral) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(tl)
+	x["quasis"] = tl.Quasis
+	x["expressions"] = tl.Expressions
+	return json.Marshal(x)
+}
+
+func (tl *TemplateLiteral) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type        string            `json:"type"`
+		Quasis      []TemplateElement `json:"quasis"`
+		Expressions []json.RawMessage `json:"expressions"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tl.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, tl.Type(), x.Type)
+	}
+	if err == nil {
+		tl.Loc, tl.Quasis = x.Location(), x.Quasis
+		if len(x.Expressions) == 0 {
+			tl.Expressions = nil
+		} else {
+			tl.Expressions = make([]Expression, len(x.Expressions))
+			for i := range x.Expressions {
+				var err2 error
+				tl.Expressions[i], _, err2 = unmarshalExpression(x.Expressions[i])
+				if err == nil && err2 != nil {
+					err = err2
+				}
+			}
+		}
+	}
+	return err
+}
+
+// TemplateElement is a string portion of a TemplateLiteral.
+type TemplateElement struct {
+	Loc SourceLocation
+
+	// Tail indicates this is the last element of the TemplateLiteral.
+	Tail bool
+
+	// Cooked is the value of the string, after processing escape sequences.
+	Cooked string
+
+	// Raw is the source text of the string, without processing escape
+	// sequences.
+	Raw string
+
+	// InvalidEscape indicates Raw contains an invalid escape sequence, and
+	// Cooked is therefore null.  This is only allowed in a
+	// TaggedTemplateExpression.
+	InvalidEscape bool
+}
+
+func (TemplateElement) Type() string                { return "TemplateElement" }
+func (te TemplateElement) Location() SourceLocation { return te.Loc }
+func (TemplateElement) IsZero() bool                { return false }
+
+func (te TemplateElement) MinVersion() Version {
+	if te.InvalidEscape {
+		return ES2018
+	}
+	return ES2015
+}
+
+func (te TemplateElement) Walk(v Visitor) {
+	if v = v.Visit(te); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (te TemplateElement) Errors() []error {
+	c := nodeChecker{Node: te}
+	if te.InvalidEscape && te.Cooked != "" {
+		c.appendf("%w cooked value %q with invalid escape sequence", ErrWrongValue, te.Cooked)
+	}
+	return c.errors()
+}
+
+func (te TemplateElement) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(te)
+	value := map[string]interface{}{
+		"cooked": te.Cooked,
+		"raw":    te.Raw,
+	}
+	if te.InvalidEscape {
+		value["cooked"] = nil
+	}
+	x["value"] = value
+	x["tail"] = te.Tail
+	return json.Marshal(x)
+}
+
+func (te *TemplateElement) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type  string `json:"type"`
+		Tail  bool   `json:"tail"`
+		Value struct {
+			Cooked *string `json:"cooked"`
+			Raw    string  `json:"raw"`
+		} `json:"value"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != te.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, te.Type(), x.Type)
+	}
+	if err == nil {
+		te.Loc, te.Tail, te.Raw = x.Location(), x.Tail, x.Value.Raw
+		if x.Value.Cooked == nil {
+			te.Cooked, te.InvalidEscape = "", true
+		} else {
+			te.Cooked, te.InvalidEscape = *x.Value.Cooked, false
+		}
+	}
+	return err
+}
+
+// TaggedTemplateExpression is a TemplateLiteral preceded by a tag function,
+// e.g. tag`foo ${bar}`.
+type TaggedTemplateExpression struct {
+	baseExpression
+	Loc   SourceLocation
+	Tag   Expression
+	Quasi TemplateLiteral
+
+	TypeArguments TypeParameterInstantiation // or nil
+}
+
+func (TaggedTemplateExpression) Type() string                 { return "TaggedTemplateExpression" }
+func (tte TaggedTemplateExpression) Location() SourceLocation { return tte.Loc }
+func (TaggedTemplateExpression) MinVersion() Version          { return ES2015 }
+
+func (tte TaggedTemplateExpression) IsZero() bool {
+	return tte.Loc.IsZero() &&
+		(tte.Tag == nil || tte.Tag.IsZero()) &&
+		tte.Quasi.IsZero() &&
+		tte.TypeArguments == nil
+}
+
+func (tte TaggedTemplateExpression) Walk(v Visitor) {
+	if v = v.Visit(tte); v != nil {
+		defer v.Visit(nil)
+		if tte.Tag != nil {
+			tte.Tag.Walk(v)
+		}
+		if tte.TypeArguments != nil {
+			tte.TypeArguments.Walk(v)
+		}
+		tte.Quasi.Walk(v)
+	}
+}
+
+func (tte TaggedTemplateExpression) Errors() []error {
+	c := nodeChecker{Node: tte}
+	c.require(tte.Tag, "template tag")
+	c.optional(tte.TypeArguments)
+	c.require(tte.Quasi, "tagged template")
+	return c.errors()
+}
+
+func (tte TaggedTemplateExpression) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(tte)
+	x["tag"] = tte.Tag
+	x["quasi"] = tte.Quasi
+	if tte.TypeArguments != nil {
+		x["typeArguments"] = tte.TypeArguments
+	}
+	return json.Marshal(x)
+}
+
+func (tte *TaggedTemplateExpression) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type  string          `json:"type"`
+		Tag   json.RawMessage `json:"tag"`
+		Quasi TemplateLiteral `json:"quasi"`
+
+		TypeArguments  json.RawMessage `json:"typeArguments"`
+		TypeParameters json.RawMessage `json:"typeParameters"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tte.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, tte.Type(), x.Type)
+	}
+	if err == nil {
+		tte.Loc, tte.Quasi = x.Location(), x.Quasi
+		tte.Tag, _, err = unmarshalExpression(x.Tag)
+		var err2 error
+		if tte.TypeArguments, err2 = unmarshalTypeArguments(x.TypeArguments, x.TypeParameters); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
@@ -0,0 +1,143 @@
+package estree
+
+import (
+	"testing"
+)
+
+func TestTemplateLiteral(t *testing.T) {
+	var tl TemplateLiteral
+	if !tl.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	tl.Quasis = []TemplateElement{
+		TemplateElement{Cooked: "foo\n", Raw: `foo\n`},
+		TemplateElement{Tail: true},
+	}
+	tl.Expressions = []Expression{
+		Identifier{Name: "bar"},
+	}
+	if tl.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if tl.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", tl.MinVersion())
+	}
+
+	var v mockVisitor
+	tl.Walk(&v)
+	v.expect(t, tl,
+		tl.Quasis[0], nil,
+		tl.Expressions[0], nil,
+		tl.Quasis[1], nil, nil)
+
+	testRoundtripJSON(t, tl, new(TemplateLiteral))
+
+	if errs := tl.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	tl.Quasis[1].InvalidEscape = true
+	if !hasError(ErrNotAllowed, tl.Errors()...) {
+		t.Error("expected ErrNotAllowed for invalid escape in untagged template")
+	}
+	tl.Quasis[1].InvalidEscape = false
+	tl.Quasis[0].Tail = true
+	if !hasError(ErrWrongValue, tl.Errors()...) {
+		t.Error("expected ErrWrongValue for Tail before end")
+	}
+	tl.Quasis[0].Tail = false
+	tl.Expressions = append(tl.Expressions, Identifier{Name: "baz"}, Identifier{Name: "qux"})
+	if !hasError(ErrWrongValue, tl.Errors()...) {
+		t.Error("expected ErrWrongValue for too few Quasis")
+	}
+	v = nil
+	tl.Walk(&v)
+	v.expect(t, tl,
+		tl.Quasis[0], nil,
+		tl.Expressions[0], nil,
+		tl.Quasis[1], nil,
+		tl.Expressions[1], nil,
+		tl.Expressions[2], nil, nil)
+	tl.Expressions[2] = Identifier{}
+	if !hasError(ErrMissingNode, Validate(tl)...) {
+		t.Error("expected ErrMissingNode for zero trailing Expression")
+	}
+	tl.Expressions = tl.Expressions[:1]
+	tl.Expressions[0] = nil
+	if !hasError(ErrMissingNode, tl.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Expression")
+	}
+}
+
+func TestTemplateElement(t *testing.T) {
+	var te TemplateElement
+	if te.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if te.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", te.MinVersion())
+	}
+
+	var v mockVisitor
+	te.Walk(&v)
+	v.expect(t, te, nil)
+
+	testRoundtripJSON(t, te, new(TemplateElement))
+
+	te.Raw = `\unicode`
+	te.InvalidEscape = true
+	if te.MinVersion() != ES2018 {
+		t.Errorf("expected ES2018, got %s", te.MinVersion())
+	}
+	testRoundtripJSON(t, te, new(TemplateElement))
+
+	if errs := te.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	te.Cooked = "unicode"
+	if !hasError(ErrWrongValue, te.Errors()...) {
+		t.Error("expected ErrWrongValue for Cooked with InvalidEscape")
+	}
+}
+
+func TestTaggedTemplateExpression(t *testing.T) {
+	var tte TaggedTemplateExpression
+	if !tte.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	tte.Tag = Identifier{Name: "foo"}
+	tte.Quasi.Quasis = []TemplateElement{
+		TemplateElement{Raw: `\unicode`, InvalidEscape: true, Tail: true},
+	}
+	if tte.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if tte.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", tte.MinVersion())
+	}
+
+	var v mockVisitor
+	tte.Walk(&v)
+	v.expect(t, tte,
+		tte.Tag, nil,
+		tte.Quasi,
+		tte.Quasi.Quasis[0], nil, nil, nil)
+
+	testRoundtripJSON(t, tte, new(TaggedTemplateExpression))
+
+	if errs := Validate(tte); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !hasError(ErrNotAllowed, Validate(tte.Quasi)...) {
+		t.Error("expected ErrNotAllowed for invalid escape in untagged template")
+	}
+	tte.Tag = nil
+	if !hasError(ErrMissingNode, tte.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Tag")
+	}
+	tte.Quasi = TemplateLiteral{}
+	if !hasError(ErrMissingNode, tte.Errors()...) {
+		t.Error("expected ErrMissingNode for zero Quasi")
+	}
+}
@@ -0,0 +1,628 @@
+package ts
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"pifke.org/estree"
+)
+
+// TSParameterProperty is a constructor parameter which also declares a class
+// field, e.g. the private x in constructor(private x: number).  It appears in
+// the Params of the constructor's FunctionExpression.
+type TSParameterProperty struct {
+	estree.BasePattern
+	Loc           estree.SourceLocation
+	Parameter     estree.Pattern // Identifier or AssignmentPattern
+	Accessibility estree.Accessibility
+	Override      bool
+	Readonly      bool
+	Static        bool
+	Decorators    []estree.Decorator
+}
+
+func (TSParameterProperty) Type() string                       { return "TSParameterProperty" }
+func (pp TSParameterProperty) Location() estree.SourceLocation { return pp.Loc }
+func (TSParameterProperty) MinVersion() estree.Version         { return estree.ES5 }
+
+func (pp TSParameterProperty) IsZero() bool {
+	return pp.Loc.IsZero() &&
+		(pp.Parameter == nil || pp.Parameter.IsZero()) &&
+		pp.Accessibility == "" &&
+		!pp.Override &&
+		!pp.Readonly &&
+		!pp.Static &&
+		len(pp.Decorators) == 0
+}
+
+func (pp TSParameterProperty) Walk(v estree.Visitor) {
+	if v = v.Visit(pp); v != nil {
+		defer v.Visit(nil)
+		for _, d := range pp.Decorators {
+			d.Walk(v)
+		}
+		if pp.Parameter != nil {
+			pp.Parameter.Walk(v)
+		}
+	}
+}
+
+func (pp TSParameterProperty) Errors() []error {
+	c := estree.NewChecker(pp)
+	c.RequireEach(len(pp.Decorators), func(i int) estree.Node { return pp.Decorators[i] }, "decorator")
+	c.Require(pp.Parameter, "parameter")
+	switch p := pp.Parameter.(type) {
+	case nil, estree.Identifier:
+	case estree.AssignmentPattern:
+		if _, ok := p.Left.(estree.Identifier); !ok && p.Left != nil {
+			c.Appendf("%w parameter property %s", estree.ErrWrongValue, p.Left.Type())
+		}
+	default:
+		c.Appendf("%w parameter property %s", estree.ErrWrongValue, p.Type())
+	}
+	if pp.Accessibility != "" && !pp.Accessibility.IsValid() {
+		c.Appendf("%w Accessibility %q", estree.ErrWrongValue, pp.Accessibility)
+	}
+	if pp.Accessibility == "" && !pp.Override && !pp.Readonly {
+		c.Appendf("%w accessibility, override, or readonly modifier", estree.ErrMissingNode)
+	}
+	if pp.Static {
+		c.Appendf("static parameter property %w", estree.ErrNotAllowed)
+	}
+	return c.Errors()
+}
+
+func (pp TSParameterProperty) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(pp)
+	x["parameter"] = pp.Parameter
+	if pp.Accessibility != "" {
+		x["accessibility"] = pp.Accessibility
+	}
+	x["override"] = pp.Override
+	x["readonly"] = pp.Readonly
+	x["static"] = pp.Static
+	if len(pp.Decorators) == 0 {
+		x["decorators"] = []estree.Decorator{}
+	} else {
+		x["decorators"] = pp.Decorators
+	}
+	return json.Marshal(x)
+}
+
+func (pp *TSParameterProperty) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type          string               `json:"type"`
+		Parameter     json.RawMessage      `json:"parameter"`
+		Accessibility estree.Accessibility `json:"accessibility"`
+		Override      bool                 `json:"override"`
+		Readonly      bool                 `json:"readonly"`
+		Static        bool                 `json:"static"`
+		Decorators    []estree.Decorator   `json:"decorators"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != pp.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, pp.Type(), x.Type)
+	}
+	if err == nil {
+		pp.Loc, pp.Accessibility = x.Location(), x.Accessibility
+		pp.Override, pp.Readonly, pp.Static = x.Override, x.Readonly, x.Static
+		if len(x.Decorators) == 0 {
+			pp.Decorators = nil
+		} else {
+			pp.Decorators = x.Decorators
+		}
+		pp.Parameter, err = estree.UnmarshalPattern(x.Parameter)
+	}
+	return err
+}
+
+// TSClassImplements is an entry in the implements clause of a class, e.g. the
+// Bar<T> in class Foo<T> implements Bar<T> {}.
+type TSClassImplements struct {
+	estree.BaseClassImplements
+	Loc           estree.SourceLocation
+	Expression    estree.Expression
+	TypeArguments TSTypeParameterInstantiation // possibly zero
+}
+
+func (TSClassImplements) Type() string                       { return "TSClassImplements" }
+func (ci TSClassImplements) Location() estree.SourceLocation { return ci.Loc }
+func (TSClassImplements) MinVersion() estree.Version         { return estree.ES5 }
+
+func (ci TSClassImplements) IsZero() bool {
+	return ci.Loc.IsZero() &&
+		(ci.Expression == nil || ci.Expression.IsZero()) &&
+		ci.TypeArguments.IsZero()
+}
+
+func (ci TSClassImplements) Walk(v estree.Visitor) {
+	if v = v.Visit(ci); v != nil {
+		defer v.Visit(nil)
+		if ci.Expression != nil {
+			ci.Expression.Walk(v)
+		}
+		if !ci.TypeArguments.IsZero() {
+			ci.TypeArguments.Walk(v)
+		}
+	}
+}
+
+func (ci TSClassImplements) Errors() []error {
+	c := estree.NewChecker(ci)
+	c.Require(ci.Expression, "implemented interface")
+	switch ci.Expression.(type) {
+	case nil, estree.Identifier, estree.MemberExpression:
+	default:
+		c.Appendf("%w implemented interface %s", estree.ErrWrongValue, ci.Expression.Type())
+	}
+	c.Optional(ci.TypeArguments)
+	return c.Errors()
+}
+
+func (ci TSClassImplements) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ci)
+	x["expression"] = ci.Expression
+	if ci.TypeArguments.IsZero() {
+		x["typeArguments"] = nil
+	} else {
+		x["typeArguments"] = ci.TypeArguments
+	}
+	return json.Marshal(x)
+}
+
+func (ci *TSClassImplements) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type          string                       `json:"type"`
+		Expression    json.RawMessage              `json:"expression"`
+		TypeArguments TSTypeParameterInstantiation `json:"typeArguments"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ci.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ci.Type(), x.Type)
+	}
+	if err == nil {
+		ci.Loc, ci.TypeArguments = x.Location(), x.TypeArguments
+		ci.Expression, err = estree.UnmarshalExpression(x.Expression)
+	}
+	return err
+}
+
+// TSEmptyBodyFunctionExpression is the Value of a method declared without a
+// body, i.e. an abstract method or an overload signature, e.g. the
+// foo(x: string): void; in a ClassBody.
+type TSEmptyBodyFunctionExpression struct {
+	estree.BaseMethodValue
+	Loc            estree.SourceLocation
+	ID             estree.Identifier          // possibly zero
+	TypeParameters TSTypeParameterDeclaration // possibly zero
+	Params         []estree.Pattern
+	ReturnType     TSTypeAnnotation // possibly zero
+	Async          bool
+	Generator      bool
+}
+
+func (TSEmptyBodyFunctionExpression) Type() string { return "TSEmptyBodyFunctionExpression" }
+func (fe TSEmptyBodyFunctionExpression) Location() estree.SourceLocation {
+	return fe.Loc
+}
+func (TSEmptyBodyFunctionExpression) MinVersion() estree.Version { return estree.ES5 }
+
+func (fe TSEmptyBodyFunctionExpression) FunctionID() estree.Identifier    { return fe.ID }
+func (fe TSEmptyBodyFunctionExpression) FunctionParams() []estree.Pattern { return fe.Params }
+func (TSEmptyBodyFunctionExpression) FunctionBody() estree.FunctionBody   { return estree.FunctionBody{} }
+func (fe TSEmptyBodyFunctionExpression) FunctionGenerator() bool          { return fe.Generator }
+func (fe TSEmptyBodyFunctionExpression) FunctionAsync() bool              { return fe.Async }
+
+func (fe TSEmptyBodyFunctionExpression) IsZero() bool {
+	return fe.Loc.IsZero() &&
+		fe.ID.IsZero() &&
+		fe.TypeParameters.IsZero() &&
+		len(fe.Params) == 0 &&
+		fe.ReturnType.IsZero() &&
+		!fe.Async &&
+		!fe.Generator
+}
+
+func (fe TSEmptyBodyFunctionExpression) Walk(v estree.Visitor) {
+	if v = v.Visit(fe); v != nil {
+		defer v.Visit(nil)
+		if !fe.ID.IsZero() {
+			fe.ID.Walk(v)
+		}
+		if !fe.TypeParameters.IsZero() {
+			fe.TypeParameters.Walk(v)
+		}
+		for _, p := range fe.Params {
+			if p != nil {
+				p.Walk(v)
+			}
+		}
+		if !fe.ReturnType.IsZero() {
+			fe.ReturnType.Walk(v)
+		}
+	}
+}
+
+func (fe TSEmptyBodyFunctionExpression) Errors() []error {
+	c := estree.NewChecker(fe)
+	c.Optional(fe.ID)
+	c.Optional(fe.TypeParameters)
+	c.RequireEach(len(fe.Params), func(i int) estree.Node { return fe.Params[i] }, "function parameter")
+	c.Optional(fe.ReturnType)
+	return c.Errors()
+}
+
+func (fe TSEmptyBodyFunctionExpression) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(fe)
+	if fe.ID.IsZero() {
+		x["id"] = nil
+	} else {
+		x["id"] = fe.ID
+	}
+	x["typeParameters"] = typeParametersToJSON(fe.TypeParameters)
+	if len(fe.Params) == 0 {
+		x["params"] = []estree.Pattern{}
+	} else {
+		x["params"] = fe.Params
+	}
+	if fe.ReturnType.IsZero() {
+		x["returnType"] = nil
+	} else {
+		x["returnType"] = fe.ReturnType
+	}
+	x["body"] = nil
+	x["async"] = fe.Async
+	x["generator"] = fe.Generator
+	return json.Marshal(x)
+}
+
+func (fe *TSEmptyBodyFunctionExpression) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string                     `json:"type"`
+		ID             estree.Identifier          `json:"id"`
+		TypeParameters TSTypeParameterDeclaration `json:"typeParameters"`
+		Params         []json.RawMessage          `json:"params"`
+		ReturnType     TSTypeAnnotation           `json:"returnType"`
+		Async          bool                       `json:"async"`
+		Generator      bool                       `json:"generator"`
+		Body           json.RawMessage            `json:"body"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != fe.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, fe.Type(), x.Type)
+	}
+	if err == nil && !isNull(x.Body) {
+		err = fmt.Errorf("TSEmptyBodyFunctionExpression body %w", estree.ErrNotAllowed)
+	}
+	if err == nil {
+		fe.Loc, fe.ID, fe.TypeParameters = x.Location(), x.ID, x.TypeParameters
+		fe.ReturnType, fe.Async, fe.Generator = x.ReturnType, x.Async, x.Generator
+		if len(x.Params) == 0 {
+			fe.Params = nil
+		} else {
+			fe.Params = make([]estree.Pattern, len(x.Params))
+			for i := range x.Params {
+				var err2 error
+				fe.Params[i], err2 = estree.UnmarshalPattern(x.Params[i])
+				if err == nil && err2 != nil {
+					err = err2
+				}
+			}
+		}
+	}
+	return err
+}
+
+// checkAbstractMember checks the modifiers of a TSAbstractMethodDefinition or
+// TSAbstractPropertyDefinition.
+func checkAbstractMember(c *estree.Checker, key estree.ExpressionOrPrivateIdentifier, computed, static bool, a estree.Accessibility, what string) {
+	switch key.(type) {
+	case nil, estree.LiteralOrIdentifier:
+	case estree.PrivateIdentifier:
+		c.Appendf("abstract private %s name %w", what, estree.ErrNotAllowed)
+	default:
+		if !computed {
+			c.Appendf("%w %s name %s without Computed", estree.ErrWrongValue, what, key.Type())
+		}
+	}
+	if a != "" && !a.IsValid() {
+		c.Appendf("%w Accessibility %q", estree.ErrWrongValue, a)
+	} else if a == estree.Private {
+		c.Appendf("private abstract %s %w", what, estree.ErrNotAllowed)
+	}
+	if static {
+		c.Appendf("static abstract %s %w", what, estree.ErrNotAllowed)
+	}
+}
+
+// TSAbstractMethodDefinition is an abstract method in a ClassBody, e.g.
+// abstract foo(): void;.
+type TSAbstractMethodDefinition struct {
+	estree.BaseClassElement
+	Loc   estree.SourceLocation
+	Key   estree.ExpressionOrPrivateIdentifier
+	Value TSEmptyBodyFunctionExpression
+	Kind  estree.MethodDefinitionKind
+
+	// Computed indicates Key is an arbitrary Expression ([key](): void).  If
+	// Computed is false, Key is an Identifier or Literal.
+	Computed bool
+
+	Static        bool
+	Decorators    []estree.Decorator
+	Accessibility estree.Accessibility // possibly empty
+	Optional      bool
+	Override      bool
+}
+
+func (TSAbstractMethodDefinition) Type() string { return "TSAbstractMethodDefinition" }
+func (md TSAbstractMethodDefinition) Location() estree.SourceLocation {
+	return md.Loc
+}
+func (TSAbstractMethodDefinition) MinVersion() estree.Version { return estree.ES2015 }
+
+func (md TSAbstractMethodDefinition) IsZero() bool {
+	return md.Loc.IsZero() &&
+		(md.Key == nil || md.Key.IsZero()) &&
+		md.Value.IsZero() &&
+		md.Kind == "" &&
+		!md.Computed &&
+		!md.Static &&
+		len(md.Decorators) == 0 &&
+		md.Accessibility == "" &&
+		!md.Optional &&
+		!md.Override
+}
+
+func (md TSAbstractMethodDefinition) Walk(v estree.Visitor) {
+	if v = v.Visit(md); v != nil {
+		defer v.Visit(nil)
+		for _, d := range md.Decorators {
+			d.Walk(v)
+		}
+		if md.Key != nil {
+			md.Key.Walk(v)
+		}
+		md.Value.Walk(v)
+	}
+}
+
+func (md TSAbstractMethodDefinition) Errors() []error {
+	c := estree.NewChecker(md)
+	c.RequireEach(len(md.Decorators), func(i int) estree.Node { return md.Decorators[i] }, "decorator")
+	c.Require(md.Key, "method name")
+	checkAbstractMember(c, md.Key, md.Computed, md.Static, md.Accessibility, "method")
+	if !md.Kind.IsValid() {
+		c.Appendf("%w MethodDefinitionKind %q", estree.ErrWrongValue, md.Kind)
+	} else if md.Kind == estree.Constructor {
+		c.Appendf("abstract constructor %w", estree.ErrNotAllowed)
+	}
+	if md.Value.Async || md.Value.Generator {
+		c.Appendf("async or generator abstract method %w", estree.ErrNotAllowed)
+	}
+	return c.Errors()
+}
+
+func (md TSAbstractMethodDefinition) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(md)
+	x["key"] = md.Key
+	x["value"] = md.Value
+	x["kind"] = md.Kind
+	x["computed"] = md.Computed
+	x["static"] = md.Static
+	if len(md.Decorators) > 0 {
+		x["decorators"] = md.Decorators
+	}
+	if md.Accessibility != "" {
+		x["accessibility"] = md.Accessibility
+	}
+	if md.Optional {
+		x["optional"] = true
+	}
+	if md.Override {
+		x["override"] = true
+	}
+	return json.Marshal(x)
+}
+
+func (md *TSAbstractMethodDefinition) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type     string                        `json:"type"`
+		Key      json.RawMessage               `json:"key"`
+		Value    TSEmptyBodyFunctionExpression `json:"value"`
+		Kind     estree.MethodDefinitionKind   `json:"kind"`
+		Computed bool                          `json:"computed"`
+		Static   bool                          `json:"static"`
+
+		Decorators    []estree.Decorator   `json:"decorators"`
+		Accessibility estree.Accessibility `json:"accessibility"`
+		Optional      bool                 `json:"optional"`
+		Override      bool                 `json:"override"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != md.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, md.Type(), x.Type)
+	}
+	if err == nil {
+		md.Loc, md.Value, md.Computed, md.Static =
+			x.Location(), x.Value, x.Computed, x.Static
+		md.Accessibility, md.Optional, md.Override =
+			x.Accessibility, x.Optional, x.Override
+		if len(x.Decorators) == 0 {
+			md.Decorators = nil
+		} else {
+			md.Decorators = x.Decorators
+		}
+		if x.Kind.IsValid() {
+			md.Kind = x.Kind
+		} else {
+			err = fmt.Errorf("%w TSAbstractMethodDefinition.Kind %q", estree.ErrWrongValue, x.Kind)
+		}
+		var err2 error
+		if md.Key, err2 = estree.UnmarshalExpressionOrPrivateIdentifier(x.Key); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// TSAbstractPropertyDefinition is an abstract field in a ClassBody, e.g.
+// abstract foo: string;.
+type TSAbstractPropertyDefinition struct {
+	estree.BaseClassElement
+	Loc   estree.SourceLocation
+	Key   estree.ExpressionOrPrivateIdentifier
+	Value estree.Expression // must be nil
+
+	// Computed indicates Key is an arbitrary Expression ([key]: type).  If
+	// Computed is false, Key is an Identifier or Literal.
+	Computed bool
+
+	Static         bool
+	TypeAnnotation TSTypeAnnotation // possibly zero
+	Decorators     []estree.Decorator
+	Accessibility  estree.Accessibility // possibly empty
+	Declare        bool
+	Definite       bool
+	Optional       bool
+	Override       bool
+	Readonly       bool
+}
+
+func (TSAbstractPropertyDefinition) Type() string { return "TSAbstractPropertyDefinition" }
+func (pd TSAbstractPropertyDefinition) Location() estree.SourceLocation {
+	return pd.Loc
+}
+func (TSAbstractPropertyDefinition) MinVersion() estree.Version { return estree.ES2022 }
+
+func (pd TSAbstractPropertyDefinition) IsZero() bool {
+	return pd.Loc.IsZero() &&
+		(pd.Key == nil || pd.Key.IsZero()) &&
+		(pd.Value == nil || pd.Value.IsZero()) &&
+		!pd.Computed &&
+		!pd.Static &&
+		pd.TypeAnnotation.IsZero() &&
+		len(pd.Decorators) == 0 &&
+		pd.Accessibility == "" &&
+		!pd.Declare &&
+		!pd.Definite &&
+		!pd.Optional &&
+		!pd.Override &&
+		!pd.Readonly
+}
+
+func (pd TSAbstractPropertyDefinition) Walk(v estree.Visitor) {
+	if v = v.Visit(pd); v != nil {
+		defer v.Visit(nil)
+		for _, d := range pd.Decorators {
+			d.Walk(v)
+		}
+		if pd.Key != nil {
+			pd.Key.Walk(v)
+		}
+		if !pd.TypeAnnotation.IsZero() {
+			pd.TypeAnnotation.Walk(v)
+		}
+		if pd.Value != nil {
+			pd.Value.Walk(v)
+		}
+	}
+}
+
+func (pd TSAbstractPropertyDefinition) Errors() []error {
+	c := estree.NewChecker(pd)
+	c.RequireEach(len(pd.Decorators), func(i int) estree.Node { return pd.Decorators[i] }, "decorator")
+	c.Require(pd.Key, "field name")
+	checkAbstractMember(c, pd.Key, pd.Computed, pd.Static, pd.Accessibility, "field")
+	if pd.Value != nil {
+		c.Appendf("initializer of abstract field %w", estree.ErrNotAllowed)
+	}
+	if pd.Definite {
+		c.Appendf("definite assertion on abstract field %w", estree.ErrNotAllowed)
+	}
+	c.Optional(pd.TypeAnnotation)
+	return c.Errors()
+}
+
+func (pd TSAbstractPropertyDefinition) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(pd)
+	x["key"] = pd.Key
+	x["value"] = pd.Value
+	x["computed"] = pd.Computed
+	x["static"] = pd.Static
+	if !pd.TypeAnnotation.IsZero() {
+		x["typeAnnotation"] = pd.TypeAnnotation
+	}
+	if len(pd.Decorators) > 0 {
+		x["decorators"] = pd.Decorators
+	}
+	if pd.Accessibility != "" {
+		x["accessibility"] = pd.Accessibility
+	}
+	if pd.Declare {
+		x["declare"] = true
+	}
+	if pd.Definite {
+		x["definite"] = true
+	}
+	if pd.Optional {
+		x["optional"] = true
+	}
+	if pd.Override {
+		x["override"] = true
+	}
+	if pd.Readonly {
+		x["readonly"] = true
+	}
+	return json.Marshal(x)
+}
+
+func (pd *TSAbstractPropertyDefinition) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type     string          `json:"type"`
+		Key      json.RawMessage `json:"key"`
+		Value    json.RawMessage `json:"value"`
+		Computed bool            `json:"computed"`
+		Static   bool            `json:"static"`
+
+		TypeAnnotation TSTypeAnnotation     `json:"typeAnnotation"`
+		Decorators     []estree.Decorator   `json:"decorators"`
+		Accessibility  estree.Accessibility `json:"accessibility"`
+		Declare        bool                 `json:"declare"`
+		Definite       bool                 `json:"definite"`
+		Optional       bool                 `json:"optional"`
+		Override       bool                 `json:"override"`
+		Readonly       bool                 `json:"readonly"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != pd.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, pd.Type(), x.Type)
+	}
+	if err == nil {
+		pd.Loc, pd.Computed, pd.Static = x.Location(), x.Computed, x.Static
+		pd.TypeAnnotation, pd.Accessibility = x.TypeAnnotation, x.Accessibility
+		pd.Declare, pd.Definite = x.Declare, x.Definite
+		pd.Optional, pd.Override, pd.Readonly = x.Optional, x.Override, x.Readonly
+		if len(x.Decorators) == 0 {
+			pd.Decorators = nil
+		} else {
+			pd.Decorators = x.Decorators
+		}
+		pd.Key, err = estree.UnmarshalExpressionOrPrivateIdentifier(x.Key)
+		var err2 error
+		if pd.Value, err2 = estree.UnmarshalExpression(x.Value); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
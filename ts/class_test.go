@@ -0,0 +1,227 @@
+package ts
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"pifke.org/estree"
+)
+
+func TestTSParameterProperty(t *testing.T) {
+	var pp TSParameterProperty
+	if !pp.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// private readonly x: number
+	pp.Parameter = estree.Identifier{
+		Name:           "x",
+		TypeAnnotation: TSTypeAnnotation{TypeAnnotation: TSKeyword{Kind: Number}},
+	}
+	pp.Accessibility = estree.Private
+	pp.Readonly = true
+	if pp.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if pp.MinVersion() != estree.ES5 {
+		t.Errorf("expected ES5, got %s", pp.MinVersion())
+	}
+
+	ta := pp.Parameter.(estree.Identifier).TypeAnnotation.(TSTypeAnnotation)
+	var v mockVisitor
+	pp.Walk(&v)
+	v.expect(t, pp,
+		pp.Parameter, ta, ta.TypeAnnotation, nil, nil, nil, nil)
+
+	testRoundtripJSON(t, pp, new(TSParameterProperty))
+
+	if errs := pp.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	pp.Accessibility, pp.Readonly = "", false
+	if !hasError(estree.ErrMissingNode, pp.Errors()...) {
+		t.Error("expected ErrMissingNode for missing modifier")
+	}
+	pp.Accessibility = "internal"
+	if !hasError(estree.ErrWrongValue, pp.Errors()...) {
+		t.Error("expected ErrWrongValue for invalid Accessibility")
+	}
+	pp.Accessibility = estree.Public
+	pp.Parameter = estree.ObjectPattern{}
+	if !hasError(estree.ErrWrongValue, pp.Errors()...) {
+		t.Error("expected ErrWrongValue for ObjectPattern parameter")
+	}
+	pp.Parameter = estree.AssignmentPattern{
+		Left:  estree.Identifier{Name: "x"},
+		Right: estree.NumberLiteral{Value: 1},
+	}
+	if errs := pp.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	testRoundtripJSON(t, pp, new(TSParameterProperty))
+	pp.Static = true
+	if !hasError(estree.ErrNotAllowed, pp.Errors()...) {
+		t.Error("expected ErrNotAllowed for static parameter property")
+	}
+}
+
+func TestClassMemberModifiers(t *testing.T) {
+	// abstract class Foo {
+	//   private readonly x: number;
+	//   protected override y?: string;
+	//   constructor(private z: number) {}
+	// }
+	b := []byte(`{"type":"ClassDeclaration","id":{"type":"Identifier","name":"Foo"},` +
+		`"superClass":null,"abstract":true,"body":{"type":"ClassBody","body":[` +
+		`{"type":"PropertyDefinition","key":{"type":"Identifier","name":"x"},"value":null,` +
+		`"computed":false,"static":false,"accessibility":"private","readonly":true,` +
+		`"typeAnnotation":{"type":"TSTypeAnnotation","typeAnnotation":{"type":"TSNumberKeyword"}}},` +
+		`{"type":"PropertyDefinition","key":{"type":"Identifier","name":"y"},"value":null,` +
+		`"computed":false,"static":false,"accessibility":"protected","override":true,"optional":true},` +
+		`{"type":"MethodDefinition","key":{"type":"Identifier","name":"constructor"},` +
+		`"kind":"constructor","computed":false,"static":false,"value":{"type":"FunctionExpression",` +
+		`"id":null,"params":[{"type":"TSParameterProperty","accessibility":"private",` +
+		`"override":false,"readonly":false,"static":false,"decorators":[],` +
+		`"parameter":{"type":"Identifier","name":"z"}}],` +
+		`"body":{"type":"BlockStatement","body":[]},"generator":false,"async":false}}]}}`)
+	var cd estree.ClassDeclaration
+	if err := json.Unmarshal(b, &cd); err != nil {
+		t.Fatal(err)
+	}
+	if !cd.Abstract {
+		t.Error("expected Abstract")
+	}
+	x := cd.Body.Body[0].(estree.PropertyDefinition)
+	if x.Accessibility != estree.Private || !x.Readonly {
+		t.Errorf("expected private readonly, got %#v", x)
+	}
+	y := cd.Body.Body[1].(estree.PropertyDefinition)
+	if y.Accessibility != estree.Protected || !y.Override || !y.Optional {
+		t.Errorf("expected protected override optional, got %#v", y)
+	}
+	ctor := cd.Body.Body[2].(estree.MethodDefinition)
+	if pp, ok := ctor.Value.FunctionParams()[0].(TSParameterProperty); !ok || pp.Accessibility != estree.Private {
+		t.Errorf("expected private TSParameterProperty, got %#v", ctor.Value.FunctionParams()[0])
+	}
+	if errs := estree.Validate(cd); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	testRoundtripJSON(t, cd, new(estree.ClassDeclaration))
+
+	b, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{`"accessibility":"private"`, `"readonly":true`} {
+		if !bytes.Contains(b, []byte(field)) {
+			t.Errorf("expected %s, got %s", field, b)
+		}
+	}
+}
+
+func TestAbstractClassMembers(t *testing.T) {
+	// abstract class Foo extends Bar<string> implements Baz<number>, Qux {
+	//   abstract foo: number;
+	//   abstract bar(x: string): void;
+	//   baz(): void;
+	//   static [key: string]: unknown;
+	// }
+	b := []byte(`{"type":"ClassDeclaration","id":{"type":"Identifier","name":"Foo"},"abstract":true,` +
+		`"superClass":{"type":"Identifier","name":"Bar"},` +
+		`"superTypeParameters":{"type":"TSTypeParameterInstantiation","params":[{"type":"TSStringKeyword"}]},` +
+		`"implements":[{"type":"TSClassImplements","expression":{"type":"Identifier","name":"Baz"},` +
+		`"typeArguments":{"type":"TSTypeParameterInstantiation","params":[{"type":"TSNumberKeyword"}]}},` +
+		`{"type":"TSClassImplements","expression":{"type":"Identifier","name":"Qux"}}],` +
+		`"body":{"type":"ClassBody","body":[` +
+		`{"type":"TSAbstractPropertyDefinition","key":{"type":"Identifier","name":"foo"},"value":null,` +
+		`"computed":false,"static":false,"typeAnnotation":{"type":"TSTypeAnnotation",` +
+		`"typeAnnotation":{"type":"TSNumberKeyword"}}},` +
+		`{"type":"TSAbstractMethodDefinition","key":{"type":"Identifier","name":"bar"},` +
+		`"kind":"method","computed":false,"static":false,"value":{"type":"TSEmptyBodyFunctionExpression",` +
+		`"id":null,"params":[{"type":"Identifier","name":"x","typeAnnotation":{"type":"TSTypeAnnotation",` +
+		`"typeAnnotation":{"type":"TSStringKeyword"}}}],"body":null,"generator":false,"async":false,` +
+		`"returnType":{"type":"TSTypeAnnotation","typeAnnotation":{"type":"TSVoidKeyword"}}}},` +
+		`{"type":"MethodDefinition","key":{"type":"Identifier","name":"baz"},` +
+		`"kind":"method","computed":false,"static":false,"value":{"type":"TSEmptyBodyFunctionExpression",` +
+		`"id":null,"params":[],"body":null,"generator":false,"async":false}},` +
+		`{"type":"TSIndexSignature","parameters":[{"type":"Identifier","name":"key",` +
+		`"typeAnnotation":{"type":"TSTypeAnnotation","typeAnnotation":{"type":"TSStringKeyword"}}}],` +
+		`"typeAnnotation":{"type":"TSTypeAnnotation","typeAnnotation":{"type":"TSUnknownKeyword"}},` +
+		`"readonly":false,"static":true}]}}`)
+	var cd estree.ClassDeclaration
+	if err := json.Unmarshal(b, &cd); err != nil {
+		t.Fatal(err)
+	}
+	if errs := estree.Validate(cd); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	testRoundtripJSON(t, cd, new(estree.ClassDeclaration))
+
+	if _, ok := cd.SuperTypeArguments.(TSTypeParameterInstantiation); !ok {
+		t.Errorf("expected TSTypeParameterInstantiation, got %+v", cd.SuperTypeArguments)
+	}
+	if len(cd.Implements) != 2 {
+		t.Fatalf("expected 2 implemented interfaces, got %+v", cd.Implements)
+	}
+	if ci, ok := cd.Implements[0].(TSClassImplements); !ok || ci.TypeArguments.IsZero() {
+		t.Errorf("expected Baz<number>, got %+v", cd.Implements[0])
+	}
+	pd, ok := cd.Body.Body[0].(TSAbstractPropertyDefinition)
+	if !ok {
+		t.Fatalf("expected TSAbstractPropertyDefinition, got %#v", cd.Body.Body[0])
+	}
+	md, ok := cd.Body.Body[1].(TSAbstractMethodDefinition)
+	if !ok || len(md.Value.Params) != 1 || md.Value.ReturnType.IsZero() {
+		t.Fatalf("expected TSAbstractMethodDefinition, got %#v", cd.Body.Body[1])
+	}
+	overload := cd.Body.Body[2].(estree.MethodDefinition)
+	if _, ok := overload.Value.(TSEmptyBodyFunctionExpression); !ok {
+		t.Errorf("expected TSEmptyBodyFunctionExpression, got %#v", overload.Value)
+	}
+	is, ok := cd.Body.Body[3].(TSIndexSignature)
+	if !ok || !is.Static {
+		t.Fatalf("expected static TSIndexSignature, got %#v", cd.Body.Body[3])
+	}
+
+	var v mockVisitor
+	cd.Walk(&v)
+	if len(v) < 10 || !reflect.DeepEqual(v[5], cd.SuperTypeArguments) || !reflect.DeepEqual(v[9], cd.Implements[0]) {
+		t.Errorf("expected SuperTypeArguments and Implements to be walked after SuperClass, %v", v)
+	}
+
+	pd.Value = estree.NumberLiteral{Value: 1}
+	if !hasError(estree.ErrNotAllowed, pd.Errors()...) {
+		t.Error("expected ErrNotAllowed for abstract field initializer")
+	}
+	md.Static = true
+	if !hasError(estree.ErrNotAllowed, md.Errors()...) {
+		t.Error("expected ErrNotAllowed for static abstract method")
+	}
+	md.Static, md.Accessibility = false, estree.Private
+	if !hasError(estree.ErrNotAllowed, md.Errors()...) {
+		t.Error("expected ErrNotAllowed for private abstract method")
+	}
+	is.Parameters = nil
+	if !hasError(estree.ErrWrongValue, is.Errors()...) {
+		t.Error("expected ErrWrongValue for index signature without parameter")
+	}
+	cd.SuperClass = nil
+	if !hasError(estree.ErrNotAllowed, cd.Errors()...) {
+		t.Error("expected ErrNotAllowed for superclass type arguments without superclass")
+	}
+	cd.Implements[1] = TSClassImplements{Expression: estree.CallExpression{Callee: estree.Identifier{Name: "Qux"}}}
+	if !hasError(estree.ErrWrongValue, cd.Implements[1].Errors()...) {
+		t.Error("expected ErrWrongValue for CallExpression implemented interface")
+	}
+
+	// foo(): void { }, where a body is not allowed
+	b = []byte(`{"type":"TSEmptyBodyFunctionExpression","id":null,"params":[],` +
+		`"body":{"type":"BlockStatement","body":[]},"generator":false,"async":false}`)
+	var fe TSEmptyBodyFunctionExpression
+	if err := json.Unmarshal(b, &fe); !errors.Is(err, estree.ErrNotAllowed) {
+		t.Errorf("expected ErrNotAllowed, got %v", err)
+	}
+}
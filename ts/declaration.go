@@ -0,0 +1,934 @@
+package ts
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"pifke.org/estree"
+)
+
+// TSTypeParameter declares a generic type parameter, e.g. the T extends Foo
+// = Bar in interface Baz<T extends Foo = Bar>.
+type TSTypeParameter struct {
+	Loc        estree.SourceLocation
+	Name       estree.Identifier
+	Constraint TSType // or nil
+	Default    TSType // or nil
+}
+
+func (TSTypeParameter) Type() string                       { return "TSTypeParameter" }
+func (tp TSTypeParameter) Location() estree.SourceLocation { return tp.Loc }
+func (TSTypeParameter) MinVersion() estree.Version         { return estree.ES5 }
+
+func (tp TSTypeParameter) IsZero() bool {
+	return tp.Loc.IsZero() &&
+		tp.Name.IsZero() &&
+		(tp.Constraint == nil || tp.Constraint.IsZero()) &&
+		(tp.Default == nil || tp.Default.IsZero())
+}
+
+func (tp TSTypeParameter) Walk(v estree.Visitor) {
+	if v = v.Visit(tp); v != nil {
+		defer v.Visit(nil)
+		tp.Name.Walk(v)
+		if tp.Constraint != nil {
+			tp.Constraint.Walk(v)
+		}
+		if tp.Default != nil {
+			tp.Default.Walk(v)
+		}
+	}
+}
+
+func (tp TSTypeParameter) Errors() []error {
+	c := estree.NewChecker(tp)
+	c.Require(tp.Name, "type parameter name")
+	c.Optional(tp.Constraint)
+	c.Optional(tp.Default)
+	return c.Errors()
+}
+
+func (tp TSTypeParameter) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(tp)
+	x["name"] = tp.Name
+	x["constraint"] = tp.Constraint
+	x["default"] = tp.Default
+	return json.Marshal(x)
+}
+
+func (tp *TSTypeParameter) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type       string            `json:"type"`
+		Name       estree.Identifier `json:"name"`
+		Constraint json.RawMessage   `json:"constraint"`
+		Default    json.RawMessage   `json:"default"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tp.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tp.Type(), x.Type)
+	}
+	if err == nil {
+		tp.Loc, tp.Name = x.Location(), x.Name
+		tp.Constraint, err = unmarshalType(x.Constraint)
+		var err2 error
+		if tp.Default, err2 = unmarshalType(x.Default); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// TSTypeParameterDeclaration is the list of type parameters of a generic
+// declaration, e.g. the <K, V> in interface Map<K, V>.  It is also the
+// TypeParameters of a generic estree function or class.
+type TSTypeParameterDeclaration struct {
+	estree.BaseTypeParameterDeclaration
+	Loc    estree.SourceLocation
+	Params []TSTypeParameter
+}
+
+func (TSTypeParameterDeclaration) Type() string { return "TSTypeParameterDeclaration" }
+func (tpd TSTypeParameterDeclaration) Location() estree.SourceLocation {
+	return tpd.Loc
+}
+func (TSTypeParameterDeclaration) MinVersion() estree.Version { return estree.ES5 }
+
+func (tpd TSTypeParameterDeclaration) IsZero() bool {
+	return tpd.Loc.IsZero() && len(tpd.Params) == 0
+}
+
+func (tpd TSTypeParameterDeclaration) Walk(v estree.Visitor) {
+	if v = v.Visit(tpd); v != nil {
+		defer v.Visit(nil)
+		for _, p := range tpd.Params {
+			p.Walk(v)
+		}
+	}
+}
+
+func (tpd TSTypeParameterDeclaration) Errors() []error {
+	c := estree.NewChecker(tpd)
+	if len(tpd.Params) == 0 {
+		c.Appendf("%w type parameter", estree.ErrMissingNode)
+	}
+	c.RequireEach(len(tpd.Params), func(i int) estree.Node { return tpd.Params[i] }, "type parameter")
+	return c.Errors()
+}
+
+func (tpd TSTypeParameterDeclaration) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(tpd)
+	x["params"] = tpd.Params
+	return json.Marshal(x)
+}
+
+func (tpd *TSTypeParameterDeclaration) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		return nil // optional type parameters are null when absent
+	}
+	var x struct {
+		estree.NodeFields
+
+		Type   string            `json:"type"`
+		Params []TSTypeParameter `json:"params"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tpd.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tpd.Type(), x.Type)
+	}
+	if err == nil {
+		tpd.Loc, tpd.Params = x.Location(), x.Params
+	}
+	return err
+}
+
+// typeParametersToJSON returns the JSON representation of optional type
+// parameters, which are null when absent.
+func typeParametersToJSON(tpd TSTypeParameterDeclaration) interface{} {
+	if tpd.IsZero() {
+		return nil
+	}
+	return tpd
+}
+
+// TSInterfaceDeclaration declares an interface, e.g.
+// interface Foo extends Bar { baz: string }.
+type TSInterfaceDeclaration struct {
+	estree.BaseDeclaration
+	Loc            estree.SourceLocation
+	ID             estree.Identifier
+	TypeParameters TSTypeParameterDeclaration // possibly zero
+	Extends        []TSInterfaceHeritage
+	Body           TSInterfaceBody
+
+	// Declare indicates the interface was declared with the declare
+	// modifier.
+	Declare bool
+}
+
+func (TSInterfaceDeclaration) Type() string                       { return "TSInterfaceDeclaration" }
+func (id TSInterfaceDeclaration) Location() estree.SourceLocation { return id.Loc }
+
+func (id TSInterfaceDeclaration) IsZero() bool {
+	return id.Loc.IsZero() &&
+		id.ID.IsZero() &&
+		id.TypeParameters.IsZero() &&
+		len(id.Extends) == 0 &&
+		len(id.Body.Body) == 0 &&
+		!id.Declare
+}
+
+func (id TSInterfaceDeclaration) Walk(v estree.Visitor) {
+	if v = v.Visit(id); v != nil {
+		defer v.Visit(nil)
+		id.ID.Walk(v)
+		if !id.TypeParameters.IsZero() {
+			id.TypeParameters.Walk(v)
+		}
+		for _, e := range id.Extends {
+			e.Walk(v)
+		}
+		id.Body.Walk(v)
+	}
+}
+
+func (id TSInterfaceDeclaration) Errors() []error {
+	c := estree.NewChecker(id)
+	c.Require(id.ID, "interface name")
+	c.Optional(id.TypeParameters)
+	c.RequireEach(len(id.Extends), func(i int) estree.Node { return id.Extends[i] }, "interface heritage")
+	c.Require(id.Body, "interface body")
+	return c.Errors()
+}
+
+func (id TSInterfaceDeclaration) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(id)
+	x["id"] = id.ID
+	x["typeParameters"] = typeParametersToJSON(id.TypeParameters)
+	if len(id.Extends) == 0 {
+		x["extends"] = []TSInterfaceHeritage{}
+	} else {
+		x["extends"] = id.Extends
+	}
+	x["body"] = id.Body
+	x["declare"] = id.Declare
+	return json.Marshal(x)
+}
+
+func (id *TSInterfaceDeclaration) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string                     `json:"type"`
+		ID             estree.Identifier          `json:"id"`
+		TypeParameters TSTypeParameterDeclaration `json:"typeParameters"`
+		Extends        []TSInterfaceHeritage      `json:"extends"`
+		Body           TSInterfaceBody            `json:"body"`
+		Declare        bool                       `json:"declare"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != id.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, id.Type(), x.Type)
+	}
+	if err == nil {
+		id.Loc, id.ID, id.TypeParameters = x.Location(), x.ID, x.TypeParameters
+		id.Body, id.Declare = x.Body, x.Declare
+		if len(x.Extends) == 0 {
+			id.Extends = nil
+		} else {
+			id.Extends = x.Extends
+		}
+	}
+	return err
+}
+
+// TSInterfaceHeritage is an entry in the extends clause of a
+// TSInterfaceDeclaration.
+type TSInterfaceHeritage struct {
+	Loc           estree.SourceLocation
+	Expression    estree.Expression
+	TypeArguments TSTypeParameterInstantiation // possibly zero
+}
+
+func (TSInterfaceHeritage) Type() string                       { return "TSInterfaceHeritage" }
+func (ih TSInterfaceHeritage) Location() estree.SourceLocation { return ih.Loc }
+func (TSInterfaceHeritage) MinVersion() estree.Version         { return estree.ES5 }
+
+func (ih TSInterfaceHeritage) IsZero() bool {
+	return ih.Loc.IsZero() &&
+		(ih.Expression == nil || ih.Expression.IsZero()) &&
+		ih.TypeArguments.IsZero()
+}
+
+func (ih TSInterfaceHeritage) Walk(v estree.Visitor) {
+	if v = v.Visit(ih); v != nil {
+		defer v.Visit(nil)
+		if ih.Expression != nil {
+			ih.Expression.Walk(v)
+		}
+		if !ih.TypeArguments.IsZero() {
+			ih.TypeArguments.Walk(v)
+		}
+	}
+}
+
+func (ih TSInterfaceHeritage) Errors() []error {
+	c := estree.NewChecker(ih)
+	c.Require(ih.Expression, "base interface")
+	switch ih.Expression.(type) {
+	case nil, estree.Identifier, estree.MemberExpression:
+	default:
+		c.Appendf("%w base interface %s", estree.ErrWrongValue, ih.Expression.Type())
+	}
+	c.Optional(ih.TypeArguments)
+	return c.Errors()
+}
+
+func (ih TSInterfaceHeritage) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ih)
+	x["expression"] = ih.Expression
+	if ih.TypeArguments.IsZero() {
+		x["typeArguments"] = nil
+	} else {
+		x["typeArguments"] = ih.TypeArguments
+	}
+	return json.Marshal(x)
+}
+
+func (ih *TSInterfaceHeritage) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type          string                       `json:"type"`
+		Expression    json.RawMessage              `json:"expression"`
+		TypeArguments TSTypeParameterInstantiation `json:"typeArguments"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ih.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ih.Type(), x.Type)
+	}
+	if err == nil {
+		ih.Loc, ih.TypeArguments = x.Location(), x.TypeArguments
+		ih.Expression, err = estree.UnmarshalExpression(x.Expression)
+	}
+	return err
+}
+
+// TSInterfaceBody is the body of a TSInterfaceDeclaration.
+type TSInterfaceBody struct {
+	Loc  estree.SourceLocation
+	Body []TSTypeElement
+}
+
+func (TSInterfaceBody) Type() string                       { return "TSInterfaceBody" }
+func (ib TSInterfaceBody) Location() estree.SourceLocation { return ib.Loc }
+func (TSInterfaceBody) MinVersion() estree.Version         { return estree.ES5 }
+func (TSInterfaceBody) IsZero() bool                       { return false }
+
+func (ib TSInterfaceBody) Walk(v estree.Visitor) {
+	if v = v.Visit(ib); v != nil {
+		defer v.Visit(nil)
+		for _, e := range ib.Body {
+			if e != nil {
+				e.Walk(v)
+			}
+		}
+	}
+}
+
+func (ib TSInterfaceBody) Errors() []error {
+	c := estree.NewChecker(ib)
+	c.RequireEach(len(ib.Body), func(i int) estree.Node { return ib.Body[i] }, "interface member")
+	return c.Errors()
+}
+
+func (ib TSInterfaceBody) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ib)
+	if len(ib.Body) == 0 {
+		x["body"] = []TSTypeElement{}
+	} else {
+		x["body"] = ib.Body
+	}
+	return json.Marshal(x)
+}
+
+func (ib *TSInterfaceBody) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type string            `json:"type"`
+		Body []json.RawMessage `json:"body"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ib.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ib.Type(), x.Type)
+	}
+	if err == nil {
+		ib.Loc = x.Location()
+		ib.Body, err = unmarshalTypeElements(x.Body)
+	}
+	return err
+}
+
+// TSTypeAliasDeclaration declares a named type, e.g. type Foo = string.
+type TSTypeAliasDeclaration struct {
+	estree.BaseDeclaration
+	Loc            estree.SourceLocation
+	ID             estree.Identifier
+	TypeParameters TSTypeParameterDeclaration // possibly zero
+	TypeAnnotation TSType
+
+	// Declare indicates the type was declared with the declare modifier.
+	Declare bool
+}
+
+func (TSTypeAliasDeclaration) Type() string                        { return "TSTypeAliasDeclaration" }
+func (tad TSTypeAliasDeclaration) Location() estree.SourceLocation { return tad.Loc }
+
+func (tad TSTypeAliasDeclaration) IsZero() bool {
+	return tad.Loc.IsZero() &&
+		tad.ID.IsZero() &&
+		tad.TypeParameters.IsZero() &&
+		(tad.TypeAnnotation == nil || tad.TypeAnnotation.IsZero()) &&
+		!tad.Declare
+}
+
+func (tad TSTypeAliasDeclaration) Walk(v estree.Visitor) {
+	if v = v.Visit(tad); v != nil {
+		defer v.Visit(nil)
+		tad.ID.Walk(v)
+		if !tad.TypeParameters.IsZero() {
+			tad.TypeParameters.Walk(v)
+		}
+		if tad.TypeAnnotation != nil {
+			tad.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (tad TSTypeAliasDeclaration) Errors() []error {
+	c := estree.NewChecker(tad)
+	c.Require(tad.ID, "type name")
+	c.Optional(tad.TypeParameters)
+	c.Require(tad.TypeAnnotation, "type")
+	return c.Errors()
+}
+
+func (tad TSTypeAliasDeclaration) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(tad)
+	x["id"] = tad.ID
+	x["typeParameters"] = typeParametersToJSON(tad.TypeParameters)
+	x["typeAnnotation"] = tad.TypeAnnotation
+	x["declare"] = tad.Declare
+	return json.Marshal(x)
+}
+
+func (tad *TSTypeAliasDeclaration) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string                     `json:"type"`
+		ID             estree.Identifier          `json:"id"`
+		TypeParameters TSTypeParameterDeclaration `json:"typeParameters"`
+		TypeAnnotation json.RawMessage            `json:"typeAnnotation"`
+		Declare        bool                       `json:"declare"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tad.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tad.Type(), x.Type)
+	}
+	if err == nil {
+		tad.Loc, tad.ID, tad.TypeParameters = x.Location(), x.ID, x.TypeParameters
+		tad.Declare = x.Declare
+		tad.TypeAnnotation, err = unmarshalType(x.TypeAnnotation)
+	}
+	return err
+}
+
+// TSEnumDeclaration declares an enum, e.g. enum Color { Red, Green = 2 }.
+type TSEnumDeclaration struct {
+	estree.BaseDeclaration
+	Loc     estree.SourceLocation
+	ID      estree.Identifier
+	Members []TSEnumMember
+
+	// Const indicates a const enum, whose members are inlined.
+	Const bool
+
+	// Declare indicates the enum was declared with the declare modifier.
+	Declare bool
+}
+
+func (TSEnumDeclaration) Type() string                       { return "TSEnumDeclaration" }
+func (ed TSEnumDeclaration) Location() estree.SourceLocation { return ed.Loc }
+
+func (ed TSEnumDeclaration) IsZero() bool {
+	return ed.Loc.IsZero() &&
+		ed.ID.IsZero() &&
+		len(ed.Members) == 0 &&
+		!ed.Const &&
+		!ed.Declare
+}
+
+func (ed TSEnumDeclaration) Walk(v estree.Visitor) {
+	if v = v.Visit(ed); v != nil {
+		defer v.Visit(nil)
+		ed.ID.Walk(v)
+		for _, m := range ed.Members {
+			m.Walk(v)
+		}
+	}
+}
+
+func (ed TSEnumDeclaration) Errors() []error {
+	c := estree.NewChecker(ed)
+	c.Require(ed.ID, "enum name")
+	c.RequireEach(len(ed.Members), func(i int) estree.Node { return ed.Members[i] }, "enum member")
+	return c.Errors()
+}
+
+func (ed TSEnumDeclaration) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ed)
+	x["id"] = ed.ID
+	if len(ed.Members) == 0 {
+		x["members"] = []TSEnumMember{}
+	} else {
+		x["members"] = ed.Members
+	}
+	x["const"] = ed.Const
+	x["declare"] = ed.Declare
+	return json.Marshal(x)
+}
+
+func (ed *TSEnumDeclaration) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type    string            `json:"type"`
+		ID      estree.Identifier `json:"id"`
+		Members []TSEnumMember    `json:"members"`
+		Const   bool              `json:"const"`
+		Declare bool              `json:"declare"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ed.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ed.Type(), x.Type)
+	}
+	if err == nil {
+		ed.Loc, ed.ID = x.Location(), x.ID
+		ed.Const, ed.Declare = x.Const, x.Declare
+		if len(x.Members) == 0 {
+			ed.Members = nil
+		} else {
+			ed.Members = x.Members
+		}
+	}
+	return err
+}
+
+// TSEnumMember is a member of a TSEnumDeclaration, e.g. Green = 2.
+type TSEnumMember struct {
+	Loc         estree.SourceLocation
+	ID          estree.LiteralOrIdentifier // Identifier or StringLiteral
+	Initializer estree.Expression          // or nil
+}
+
+func (TSEnumMember) Type() string                       { return "TSEnumMember" }
+func (em TSEnumMember) Location() estree.SourceLocation { return em.Loc }
+func (TSEnumMember) MinVersion() estree.Version         { return estree.ES5 }
+
+func (em TSEnumMember) IsZero() bool {
+	return em.Loc.IsZero() &&
+		(em.ID == nil || em.ID.IsZero()) &&
+		(em.Initializer == nil || em.Initializer.IsZero())
+}
+
+func (em TSEnumMember) Walk(v estree.Visitor) {
+	if v = v.Visit(em); v != nil {
+		defer v.Visit(nil)
+		if em.ID != nil {
+			em.ID.Walk(v)
+		}
+		if em.Initializer != nil {
+			em.Initializer.Walk(v)
+		}
+	}
+}
+
+func (em TSEnumMember) Errors() []error {
+	c := estree.NewChecker(em)
+	c.Require(em.ID, "enum member name")
+	switch em.ID.(type) {
+	case nil, estree.Identifier, estree.StringLiteral:
+	default:
+		c.Appendf("%w enum member name %s", estree.ErrWrongValue, em.ID.Type())
+	}
+	c.Optional(em.Initializer)
+	return c.Errors()
+}
+
+func (em TSEnumMember) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(em)
+	x["id"] = em.ID
+	if em.Initializer != nil {
+		x["initializer"] = em.Initializer
+	}
+	return json.Marshal(x)
+}
+
+func (em *TSEnumMember) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type        string          `json:"type"`
+		ID          json.RawMessage `json:"id"`
+		Initializer json.RawMessage `json:"initializer"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != em.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, em.Type(), x.Type)
+	}
+	if err == nil {
+		em.Loc = x.Location()
+		var id estree.Expression
+		if id, err = estree.UnmarshalExpression(x.ID); err == nil && id != nil {
+			var ok bool
+			if em.ID, ok = id.(estree.LiteralOrIdentifier); !ok {
+				err = fmt.Errorf("%w Identifier or Literal, got %v", estree.ErrWrongType, string(x.ID))
+			}
+		}
+		var err2 error
+		if em.Initializer, err2 = estree.UnmarshalExpression(x.Initializer); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// TSDeclareFunction declares a function without a body, e.g. the overload
+// signatures in function f(x: string): void; function f(x: number): void;
+// or declare function g(): void.
+type TSDeclareFunction struct {
+	estree.BaseDeclaration
+	Loc            estree.SourceLocation
+	ID             estree.Identifier          // possibly zero
+	TypeParameters TSTypeParameterDeclaration // possibly zero
+	Params         []estree.Pattern
+	ReturnType     TSTypeAnnotation // possibly zero
+	Async          bool
+	Generator      bool
+
+	// Declare indicates the function was declared with the declare
+	// modifier.
+	Declare bool
+}
+
+func (TSDeclareFunction) Type() string                       { return "TSDeclareFunction" }
+func (df TSDeclareFunction) Location() estree.SourceLocation { return df.Loc }
+
+func (df TSDeclareFunction) IsZero() bool {
+	return df.Loc.IsZero() &&
+		df.ID.IsZero() &&
+		df.TypeParameters.IsZero() &&
+		len(df.Params) == 0 &&
+		df.ReturnType.IsZero() &&
+		!df.Async &&
+		!df.Generator &&
+		!df.Declare
+}
+
+func (df TSDeclareFunction) Walk(v estree.Visitor) {
+	if v = v.Visit(df); v != nil {
+		defer v.Visit(nil)
+		if !df.ID.IsZero() {
+			df.ID.Walk(v)
+		}
+		if !df.TypeParameters.IsZero() {
+			df.TypeParameters.Walk(v)
+		}
+		for _, p := range df.Params {
+			if p != nil {
+				p.Walk(v)
+			}
+		}
+		if !df.ReturnType.IsZero() {
+			df.ReturnType.Walk(v)
+		}
+	}
+}
+
+func (df TSDeclareFunction) Errors() []error {
+	c := estree.NewChecker(df)
+	c.Optional(df.ID)
+	c.Optional(df.TypeParameters)
+	c.RequireEach(len(df.Params), func(i int) estree.Node { return df.Params[i] }, "function parameter")
+	c.Optional(df.ReturnType)
+	return c.Errors()
+}
+
+func (df TSDeclareFunction) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(df)
+	if df.ID.IsZero() {
+		x["id"] = nil
+	} else {
+		x["id"] = df.ID
+	}
+	x["typeParameters"] = typeParametersToJSON(df.TypeParameters)
+	if len(df.Params) == 0 {
+		x["params"] = []estree.Pattern{}
+	} else {
+		x["params"] = df.Params
+	}
+	if df.ReturnType.IsZero() {
+		x["returnType"] = nil
+	} else {
+		x["returnType"] = df.ReturnType
+	}
+	x["async"] = df.Async
+	x["generator"] = df.Generator
+	x["declare"] = df.Declare
+	return json.Marshal(x)
+}
+
+func (df *TSDeclareFunction) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string                     `json:"type"`
+		ID             estree.Identifier          `json:"id"`
+		TypeParameters TSTypeParameterDeclaration `json:"typeParameters"`
+		Params         []json.RawMessage          `json:"params"`
+		ReturnType     TSTypeAnnotation           `json:"returnType"`
+		Async          bool                       `json:"async"`
+		Generator      bool                       `json:"generator"`
+		Declare        bool                       `json:"declare"`
+		Body           json.RawMessage            `json:"body"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != df.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, df.Type(), x.Type)
+	}
+	if err == nil && !isNull(x.Body) {
+		err = fmt.Errorf("TSDeclareFunction body %w", estree.ErrNotAllowed)
+	}
+	if err == nil {
+		df.Loc, df.ID, df.TypeParameters = x.Location(), x.ID, x.TypeParameters
+		df.ReturnType, df.Async, df.Generator = x.ReturnType, x.Async, x.Generator
+		df.Declare = x.Declare
+		if len(x.Params) == 0 {
+			df.Params = nil
+		} else {
+			df.Params = make([]estree.Pattern, len(x.Params))
+			for i := range x.Params {
+				var err2 error
+				df.Params[i], err2 = estree.UnmarshalPattern(x.Params[i])
+				if err == nil && err2 != nil {
+					err = err2
+				}
+			}
+		}
+	}
+	return err
+}
+
+// TSModuleKind is a value for TSModuleDeclaration.Kind, indicating which
+// keyword declared the module.
+type TSModuleKind string
+
+const (
+	GlobalModule   TSModuleKind = "global"    // declare global { ... }
+	ExternalModule TSModuleKind = "module"    // declare module "foo" { ... }
+	Namespace      TSModuleKind = "namespace" // namespace Foo.Bar { ... }
+)
+
+func (mk TSModuleKind) IsValid() bool {
+	switch mk {
+	case GlobalModule, ExternalModule, Namespace:
+		return true
+	}
+	return false
+}
+
+// TSModuleDeclaration declares a namespace or ambient module, e.g.
+// namespace Foo { ... } or declare module "foo";.  A nested namespace
+// declaration, namespace Foo.Bar { ... }, has a TSQualifiedName ID, as
+// produced by typescript-estree 6 and later.
+type TSModuleDeclaration struct {
+	estree.BaseDeclaration
+	Loc  estree.SourceLocation
+	ID   estree.Node    // Identifier, StringLiteral, or TSQualifiedName
+	Body *TSModuleBlock // or nil, e.g. declare module "foo";
+	Kind TSModuleKind
+
+	// Declare indicates the module was declared with the declare modifier.
+	Declare bool
+}
+
+func (TSModuleDeclaration) Type() string                       { return "TSModuleDeclaration" }
+func (md TSModuleDeclaration) Location() estree.SourceLocation { return md.Loc }
+
+func (md TSModuleDeclaration) IsZero() bool {
+	return md.Loc.IsZero() &&
+		(md.ID == nil || md.ID.IsZero()) &&
+		md.Body == nil &&
+		md.Kind == "" &&
+		!md.Declare
+}
+
+func (md TSModuleDeclaration) Walk(v estree.Visitor) {
+	if v = v.Visit(md); v != nil {
+		defer v.Visit(nil)
+		if md.ID != nil {
+			md.ID.Walk(v)
+		}
+		if md.Body != nil {
+			md.Body.Walk(v)
+		}
+	}
+}
+
+func (md TSModuleDeclaration) Errors() []error {
+	c := estree.NewChecker(md)
+	c.Require(md.ID, "module name")
+	if !md.Kind.IsValid() {
+		c.Appendf("%w TSModuleDeclaration.Kind %q", estree.ErrWrongValue, md.Kind)
+	}
+	switch md.ID.(type) {
+	case nil:
+	case estree.StringLiteral:
+		if md.Kind != ExternalModule {
+			c.Appendf("string name of %s %w", md.Kind, estree.ErrNotAllowed)
+		}
+	default:
+		checkEntityName(c, md.ID, "module name")
+	}
+	if md.Body != nil {
+		c.Optional(*md.Body)
+	} else if md.Kind != ExternalModule {
+		c.Appendf("%w %s body", estree.ErrMissingNode, md.Kind)
+	}
+	return c.Errors()
+}
+
+func (md TSModuleDeclaration) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(md)
+	x["id"] = md.ID
+	if md.Body != nil {
+		x["body"] = md.Body
+	}
+	x["kind"] = md.Kind
+	x["declare"] = md.Declare
+	return json.Marshal(x)
+}
+
+func (md *TSModuleDeclaration) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type    string          `json:"type"`
+		ID      json.RawMessage `json:"id"`
+		Body    *TSModuleBlock  `json:"body"`
+		Kind    TSModuleKind    `json:"kind"`
+		Declare bool            `json:"declare"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != md.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, md.Type(), x.Type)
+	}
+	if err == nil {
+		md.Loc, md.Body, md.Kind, md.Declare = x.Location(), x.Body, x.Kind, x.Declare
+		md.ID, err = unmarshalModuleName(x.ID)
+	}
+	return err
+}
+
+// unmarshalModuleName decodes the ID of a TSModuleDeclaration, which is
+// either a StringLiteral or an entity name.
+func unmarshalModuleName(m json.RawMessage) (estree.Node, error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(m, &x)
+	if err == nil && x.Type == "Literal" {
+		var l estree.Literal
+		if l, err = estree.UnmarshalLiteral(m); err == nil {
+			if sl, ok := l.(estree.StringLiteral); ok {
+				return sl, nil
+			}
+			err = fmt.Errorf("%w StringLiteral, got %v", estree.ErrWrongType, string(m))
+		}
+		return nil, err
+	}
+	if err == nil {
+		return unmarshalEntityName(m)
+	}
+	return nil, err
+}
+
+// TSModuleBlock is the body of a TSModuleDeclaration.
+type TSModuleBlock struct {
+	Loc  estree.SourceLocation
+	Body []estree.DirectiveOrStatement
+}
+
+func (TSModuleBlock) Type() string                       { return "TSModuleBlock" }
+func (mb TSModuleBlock) Location() estree.SourceLocation { return mb.Loc }
+func (TSModuleBlock) MinVersion() estree.Version         { return estree.ES5 }
+func (TSModuleBlock) IsZero() bool                       { return false }
+
+func (mb TSModuleBlock) Walk(v estree.Visitor) {
+	if v = v.Visit(mb); v != nil {
+		defer v.Visit(nil)
+		for _, s := range mb.Body {
+			if s != nil {
+				s.Walk(v)
+			}
+		}
+	}
+}
+
+func (mb TSModuleBlock) Errors() []error {
+	c := estree.NewChecker(mb)
+	c.RequireEach(len(mb.Body), func(i int) estree.Node { return mb.Body[i] }, "statement")
+	return c.Errors()
+}
+
+func (mb TSModuleBlock) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(mb)
+	if len(mb.Body) == 0 {
+		x["body"] = []estree.DirectiveOrStatement{}
+	} else {
+		x["body"] = mb.Body
+	}
+	return json.Marshal(x)
+}
+
+func (mb *TSModuleBlock) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type string            `json:"type"`
+		Body []json.RawMessage `json:"body"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != mb.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, mb.Type(), x.Type)
+	}
+	if err == nil {
+		mb.Loc = x.Location()
+		if len(x.Body) == 0 {
+			mb.Body = nil
+		} else {
+			mb.Body = make([]estree.DirectiveOrStatement, len(x.Body))
+			for i := range x.Body {
+				var err2 error
+				mb.Body[i], err2 = estree.UnmarshalDirectiveOrStatement(x.Body[i])
+				if err == nil && err2 != nil {
+					err = err2
+				}
+			}
+		}
+	}
+	return err
+}
@@ -0,0 +1,324 @@
+package ts
+
+import (
+	"encoding/json"
+	"testing"
+
+	"pifke.org/estree"
+)
+
+func TestTSInterfaceDeclaration(t *testing.T) {
+	var id TSInterfaceDeclaration
+	if !id.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// interface Foo<T extends object = {}> extends Bar<T> { baz: T }
+	id.ID = estree.Identifier{Name: "Foo"}
+	id.TypeParameters = TSTypeParameterDeclaration{
+		Params: []TSTypeParameter{
+			TSTypeParameter{
+				Name:       estree.Identifier{Name: "T"},
+				Constraint: TSKeyword{Kind: Object},
+				Default:    TSTypeLiteral{},
+			},
+		},
+	}
+	id.Extends = []TSInterfaceHeritage{
+		TSInterfaceHeritage{
+			Expression: estree.Identifier{Name: "Bar"},
+			TypeArguments: TSTypeParameterInstantiation{
+				Params: []TSType{TSTypeReference{TypeName: estree.Identifier{Name: "T"}}},
+			},
+		},
+	}
+	id.Body.Body = []TSTypeElement{
+		TSPropertySignature{
+			Key: estree.Identifier{Name: "baz"},
+			TypeAnnotation: TSTypeAnnotation{
+				TypeAnnotation: TSTypeReference{TypeName: estree.Identifier{Name: "T"}},
+			},
+		},
+	}
+	if id.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if id.MinVersion() != estree.ES5 {
+		t.Errorf("expected ES5, got %s", id.MinVersion())
+	}
+
+	tp := id.TypeParameters.Params[0]
+	ps := id.Body.Body[0].(TSPropertySignature)
+	var v mockVisitor
+	id.Walk(&v)
+	v.expect(t, id,
+		id.ID, nil,
+		id.TypeParameters, tp, tp.Name, nil, tp.Constraint, nil, tp.Default, nil, nil, nil,
+		id.Extends[0], id.Extends[0].Expression, nil,
+		id.Extends[0].TypeArguments, id.Extends[0].TypeArguments.Params[0],
+		estree.Identifier{Name: "T"}, nil, nil, nil, nil,
+		id.Body, ps, ps.Key, nil, ps.TypeAnnotation, ps.TypeAnnotation.TypeAnnotation,
+		estree.Identifier{Name: "T"}, nil, nil, nil, nil, nil, nil)
+
+	testRoundtripJSON(t, id, new(TSInterfaceDeclaration))
+
+	if errs := estree.Validate(id); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	id.Extends[0].Expression = estree.CallExpression{Callee: estree.Identifier{Name: "Bar"}}
+	if !hasError(estree.ErrWrongValue, id.Extends[0].Errors()...) {
+		t.Error("expected ErrWrongValue for CallExpression heritage")
+	}
+	id.TypeParameters.Params[0] = TSTypeParameter{}
+	if !hasError(estree.ErrMissingNode, id.TypeParameters.Errors()...) {
+		t.Error("expected ErrMissingNode for zero type parameter")
+	}
+	id.ID = estree.Identifier{}
+	if !hasError(estree.ErrMissingNode, id.Errors()...) {
+		t.Error("expected ErrMissingNode for zero ID")
+	}
+}
+
+func TestTSTypeAliasDeclaration(t *testing.T) {
+	var tad TSTypeAliasDeclaration
+	if !tad.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// declare type Foo = string | number
+	tad.ID = estree.Identifier{Name: "Foo"}
+	tad.TypeAnnotation = TSUnionType{
+		Types: []TSType{TSKeyword{Kind: String}, TSKeyword{Kind: Number}},
+	}
+	tad.Declare = true
+	if tad.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	var v mockVisitor
+	tad.Walk(&v)
+	v.expect(t, tad,
+		tad.ID, nil,
+		tad.TypeAnnotation,
+		tad.TypeAnnotation.(TSUnionType).Types[0], nil,
+		tad.TypeAnnotation.(TSUnionType).Types[1], nil, nil, nil)
+
+	testRoundtripJSON(t, tad, new(TSTypeAliasDeclaration))
+
+	if errs := estree.Validate(tad); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	tad.TypeAnnotation = nil
+	if !hasError(estree.ErrMissingNode, tad.Errors()...) {
+		t.Error("expected ErrMissingNode for nil TypeAnnotation")
+	}
+}
+
+func TestTSEnumDeclaration(t *testing.T) {
+	var ed TSEnumDeclaration
+	if !ed.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// const enum Color { Red, "Green" = 2 }
+	ed.ID = estree.Identifier{Name: "Color"}
+	ed.Members = []TSEnumMember{
+		TSEnumMember{ID: estree.Identifier{Name: "Red"}},
+		TSEnumMember{
+			ID:          estree.StringLiteral{Value: "Green"},
+			Initializer: estree.NumberLiteral{Value: 2},
+		},
+	}
+	ed.Const = true
+	if ed.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	var v mockVisitor
+	ed.Walk(&v)
+	v.expect(t, ed,
+		ed.ID, nil,
+		ed.Members[0], ed.Members[0].ID, nil, nil,
+		ed.Members[1], ed.Members[1].ID, nil, ed.Members[1].Initializer, nil, nil, nil)
+
+	testRoundtripJSON(t, ed, new(TSEnumDeclaration))
+
+	if errs := estree.Validate(ed); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ed.Members[1].ID = estree.NumberLiteral{Value: 1}
+	if !hasError(estree.ErrWrongValue, ed.Members[1].Errors()...) {
+		t.Error("expected ErrWrongValue for NumberLiteral member name")
+	}
+	ed.Members[1] = TSEnumMember{}
+	if !hasError(estree.ErrMissingNode, ed.Errors()...) {
+		t.Error("expected ErrMissingNode for zero member")
+	}
+}
+
+func TestDeclarationDispatch(t *testing.T) {
+	b := []byte(`{"type":"Program","sourceType":"module","body":[` +
+		`{"type":"ExportNamedDeclaration","declaration":{"type":"TSTypeAliasDeclaration",` +
+		`"id":{"type":"Identifier","name":"Foo"},"typeParameters":null,` +
+		`"typeAnnotation":{"type":"TSStringKeyword"},"declare":false},"specifiers":[],"source":null},` +
+		`{"type":"TSEnumDeclaration","id":{"type":"Identifier","name":"Bar"},"members":[],` +
+		`"const":false,"declare":true}]}`)
+	var p estree.Program
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatal(err)
+	}
+	end := p.Body[0].(estree.ExportNamedDeclaration)
+	if tad, ok := end.Declaration.(TSTypeAliasDeclaration); !ok || tad.ID.Name != "Foo" {
+		t.Errorf("expected TSTypeAliasDeclaration, got %#v", end.Declaration)
+	}
+	if ed, ok := p.Body[1].(TSEnumDeclaration); !ok || !ed.Declare {
+		t.Errorf("expected TSEnumDeclaration, got %#v", p.Body[1])
+	}
+	if errs := estree.Validate(p); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestTSDeclareFunction(t *testing.T) {
+	var df TSDeclareFunction
+	if !df.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// declare function f<T>(x: T): void;
+	df.ID = estree.Identifier{Name: "f"}
+	df.TypeParameters = TSTypeParameterDeclaration{
+		Params: []TSTypeParameter{
+			TSTypeParameter{Name: estree.Identifier{Name: "T"}},
+		},
+	}
+	df.Params = []estree.Pattern{
+		estree.Identifier{
+			Name: "x",
+			TypeAnnotation: TSTypeAnnotation{
+				TypeAnnotation: TSTypeReference{TypeName: estree.Identifier{Name: "T"}},
+			},
+		},
+	}
+	df.ReturnType = TSTypeAnnotation{TypeAnnotation: TSKeyword{Kind: Void}}
+	df.Declare = true
+	if df.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if df.MinVersion() != estree.ES5 {
+		t.Errorf("expected ES5, got %s", df.MinVersion())
+	}
+
+	tp := df.TypeParameters.Params[0]
+	x := df.Params[0].(estree.Identifier)
+	xt := x.TypeAnnotation.(TSTypeAnnotation)
+	var v mockVisitor
+	df.Walk(&v)
+	v.expect(t, df,
+		df.ID, nil,
+		df.TypeParameters, tp, tp.Name, nil, nil, nil,
+		x, xt, xt.TypeAnnotation, estree.Identifier{Name: "T"}, nil, nil, nil, nil,
+		df.ReturnType, df.ReturnType.TypeAnnotation, nil, nil, nil)
+
+	testRoundtripJSON(t, df, new(TSDeclareFunction))
+
+	if errs := df.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	df.Params[0] = estree.Identifier{}
+	if !hasError(estree.ErrMissingNode, df.Errors()...) {
+		t.Error("expected ErrMissingNode for zero parameter")
+	}
+
+	b := []byte(`{"type":"TSDeclareFunction","id":{"type":"Identifier","name":"f"},"params":[],` +
+		`"body":{"type":"BlockStatement","body":[]}}`)
+	if err := json.Unmarshal(b, &df); !hasError(estree.ErrNotAllowed, err) {
+		t.Errorf("expected ErrNotAllowed for body, got %v", err)
+	}
+}
+
+func TestTSModuleDeclaration(t *testing.T) {
+	var md TSModuleDeclaration
+	if !md.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// namespace Foo.Bar { export const x = 1; }
+	md.ID = TSQualifiedName{
+		Left:  estree.Identifier{Name: "Foo"},
+		Right: estree.Identifier{Name: "Bar"},
+	}
+	md.Kind = Namespace
+	md.Body = &TSModuleBlock{
+		Body: []estree.DirectiveOrStatement{
+			estree.ExportNamedDeclaration{
+				Declaration: estree.VariableDeclaration{
+					Kind: estree.Const,
+					Declarations: []estree.VariableDeclarator{
+						estree.VariableDeclarator{
+							ID:   estree.Identifier{Name: "x"},
+							Init: estree.NumberLiteral{Value: 1},
+						},
+					},
+				},
+			},
+		},
+	}
+	if md.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if md.MinVersion() != estree.ES5 {
+		t.Errorf("expected ES5, got %s", md.MinVersion())
+	}
+
+	qn := md.ID.(TSQualifiedName)
+	end := md.Body.Body[0].(estree.ExportNamedDeclaration)
+	vd := end.Declaration.(estree.VariableDeclaration)
+	var v mockVisitor
+	md.Walk(&v)
+	v.expect(t, md,
+		qn, qn.Left, nil, qn.Right, nil, nil,
+		*md.Body, end, vd, vd.Declarations[0],
+		vd.Declarations[0].ID, nil, vd.Declarations[0].Init, nil, nil, nil, nil, nil, nil)
+
+	testRoundtripJSON(t, md, new(TSModuleDeclaration))
+
+	if errs := estree.Validate(md); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	md.Kind = "package"
+	if !hasError(estree.ErrWrongValue, md.Errors()...) {
+		t.Error("expected ErrWrongValue for invalid Kind")
+	}
+
+	// declare module "foo";
+	md = TSModuleDeclaration{
+		ID:      estree.StringLiteral{Value: "foo"},
+		Kind:    ExternalModule,
+		Declare: true,
+	}
+	testRoundtripJSON(t, md, new(TSModuleDeclaration))
+	if errs := md.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	md.Kind = Namespace
+	if !hasError(estree.ErrNotAllowed, md.Errors()...) {
+		t.Error("expected ErrNotAllowed for namespace with string name")
+	}
+	if !hasError(estree.ErrMissingNode, md.Errors()...) {
+		t.Error("expected ErrMissingNode for namespace without body")
+	}
+
+	// namespace Foo {}
+	b := []byte(`{"type":"TSModuleDeclaration","id":{"type":"Identifier","name":"Foo"},` +
+		`"body":{"type":"TSModuleBlock","body":[]},"kind":"namespace","declare":false}`)
+	if err := json.Unmarshal(b, &md); err != nil {
+		t.Fatal(err)
+	}
+	if md.Body == nil {
+		t.Error("expected non-nil Body for empty namespace")
+	}
+	if errs := md.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
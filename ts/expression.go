@@ -0,0 +1,262 @@
+package ts
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"pifke.org/estree"
+)
+
+// TSAsExpression is a type assertion, e.g. x as string.
+type TSAsExpression struct {
+	estree.BaseExpression
+	Loc            estree.SourceLocation
+	Expression     estree.Expression
+	TypeAnnotation TSType
+}
+
+func (TSAsExpression) Type() string                       { return "TSAsExpression" }
+func (ae TSAsExpression) Location() estree.SourceLocation { return ae.Loc }
+
+func (ae TSAsExpression) IsZero() bool {
+	return ae.Loc.IsZero() &&
+		(ae.Expression == nil || ae.Expression.IsZero()) &&
+		(ae.TypeAnnotation == nil || ae.TypeAnnotation.IsZero())
+}
+
+func (ae TSAsExpression) Walk(v estree.Visitor) {
+	if v = v.Visit(ae); v != nil {
+		defer v.Visit(nil)
+		if ae.Expression != nil {
+			ae.Expression.Walk(v)
+		}
+		if ae.TypeAnnotation != nil {
+			ae.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (ae TSAsExpression) Errors() []error {
+	c := estree.NewChecker(ae)
+	c.Require(ae.Expression, "expression")
+	c.Require(ae.TypeAnnotation, "asserted type")
+	return c.Errors()
+}
+
+func (ae TSAsExpression) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ae)
+	x["expression"] = ae.Expression
+	x["typeAnnotation"] = ae.TypeAnnotation
+	return json.Marshal(x)
+}
+
+func (ae *TSAsExpression) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string          `json:"type"`
+		Expression     json.RawMessage `json:"expression"`
+		TypeAnnotation json.RawMessage `json:"typeAnnotation"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ae.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ae.Type(), x.Type)
+	}
+	if err == nil {
+		ae.Loc = x.Location()
+		ae.Expression, err = estree.UnmarshalExpression(x.Expression)
+		var err2 error
+		if ae.TypeAnnotation, err2 = unmarshalType(x.TypeAnnotation); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// TSTypeAssertion is an angle-bracket type assertion, e.g. <string>x.
+type TSTypeAssertion struct {
+	estree.BaseExpression
+	Loc            estree.SourceLocation
+	TypeAnnotation TSType
+	Expression     estree.Expression
+}
+
+func (TSTypeAssertion) Type() string                       { return "TSTypeAssertion" }
+func (ta TSTypeAssertion) Location() estree.SourceLocation { return ta.Loc }
+
+func (ta TSTypeAssertion) IsZero() bool {
+	return ta.Loc.IsZero() &&
+		(ta.TypeAnnotation == nil || ta.TypeAnnotation.IsZero()) &&
+		(ta.Expression == nil || ta.Expression.IsZero())
+}
+
+func (ta TSTypeAssertion) Walk(v estree.Visitor) {
+	if v = v.Visit(ta); v != nil {
+		defer v.Visit(nil)
+		if ta.TypeAnnotation != nil {
+			ta.TypeAnnotation.Walk(v)
+		}
+		if ta.Expression != nil {
+			ta.Expression.Walk(v)
+		}
+	}
+}
+
+func (ta TSTypeAssertion) Errors() []error {
+	c := estree.NewChecker(ta)
+	c.Require(ta.TypeAnnotation, "asserted type")
+	c.Require(ta.Expression, "expression")
+	return c.Errors()
+}
+
+func (ta TSTypeAssertion) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ta)
+	x["typeAnnotation"] = ta.TypeAnnotation
+	x["expression"] = ta.Expression
+	return json.Marshal(x)
+}
+
+func (ta *TSTypeAssertion) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string          `json:"type"`
+		TypeAnnotation json.RawMessage `json:"typeAnnotation"`
+		Expression     json.RawMessage `json:"expression"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ta.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ta.Type(), x.Type)
+	}
+	if err == nil {
+		ta.Loc = x.Location()
+		ta.TypeAnnotation, err = unmarshalType(x.TypeAnnotation)
+		var err2 error
+		if ta.Expression, err2 = estree.UnmarshalExpression(x.Expression); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// TSSatisfiesExpression checks an expression against a type without
+// changing its inferred type, e.g. x satisfies Foo.
+type TSSatisfiesExpression struct {
+	estree.BaseExpression
+	Loc            estree.SourceLocation
+	Expression     estree.Expression
+	TypeAnnotation TSType
+}
+
+func (TSSatisfiesExpression) Type() string                       { return "TSSatisfiesExpression" }
+func (se TSSatisfiesExpression) Location() estree.SourceLocation { return se.Loc }
+
+func (se TSSatisfiesExpression) IsZero() bool {
+	return se.Loc.IsZero() &&
+		(se.Expression == nil || se.Expression.IsZero()) &&
+		(se.TypeAnnotation == nil || se.TypeAnnotation.IsZero())
+}
+
+func (se TSSatisfiesExpression) Walk(v estree.Visitor) {
+	if v = v.Visit(se); v != nil {
+		defer v.Visit(nil)
+		if se.Expression != nil {
+			se.Expression.Walk(v)
+		}
+		if se.TypeAnnotation != nil {
+			se.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (se TSSatisfiesExpression) Errors() []error {
+	c := estree.NewChecker(se)
+	c.Require(se.Expression, "expression")
+	c.Require(se.TypeAnnotation, "satisfied type")
+	return c.Errors()
+}
+
+func (se TSSatisfiesExpression) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(se)
+	x["expression"] = se.Expression
+	x["typeAnnotation"] = se.TypeAnnotation
+	return json.Marshal(x)
+}
+
+func (se *TSSatisfiesExpression) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string          `json:"type"`
+		Expression     json.RawMessage `json:"expression"`
+		TypeAnnotation json.RawMessage `json:"typeAnnotation"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != se.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, se.Type(), x.Type)
+	}
+	if err == nil {
+		se.Loc = x.Location()
+		se.Expression, err = estree.UnmarshalExpression(x.Expression)
+		var err2 error
+		if se.TypeAnnotation, err2 = unmarshalType(x.TypeAnnotation); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// TSNonNullExpression asserts an expression is not null or undefined, e.g.
+// x!.
+type TSNonNullExpression struct {
+	estree.BaseExpression
+	Loc        estree.SourceLocation
+	Expression estree.Expression
+}
+
+func (TSNonNullExpression) Type() string                        { return "TSNonNullExpression" }
+func (nne TSNonNullExpression) Location() estree.SourceLocation { return nne.Loc }
+
+func (nne TSNonNullExpression) IsZero() bool {
+	return nne.Loc.IsZero() &&
+		(nne.Expression == nil || nne.Expression.IsZero())
+}
+
+func (nne TSNonNullExpression) Walk(v estree.Visitor) {
+	if v = v.Visit(nne); v != nil {
+		defer v.Visit(nil)
+		if nne.Expression != nil {
+			nne.Expression.Walk(v)
+		}
+	}
+}
+
+func (nne TSNonNullExpression) Errors() []error {
+	c := estree.NewChecker(nne)
+	c.Require(nne.Expression, "expression")
+	return c.Errors()
+}
+
+func (nne TSNonNullExpression) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(nne)
+	x["expression"] = nne.Expression
+	return json.Marshal(x)
+}
+
+func (nne *TSNonNullExpression) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type       string          `json:"type"`
+		Expression json.RawMessage `json:"expression"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != nne.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, nne.Type(), x.Type)
+	}
+	if err == nil {
+		nne.Loc = x.Location()
+		nne.Expression, err = estree.UnmarshalExpression(x.Expression)
+	}
+	return err
+}
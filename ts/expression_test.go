@@ -0,0 +1,157 @@
+package ts
+
+import (
+	"encoding/json"
+	"testing"
+
+	"pifke.org/estree"
+)
+
+func TestTSAsExpression(t *testing.T) {
+	var ae TSAsExpression
+	if !ae.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	ae.Expression = estree.Identifier{Name: "x"}
+	ae.TypeAnnotation = TSKeyword{Kind: Any}
+	if ae.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if ae.MinVersion() != estree.ES5 {
+		t.Errorf("expected ES5, got %s", ae.MinVersion())
+	}
+
+	var v mockVisitor
+	ae.Walk(&v)
+	v.expect(t, ae, ae.Expression, nil, ae.TypeAnnotation, nil, nil)
+
+	testRoundtripJSON(t, ae, new(TSAsExpression))
+
+	if errs := estree.Validate(ae); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ae.TypeAnnotation = nil
+	if !hasError(estree.ErrMissingNode, ae.Errors()...) {
+		t.Error("expected ErrMissingNode for nil TypeAnnotation")
+	}
+	ae.Expression = nil
+	if !hasError(estree.ErrMissingNode, ae.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Expression")
+	}
+}
+
+func TestTSTypeAssertion(t *testing.T) {
+	var ta TSTypeAssertion
+	if !ta.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// <string>x
+	ta.TypeAnnotation = TSKeyword{Kind: String}
+	ta.Expression = estree.Identifier{Name: "x"}
+	if ta.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	var v mockVisitor
+	ta.Walk(&v)
+	v.expect(t, ta, ta.TypeAnnotation, nil, ta.Expression, nil, nil)
+
+	testRoundtripJSON(t, ta, new(TSTypeAssertion))
+
+	if errs := estree.Validate(ta); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ta.Expression = nil
+	if !hasError(estree.ErrMissingNode, ta.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Expression")
+	}
+	ta.TypeAnnotation = nil
+	if !hasError(estree.ErrMissingNode, ta.Errors()...) {
+		t.Error("expected ErrMissingNode for nil TypeAnnotation")
+	}
+
+	b := []byte(`{"type":"ExpressionStatement","expression":{"type":"TSTypeAssertion",` +
+		`"typeAnnotation":{"type":"TSThisType"},"expression":{"type":"Identifier","name":"x"}}}`)
+	var es estree.ExpressionStatement
+	if err := json.Unmarshal(b, &es); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := es.Expression.(TSTypeAssertion); !ok {
+		t.Fatalf("expected TSTypeAssertion, got %#v", es.Expression)
+	}
+}
+
+func TestTSSatisfiesExpression(t *testing.T) {
+	var se TSSatisfiesExpression
+	if !se.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	se.Expression = estree.ObjectExpression{}
+	se.TypeAnnotation = TSTypeReference{TypeName: estree.Identifier{Name: "Foo"}}
+	if se.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	var v mockVisitor
+	se.Walk(&v)
+	v.expect(t, se,
+		se.Expression, nil,
+		se.TypeAnnotation, se.TypeAnnotation.(TSTypeReference).TypeName, nil, nil, nil)
+
+	testRoundtripJSON(t, se, new(TSSatisfiesExpression))
+
+	if errs := estree.Validate(se); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	se.TypeAnnotation = TSUnionType{}
+	if !hasError(estree.ErrMissingNode, se.Errors()...) {
+		t.Error("expected ErrMissingNode for zero TypeAnnotation")
+	}
+}
+
+func TestTSNonNullExpression(t *testing.T) {
+	var nne TSNonNullExpression
+	if !nne.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	nne.Expression = estree.Identifier{Name: "x"}
+	if nne.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	var v mockVisitor
+	nne.Walk(&v)
+	v.expect(t, nne, nne.Expression, nil, nil)
+
+	testRoundtripJSON(t, nne, new(TSNonNullExpression))
+
+	if errs := estree.Validate(nne); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	nne.Expression = nil
+	if !hasError(estree.ErrMissingNode, nne.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Expression")
+	}
+
+	// foo!.bar as Baz
+	b := []byte(`{"type":"ExpressionStatement","expression":{"type":"TSAsExpression",` +
+		`"expression":{"type":"MemberExpression","object":{"type":"TSNonNullExpression",` +
+		`"expression":{"type":"Identifier","name":"foo"}},"property":{"type":"Identifier",` +
+		`"name":"bar"},"computed":false,"optional":false},"typeAnnotation":{"type":"TSTypeReference",` +
+		`"typeName":{"type":"Identifier","name":"Baz"}}}}`)
+	var es estree.ExpressionStatement
+	if err := json.Unmarshal(b, &es); err != nil {
+		t.Fatal(err)
+	}
+	ae, ok := es.Expression.(TSAsExpression)
+	if !ok {
+		t.Fatalf("expected TSAsExpression, got %#v", es.Expression)
+	}
+	if _, ok := ae.Expression.(estree.MemberExpression).Object.(TSNonNullExpression); !ok {
+		t.Errorf("expected TSNonNullExpression, got %#v", ae.Expression)
+	}
+}
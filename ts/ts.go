@@ -0,0 +1,177 @@
+// Package ts implements the TypeScript extensions to ESTree, as produced by
+// typescript-estree.
+//
+// Importing this package registers its declarations, expressions, parameter
+// properties, and class members with pifke.org/estree, so that they are
+// decoded from JSON wherever a Statement, Expression, Pattern, or
+// ClassElement is allowed.  Type annotations are attached to estree Nodes
+// (such as Identifier.TypeAnnotation or FunctionDeclaration.ReturnType) using
+// TSTypeAnnotation, and class member modifiers are carried by
+// estree.MethodDefinition and estree.PropertyDefinition.  A method overload
+// without a body has a TSEmptyBodyFunctionExpression as its Value, and the
+// implements clause of a class is a list of TSClassImplements.
+package ts
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"pifke.org/estree"
+)
+
+func init() {
+	estree.RegisterTypeAnnotation("TSTypeAnnotation", func(m json.RawMessage) (estree.TypeAnnotation, error) {
+		var ta TSTypeAnnotation
+		err := json.Unmarshal(m, &ta)
+		return ta, err
+	})
+	estree.RegisterTypeParameterDeclaration("TSTypeParameterDeclaration", func(m json.RawMessage) (estree.TypeParameterDeclaration, error) {
+		var tpd TSTypeParameterDeclaration
+		err := json.Unmarshal(m, &tpd)
+		return tpd, err
+	})
+	estree.RegisterTypeParameterInstantiation("TSTypeParameterInstantiation", func(m json.RawMessage) (estree.TypeParameterInstantiation, error) {
+		var tpi TSTypeParameterInstantiation
+		err := json.Unmarshal(m, &tpi)
+		return tpi, err
+	})
+
+	estree.RegisterStatement("TSInterfaceDeclaration", func(m json.RawMessage) (estree.Statement, error) {
+		var id TSInterfaceDeclaration
+		err := json.Unmarshal(m, &id)
+		return id, err
+	})
+	estree.RegisterStatement("TSTypeAliasDeclaration", func(m json.RawMessage) (estree.Statement, error) {
+		var tad TSTypeAliasDeclaration
+		err := json.Unmarshal(m, &tad)
+		return tad, err
+	})
+	estree.RegisterStatement("TSEnumDeclaration", func(m json.RawMessage) (estree.Statement, error) {
+		var ed TSEnumDeclaration
+		err := json.Unmarshal(m, &ed)
+		return ed, err
+	})
+
+	estree.RegisterStatement("TSDeclareFunction", func(m json.RawMessage) (estree.Statement, error) {
+		var df TSDeclareFunction
+		err := json.Unmarshal(m, &df)
+		return df, err
+	})
+	estree.RegisterStatement("TSModuleDeclaration", func(m json.RawMessage) (estree.Statement, error) {
+		var md TSModuleDeclaration
+		err := json.Unmarshal(m, &md)
+		return md, err
+	})
+
+	estree.RegisterClassImplements("TSClassImplements", func(m json.RawMessage) (estree.ClassImplements, error) {
+		var ci TSClassImplements
+		err := json.Unmarshal(m, &ci)
+		return ci, err
+	})
+	estree.RegisterClassElement("TSAbstractMethodDefinition", func(m json.RawMessage) (estree.ClassElement, error) {
+		var md TSAbstractMethodDefinition
+		err := json.Unmarshal(m, &md)
+		return md, err
+	})
+	estree.RegisterClassElement("TSAbstractPropertyDefinition", func(m json.RawMessage) (estree.ClassElement, error) {
+		var pd TSAbstractPropertyDefinition
+		err := json.Unmarshal(m, &pd)
+		return pd, err
+	})
+	estree.RegisterClassElement("TSIndexSignature", func(m json.RawMessage) (estree.ClassElement, error) {
+		var is TSIndexSignature
+		err := json.Unmarshal(m, &is)
+		return is, err
+	})
+	estree.RegisterMethodValue("TSEmptyBodyFunctionExpression", func(m json.RawMessage) (estree.MethodValue, error) {
+		var fe TSEmptyBodyFunctionExpression
+		err := json.Unmarshal(m, &fe)
+		return fe, err
+	})
+
+	estree.RegisterPattern("TSParameterProperty", func(m json.RawMessage) (estree.Pattern, error) {
+		var pp TSParameterProperty
+		err := json.Unmarshal(m, &pp)
+		return pp, err
+	})
+
+	estree.RegisterExpression("TSAsExpression", func(m json.RawMessage) (estree.Expression, error) {
+		var ae TSAsExpression
+		err := json.Unmarshal(m, &ae)
+		return ae, err
+	})
+	estree.RegisterExpression("TSTypeAssertion", func(m json.RawMessage) (estree.Expression, error) {
+		var ta TSTypeAssertion
+		err := json.Unmarshal(m, &ta)
+		return ta, err
+	})
+	estree.RegisterExpression("TSSatisfiesExpression", func(m json.RawMessage) (estree.Expression, error) {
+		var se TSSatisfiesExpression
+		err := json.Unmarshal(m, &se)
+		return se, err
+	})
+	estree.RegisterExpression("TSNonNullExpression", func(m json.RawMessage) (estree.Expression, error) {
+		var nne TSNonNullExpression
+		err := json.Unmarshal(m, &nne)
+		return nne, err
+	})
+}
+
+// TSTypeAnnotation wraps a TSType, where it is attached to an estree Node,
+// e.g. the ": string" in let x: string.
+type TSTypeAnnotation struct {
+	estree.BaseTypeAnnotation
+	Loc            estree.SourceLocation
+	TypeAnnotation TSType
+}
+
+func (TSTypeAnnotation) Type() string                       { return "TSTypeAnnotation" }
+func (ta TSTypeAnnotation) Location() estree.SourceLocation { return ta.Loc }
+func (TSTypeAnnotation) MinVersion() estree.Version         { return estree.ES5 }
+
+func (ta TSTypeAnnotation) IsZero() bool {
+	return ta.Loc.IsZero() &&
+		(ta.TypeAnnotation == nil || ta.TypeAnnotation.IsZero())
+}
+
+func (ta TSTypeAnnotation) Walk(v estree.Visitor) {
+	if v = v.Visit(ta); v != nil {
+		defer v.Visit(nil)
+		if ta.TypeAnnotation != nil {
+			ta.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (ta TSTypeAnnotation) Errors() []error {
+	c := estree.NewChecker(ta)
+	c.Require(ta.TypeAnnotation, "type")
+	return c.Errors()
+}
+
+func (ta TSTypeAnnotation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ta)
+	x["typeAnnotation"] = ta.TypeAnnotation
+	return json.Marshal(x)
+}
+
+func (ta *TSTypeAnnotation) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		return nil // optional type annotations are null when absent
+	}
+	var x struct {
+		estree.NodeFields
+
+		Type           string          `json:"type"`
+		TypeAnnotation json.RawMessage `json:"typeAnnotation"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ta.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ta.Type(), x.Type)
+	}
+	if err == nil {
+		ta.Loc = x.Location()
+		ta.TypeAnnotation, err = unmarshalType(x.TypeAnnotation)
+	}
+	return err
+}
@@ -0,0 +1,285 @@
+package ts
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"pifke.org/estree"
+)
+
+func printNodes(nodes []estree.Node) string {
+	var s strings.Builder
+	s.WriteRune('[')
+	for i, n := range nodes {
+		if i > 0 {
+			s.WriteString(", ")
+		}
+		fmt.Fprintf(&s, "%T", n)
+	}
+	s.WriteRune(']')
+	return s.String()
+}
+
+// mockVisitor implements estree.Visitor by storing the list of nodes visited.
+type mockVisitor []estree.Node
+
+func (v *mockVisitor) Visit(n estree.Node) estree.Visitor {
+	*v = append(*v, n)
+	return v
+}
+
+func (v mockVisitor) String() string {
+	return "visited: " + printNodes(v)
+}
+
+// expect logs a test error if the visited nodes don't match.
+func (v mockVisitor) expect(t *testing.T, nodes ...estree.Node) {
+	if !reflect.DeepEqual(nodes, []estree.Node(v)) {
+		t.Helper()
+		t.Error("expected:", printNodes(nodes))
+		t.Error(v)
+	}
+}
+
+// testRoundtripJSON is a test helper for JSON serialization.
+func testRoundtripJSON(t *testing.T, in estree.Node, out json.Unmarshaler) {
+	t.Helper()
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Error(err)
+	} else if err := out.UnmarshalJSON(b); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(in, reflect.ValueOf(out).Elem().Interface()) {
+		t.Errorf("JSON roundtrip failed marshaling/unmarshaling %T", in)
+		t.Log("marshal:", string(b))
+		t.Logf("unmarshal: %+v", out)
+		if b2, err := json.Marshal(out); err == nil {
+			t.Log("re-marshal:", string(b2))
+		}
+	}
+
+	err = out.UnmarshalJSON([]byte(`{"type":"DoesNotExist","foo":"bar"}`))
+	if !errors.Is(err, estree.ErrWrongType) {
+		t.Errorf("expected ErrWrongType unmarshaling %T, got %v", in, err)
+	}
+}
+
+// hasError indicates one of errs satisfies errors.Is for expect.
+func hasError(expect error, errs ...error) bool {
+	for _, err := range errs {
+		if errors.Is(err, expect) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTSTypeAnnotation(t *testing.T) {
+	var ta TSTypeAnnotation
+	if !ta.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	ta.TypeAnnotation = TSKeyword{Kind: String}
+	if ta.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if ta.MinVersion() != estree.ES5 {
+		t.Errorf("expected ES5, got %s", ta.MinVersion())
+	}
+
+	var v mockVisitor
+	ta.Walk(&v)
+	v.expect(t, ta, ta.TypeAnnotation, nil, nil)
+
+	testRoundtripJSON(t, ta, new(TSTypeAnnotation))
+
+	if errs := ta.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ta.TypeAnnotation = nil
+	if !hasError(estree.ErrMissingNode, ta.Errors()...) {
+		t.Error("expected ErrMissingNode for nil TypeAnnotation")
+	}
+}
+
+func TestAnnotatedFunction(t *testing.T) {
+	// function foo(x?: number, ...rest: string[]): void {}
+	fd := estree.FunctionDeclaration{
+		ID: estree.Identifier{Name: "foo"},
+		Params: []estree.Pattern{
+			estree.Identifier{
+				Name:           "x",
+				TypeAnnotation: TSTypeAnnotation{TypeAnnotation: TSKeyword{Kind: Number}},
+				Optional:       true,
+			},
+			estree.RestElement{
+				Argument: estree.Identifier{Name: "rest"},
+				TypeAnnotation: TSTypeAnnotation{
+					TypeAnnotation: TSArrayType{ElementType: TSKeyword{Kind: String}},
+				},
+			},
+		},
+		ReturnType: TSTypeAnnotation{TypeAnnotation: TSKeyword{Kind: Void}},
+	}
+	if errs := estree.Validate(fd); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	x := fd.Params[0].(estree.Identifier)
+	rest := fd.Params[1].(estree.RestElement)
+	var v mockVisitor
+	fd.Walk(&v)
+	v.expect(t, fd,
+		fd.ID, nil,
+		x, x.TypeAnnotation, x.TypeAnnotation.(TSTypeAnnotation).TypeAnnotation, nil, nil, nil,
+		rest, rest.Argument, nil, rest.TypeAnnotation,
+		rest.TypeAnnotation.(TSTypeAnnotation).TypeAnnotation,
+		rest.TypeAnnotation.(TSTypeAnnotation).TypeAnnotation.(TSArrayType).ElementType, nil, nil, nil, nil,
+		fd.ReturnType, fd.ReturnType.(TSTypeAnnotation).TypeAnnotation, nil, nil,
+		fd.Body, nil, nil)
+
+	b, err := json.Marshal(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out estree.FunctionDeclaration
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fd, out) {
+		t.Errorf("JSON roundtrip failed: %s", b)
+	}
+
+	b = []byte(`{"type":"VariableDeclaration","kind":"let","declarations":[{` +
+		`"type":"VariableDeclarator","id":{"type":"Identifier","name":"x",` +
+		`"typeAnnotation":{"type":"NotAType"}},"init":null}]}`)
+	var vd estree.VariableDeclaration
+	if err := json.Unmarshal(b, &vd); !errors.Is(err, estree.ErrWrongType) {
+		t.Errorf("expected ErrWrongType, got %v", err)
+	}
+}
+
+func TestGenerics(t *testing.T) {
+	// import type {Foo} from "foo"; function id<T>(x: T): T {}; class Box<T> {}
+	b := []byte(`{"type":"Program","sourceType":"module","body":[` +
+		`{"type":"ImportDeclaration","importKind":"type","specifiers":[` +
+		`{"type":"ImportSpecifier","importKind":"value",` +
+		`"imported":{"type":"Identifier","name":"Foo"},` +
+		`"local":{"type":"Identifier","name":"Foo"}}],` +
+		`"source":{"type":"Literal","value":"foo","raw":"\"foo\""}},` +
+		`{"type":"FunctionDeclaration","id":{"type":"Identifier","name":"id"},` +
+		`"typeParameters":{"type":"TSTypeParameterDeclaration","params":[` +
+		`{"type":"TSTypeParameter","name":{"type":"Identifier","name":"T"},"constraint":null,"default":null}]},` +
+		`"params":[{"type":"Identifier","name":"x","typeAnnotation":{"type":"TSTypeAnnotation",` +
+		`"typeAnnotation":{"type":"TSTypeReference","typeName":{"type":"Identifier","name":"T"}}}}],` +
+		`"returnType":{"type":"TSTypeAnnotation",` +
+		`"typeAnnotation":{"type":"TSTypeReference","typeName":{"type":"Identifier","name":"T"}}},` +
+		`"body":{"type":"BlockStatement","body":[]},"generator":false,"async":false},` +
+		`{"type":"ClassDeclaration","id":{"type":"Identifier","name":"Box"},` +
+		`"typeParameters":{"type":"TSTypeParameterDeclaration","params":[` +
+		`{"type":"TSTypeParameter","name":{"type":"Identifier","name":"T"}}]},` +
+		`"superClass":null,"body":{"type":"ClassBody","body":[]}}]}`)
+	var p estree.Program
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatal(err)
+	}
+	if errs := estree.Validate(p); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	id := p.Body[0].(estree.ImportDeclaration)
+	if id.ImportKind != estree.TypeBinding {
+		t.Errorf("expected import type, got %q", id.ImportKind)
+	}
+	fd := p.Body[1].(estree.FunctionDeclaration)
+	if tpd, ok := fd.TypeParameters.(TSTypeParameterDeclaration); !ok || len(tpd.Params) != 1 || tpd.Params[0].Name.Name != "T" {
+		t.Errorf("expected <T>, got %+v", fd.TypeParameters)
+	}
+	cd := p.Body[2].(estree.ClassDeclaration)
+	if _, ok := cd.TypeParameters.(TSTypeParameterDeclaration); !ok {
+		t.Errorf("expected TSTypeParameterDeclaration, got %+v", cd.TypeParameters)
+	}
+
+	var v mockVisitor
+	fd.Walk(&v)
+	if len(v) < 4 || !reflect.DeepEqual(v[3], fd.TypeParameters) {
+		t.Errorf("expected TypeParameters to be walked after ID, %v", v)
+	}
+
+	out, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p2 estree.Program
+	if err := json.Unmarshal(out, &p2); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p, p2) {
+		t.Errorf("JSON roundtrip failed: %s", out)
+	}
+}
+
+func TestTypeArguments(t *testing.T) {
+	// useState<string>(); new Map<string, number>(); sql<Row>`select`
+	b := []byte(`{"type":"Program","sourceType":"module","body":[` +
+		`{"type":"ExpressionStatement","expression":{"type":"CallExpression",` +
+		`"callee":{"type":"Identifier","name":"useState"},"arguments":[],"optional":false,` +
+		`"typeArguments":{"type":"TSTypeParameterInstantiation","params":[{"type":"TSStringKeyword"}]}}},` +
+		`{"type":"ExpressionStatement","expression":{"type":"NewExpression",` +
+		`"callee":{"type":"Identifier","name":"Map"},"arguments":[],` +
+		`"typeParameters":{"type":"TSTypeParameterInstantiation","params":[` +
+		`{"type":"TSStringKeyword"},{"type":"TSNumberKeyword"}]}}},` +
+		`{"type":"ExpressionStatement","expression":{"type":"TaggedTemplateExpression",` +
+		`"tag":{"type":"Identifier","name":"sql"},` +
+		`"typeArguments":{"type":"TSTypeParameterInstantiation","params":[` +
+		`{"type":"TSTypeReference","typeName":{"type":"Identifier","name":"Row"}}]},` +
+		`"quasi":{"type":"TemplateLiteral","expressions":[],"quasis":[` +
+		`{"type":"TemplateElement","value":{"raw":"select","cooked":"select"},"tail":true}]}}}]}`)
+	var p estree.Program
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatal(err)
+	}
+	if errs := estree.Validate(p); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	ce := p.Body[0].(estree.ExpressionStatement).Expression.(estree.CallExpression)
+	if tpi, ok := ce.TypeArguments.(TSTypeParameterInstantiation); !ok || len(tpi.Params) != 1 {
+		t.Errorf("expected <string>, got %+v", ce.TypeArguments)
+	}
+	ne := p.Body[1].(estree.ExpressionStatement).Expression.(estree.NewExpression)
+	if tpi, ok := ne.TypeArguments.(TSTypeParameterInstantiation); !ok || len(tpi.Params) != 2 {
+		t.Errorf("expected <string, number>, got %+v", ne.TypeArguments)
+	}
+	tte := p.Body[2].(estree.ExpressionStatement).Expression.(estree.TaggedTemplateExpression)
+	if _, ok := tte.TypeArguments.(TSTypeParameterInstantiation); !ok {
+		t.Errorf("expected <Row>, got %+v", tte.TypeArguments)
+	}
+
+	var v mockVisitor
+	ce.Walk(&v)
+	if len(v) < 4 || !reflect.DeepEqual(v[3], ce.TypeArguments) {
+		t.Errorf("expected TypeArguments to be walked after Callee, %v", v)
+	}
+
+	out, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(out, []byte(`"typeParameters"`)) {
+		t.Errorf("expected typeArguments, got %s", out)
+	}
+	var p2 estree.Program
+	if err := json.Unmarshal(out, &p2); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p, p2) {
+		t.Errorf("JSON roundtrip failed: %s", out)
+	}
+}
@@ -0,0 +1,1917 @@
+package ts
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"pifke.org/estree"
+)
+
+// TSType is any TypeScript type expression.
+type TSType interface {
+	estree.Node
+	isTSType()
+}
+
+type baseType struct{}
+
+func (baseType) MinVersion() estree.Version { return estree.ES5 }
+func (baseType) isTSType()                  {}
+
+func unmarshalType(m json.RawMessage) (t TSType, err error) {
+	if isNull(m) {
+		return nil, nil
+	}
+	var x struct {
+		Type string `json:"type"`
+	}
+	if err = json.Unmarshal(m, &x); err == nil {
+		switch x.Type {
+		case TSTypeReference{}.Type():
+			var tr TSTypeReference
+			err, t = json.Unmarshal(m, &tr), tr
+		case TSUnionType{}.Type():
+			var ut TSUnionType
+			err, t = json.Unmarshal(m, &ut), ut
+		case TSIntersectionType{}.Type():
+			var it TSIntersectionType
+			err, t = json.Unmarshal(m, &it), it
+		case TSArrayType{}.Type():
+			var at TSArrayType
+			err, t = json.Unmarshal(m, &at), at
+		case TSLiteralType{}.Type():
+			var lt TSLiteralType
+			err, t = json.Unmarshal(m, &lt), lt
+		case TSTypeLiteral{}.Type():
+			var tl TSTypeLiteral
+			err, t = json.Unmarshal(m, &tl), tl
+		case TSFunctionType{}.Type():
+			var ft TSFunctionType
+			err, t = json.Unmarshal(m, &ft), ft
+		case TSTupleType{}.Type():
+			var tt TSTupleType
+			err, t = json.Unmarshal(m, &tt), tt
+		case TSNamedTupleMember{}.Type():
+			var tm TSNamedTupleMember
+			err, t = json.Unmarshal(m, &tm), tm
+		case TSOptionalType{}.Type():
+			var ot TSOptionalType
+			err, t = json.Unmarshal(m, &ot), ot
+		case TSRestType{}.Type():
+			var rt TSRestType
+			err, t = json.Unmarshal(m, &rt), rt
+		case TSTypeOperator{}.Type():
+			var to TSTypeOperator
+			err, t = json.Unmarshal(m, &to), to
+		case TSTypeQuery{}.Type():
+			var tq TSTypeQuery
+			err, t = json.Unmarshal(m, &tq), tq
+		case TSIndexedAccessType{}.Type():
+			var iat TSIndexedAccessType
+			err, t = json.Unmarshal(m, &iat), iat
+		case TSConditionalType{}.Type():
+			var ct TSConditionalType
+			err, t = json.Unmarshal(m, &ct), ct
+		case TSInferType{}.Type():
+			var it TSInferType
+			err, t = json.Unmarshal(m, &it), it
+		case TSMappedType{}.Type():
+			var mt TSMappedType
+			err, t = json.Unmarshal(m, &mt), mt
+		case TSThisType{}.Type():
+			var tt TSThisType
+			err, t = json.Unmarshal(m, &tt), tt
+		case TSImportType{}.Type():
+			var it TSImportType
+			err, t = json.Unmarshal(m, &it), it
+		default:
+			if TSKeywordKind(x.Type).IsValid() {
+				var k TSKeyword
+				err, t = json.Unmarshal(m, &k), k
+			} else {
+				err = fmt.Errorf("%w TSType, got %v", estree.ErrWrongType, string(m))
+			}
+		}
+		if err != nil {
+			t = nil
+		}
+	}
+	return
+}
+
+// isNull indicates m is JSON null or empty, i.e. an optional Node is absent.
+func isNull(m json.RawMessage) bool {
+	return len(m) == 0 || string(m) == "null"
+}
+
+// TSKeywordKind is the Type of a TSKeyword.
+type TSKeywordKind string
+
+const (
+	Any       TSKeywordKind = "TSAnyKeyword"
+	Unknown   TSKeywordKind = "TSUnknownKeyword"
+	Never     TSKeywordKind = "TSNeverKeyword"
+	Void      TSKeywordKind = "TSVoidKeyword"
+	Undefined TSKeywordKind = "TSUndefinedKeyword"
+	Null      TSKeywordKind = "TSNullKeyword"
+	Boolean   TSKeywordKind = "TSBooleanKeyword"
+	Number    TSKeywordKind = "TSNumberKeyword"
+	BigInt    TSKeywordKind = "TSBigIntKeyword"
+	String    TSKeywordKind = "TSStringKeyword"
+	Symbol    TSKeywordKind = "TSSymbolKeyword"
+	Object    TSKeywordKind = "TSObjectKeyword"
+	Intrinsic TSKeywordKind = "TSIntrinsicKeyword"
+)
+
+func (k TSKeywordKind) IsValid() bool {
+	switch k {
+	case Any, Unknown, Never, Void, Undefined, Null, Boolean, Number, BigInt,
+		String, Symbol, Object, Intrinsic:
+		return true
+	}
+	return false
+}
+
+// TSKeyword is a built-in type, e.g. string or any.  Unlike most Nodes, its
+// Type is determined by Kind.
+type TSKeyword struct {
+	baseType
+	Loc  estree.SourceLocation
+	Kind TSKeywordKind
+}
+
+func (k TSKeyword) Type() string                    { return string(k.Kind) }
+func (k TSKeyword) Location() estree.SourceLocation { return k.Loc }
+
+func (k TSKeyword) IsZero() bool {
+	return k.Loc.IsZero() && k.Kind == ""
+}
+
+func (k TSKeyword) Walk(v estree.Visitor) {
+	if v = v.Visit(k); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (k TSKeyword) Errors() []error {
+	c := estree.NewChecker(k)
+	if !k.Kind.IsValid() {
+		c.Appendf("%w TSKeyword.Kind %q", estree.ErrWrongValue, k.Kind)
+	}
+	return c.Errors()
+}
+
+func (k TSKeyword) MarshalJSON() ([]byte, error) {
+	return json.Marshal(estree.NodeToMap(k))
+}
+
+func (k *TSKeyword) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type TSKeywordKind `json:"type"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && !x.Type.IsValid() {
+		err = fmt.Errorf("%w TSKeyword, got %q", estree.ErrWrongType, x.Type)
+	}
+	if err == nil {
+		k.Loc, k.Kind = x.Location(), x.Type
+	}
+	return err
+}
+
+// unmarshalEntityName decodes the name of a type, which is either an
+// Identifier or TSQualifiedName.
+func unmarshalEntityName(m json.RawMessage) (estree.Node, error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(m, &x)
+	if err == nil {
+		switch x.Type {
+		case estree.Identifier{}.Type():
+			var i estree.Identifier
+			if err = json.Unmarshal(m, &i); err == nil {
+				return i, nil
+			}
+		case TSQualifiedName{}.Type():
+			var qn TSQualifiedName
+			if err = json.Unmarshal(m, &qn); err == nil {
+				return qn, nil
+			}
+		default:
+			err = fmt.Errorf("%w Identifier or TSQualifiedName, got %v", estree.ErrWrongType, string(m))
+		}
+	}
+	return nil, err
+}
+
+// checkEntityName reports ErrWrongValue if n is not an Identifier or
+// TSQualifiedName.
+func checkEntityName(c *estree.Checker, n estree.Node, what string) {
+	switch n.(type) {
+	case nil, estree.Identifier, TSQualifiedName:
+	default:
+		c.Appendf("%w %s %s", estree.ErrWrongValue, what, n.Type())
+	}
+}
+
+// TSQualifiedName is a dotted type name, e.g. Foo.Bar.
+type TSQualifiedName struct {
+	Loc   estree.SourceLocation
+	Left  estree.Node // Identifier or TSQualifiedName
+	Right estree.Identifier
+}
+
+func (TSQualifiedName) Type() string                       { return "TSQualifiedName" }
+func (qn TSQualifiedName) Location() estree.SourceLocation { return qn.Loc }
+func (TSQualifiedName) MinVersion() estree.Version         { return estree.ES5 }
+
+func (qn TSQualifiedName) IsZero() bool {
+	return qn.Loc.IsZero() &&
+		(qn.Left == nil || qn.Left.IsZero()) &&
+		qn.Right.IsZero()
+}
+
+func (qn TSQualifiedName) Walk(v estree.Visitor) {
+	if v = v.Visit(qn); v != nil {
+		defer v.Visit(nil)
+		if qn.Left != nil {
+			qn.Left.Walk(v)
+		}
+		qn.Right.Walk(v)
+	}
+}
+
+func (qn TSQualifiedName) Errors() []error {
+	c := estree.NewChecker(qn)
+	c.Require(qn.Left, "qualifier")
+	checkEntityName(c, qn.Left, "qualifier")
+	c.Require(qn.Right, "qualified name")
+	return c.Errors()
+}
+
+func (qn TSQualifiedName) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(qn)
+	x["left"] = qn.Left
+	x["right"] = qn.Right
+	return json.Marshal(x)
+}
+
+func (qn *TSQualifiedName) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type  string            `json:"type"`
+		Left  json.RawMessage   `json:"left"`
+		Right estree.Identifier `json:"right"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != qn.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, qn.Type(), x.Type)
+	}
+	if err == nil {
+		qn.Loc, qn.Right = x.Location(), x.Right
+		qn.Left, err = unmarshalEntityName(x.Left)
+	}
+	return err
+}
+
+// TSTypeParameterInstantiation is a list of type arguments, e.g. the <string>
+// in Array<string>.
+type TSTypeParameterInstantiation struct {
+	estree.BaseTypeParameterInstantiation
+	Loc    estree.SourceLocation
+	Params []TSType
+}
+
+func (TSTypeParameterInstantiation) Type() string { return "TSTypeParameterInstantiation" }
+func (tpi TSTypeParameterInstantiation) Location() estree.SourceLocation {
+	return tpi.Loc
+}
+func (TSTypeParameterInstantiation) MinVersion() estree.Version { return estree.ES5 }
+
+func (tpi TSTypeParameterInstantiation) IsZero() bool {
+	return tpi.Loc.IsZero() && len(tpi.Params) == 0
+}
+
+func (tpi TSTypeParameterInstantiation) Walk(v estree.Visitor) {
+	if v = v.Visit(tpi); v != nil {
+		defer v.Visit(nil)
+		for _, p := range tpi.Params {
+			if p != nil {
+				p.Walk(v)
+			}
+		}
+	}
+}
+
+func (tpi TSTypeParameterInstantiation) Errors() []error {
+	c := estree.NewChecker(tpi)
+	if len(tpi.Params) == 0 {
+		c.Appendf("%w type argument", estree.ErrMissingNode)
+	}
+	c.RequireEach(len(tpi.Params), func(i int) estree.Node { return tpi.Params[i] }, "type argument")
+	return c.Errors()
+}
+
+func (tpi TSTypeParameterInstantiation) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(tpi)
+	x["params"] = tpi.Params
+	return json.Marshal(x)
+}
+
+func (tpi *TSTypeParameterInstantiation) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		return nil // optional type arguments are null when absent
+	}
+	var x struct {
+		estree.NodeFields
+
+		Type   string            `json:"type"`
+		Params []json.RawMessage `json:"params"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tpi.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tpi.Type(), x.Type)
+	}
+	if err == nil {
+		tpi.Loc = x.Location()
+		tpi.Params, err = unmarshalTypes(x.Params)
+	}
+	return err
+}
+
+// unmarshalTypes decodes a list of TSTypes, returning the first error
+// encountered.
+func unmarshalTypes(ms []json.RawMessage) ([]TSType, error) {
+	if len(ms) == 0 {
+		return nil, nil
+	}
+	var err error
+	types := make([]TSType, len(ms))
+	for i := range ms {
+		var err2 error
+		types[i], err2 = unmarshalType(ms[i])
+		if err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return types, err
+}
+
+// TSTypeReference refers to a named type, e.g. Foo or Array<string>.
+type TSTypeReference struct {
+	baseType
+	Loc           estree.SourceLocation
+	TypeName      estree.Node                  // Identifier or TSQualifiedName
+	TypeArguments TSTypeParameterInstantiation // possibly zero
+}
+
+func (TSTypeReference) Type() string                       { return "TSTypeReference" }
+func (tr TSTypeReference) Location() estree.SourceLocation { return tr.Loc }
+
+func (tr TSTypeReference) IsZero() bool {
+	return tr.Loc.IsZero() &&
+		(tr.TypeName == nil || tr.TypeName.IsZero()) &&
+		tr.TypeArguments.IsZero()
+}
+
+func (tr TSTypeReference) Walk(v estree.Visitor) {
+	if v = v.Visit(tr); v != nil {
+		defer v.Visit(nil)
+		if tr.TypeName != nil {
+			tr.TypeName.Walk(v)
+		}
+		if !tr.TypeArguments.IsZero() {
+			tr.TypeArguments.Walk(v)
+		}
+	}
+}
+
+func (tr TSTypeReference) Errors() []error {
+	c := estree.NewChecker(tr)
+	c.Require(tr.TypeName, "type name")
+	checkEntityName(c, tr.TypeName, "type name")
+	c.Optional(tr.TypeArguments)
+	return c.Errors()
+}
+
+func (tr TSTypeReference) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(tr)
+	x["typeName"] = tr.TypeName
+	if tr.TypeArguments.IsZero() {
+		x["typeArguments"] = nil
+	} else {
+		x["typeArguments"] = tr.TypeArguments
+	}
+	return json.Marshal(x)
+}
+
+func (tr *TSTypeReference) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type          string                       `json:"type"`
+		TypeName      json.RawMessage              `json:"typeName"`
+		TypeArguments TSTypeParameterInstantiation `json:"typeArguments"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tr.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tr.Type(), x.Type)
+	}
+	if err == nil {
+		tr.Loc, tr.TypeArguments = x.Location(), x.TypeArguments
+		tr.TypeName, err = unmarshalEntityName(x.TypeName)
+	}
+	return err
+}
+
+// TSUnionType is a union of types, e.g. string | number.
+type TSUnionType struct {
+	baseType
+	Loc   estree.SourceLocation
+	Types []TSType
+}
+
+func (TSUnionType) Type() string                       { return "TSUnionType" }
+func (ut TSUnionType) Location() estree.SourceLocation { return ut.Loc }
+
+func (ut TSUnionType) IsZero() bool {
+	return ut.Loc.IsZero() && len(ut.Types) == 0
+}
+
+func (ut TSUnionType) Walk(v estree.Visitor) {
+	if v = v.Visit(ut); v != nil {
+		defer v.Visit(nil)
+		for _, t := range ut.Types {
+			if t != nil {
+				t.Walk(v)
+			}
+		}
+	}
+}
+
+func (ut TSUnionType) Errors() []error {
+	c := estree.NewChecker(ut)
+	if len(ut.Types) == 0 {
+		c.Appendf("%w union member", estree.ErrMissingNode)
+	}
+	c.RequireEach(len(ut.Types), func(i int) estree.Node { return ut.Types[i] }, "union member")
+	return c.Errors()
+}
+
+func (ut TSUnionType) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ut)
+	x["types"] = ut.Types
+	return json.Marshal(x)
+}
+
+func (ut *TSUnionType) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type  string            `json:"type"`
+		Types []json.RawMessage `json:"types"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ut.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ut.Type(), x.Type)
+	}
+	if err == nil {
+		ut.Loc = x.Location()
+		ut.Types, err = unmarshalTypes(x.Types)
+	}
+	return err
+}
+
+// TSIntersectionType is an intersection of types, e.g. Foo & Bar.
+type TSIntersectionType struct {
+	baseType
+	Loc   estree.SourceLocation
+	Types []TSType
+}
+
+func (TSIntersectionType) Type() string                       { return "TSIntersectionType" }
+func (it TSIntersectionType) Location() estree.SourceLocation { return it.Loc }
+
+func (it TSIntersectionType) IsZero() bool {
+	return it.Loc.IsZero() && len(it.Types) == 0
+}
+
+func (it TSIntersectionType) Walk(v estree.Visitor) {
+	if v = v.Visit(it); v != nil {
+		defer v.Visit(nil)
+		for _, t := range it.Types {
+			if t != nil {
+				t.Walk(v)
+			}
+		}
+	}
+}
+
+func (it TSIntersectionType) Errors() []error {
+	c := estree.NewChecker(it)
+	if len(it.Types) == 0 {
+		c.Appendf("%w intersection member", estree.ErrMissingNode)
+	}
+	c.RequireEach(len(it.Types), func(i int) estree.Node { return it.Types[i] }, "intersection member")
+	return c.Errors()
+}
+
+func (it TSIntersectionType) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(it)
+	x["types"] = it.Types
+	return json.Marshal(x)
+}
+
+func (it *TSIntersectionType) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type  string            `json:"type"`
+		Types []json.RawMessage `json:"types"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != it.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, it.Type(), x.Type)
+	}
+	if err == nil {
+		it.Loc = x.Location()
+		it.Types, err = unmarshalTypes(x.Types)
+	}
+	return err
+}
+
+// TSArrayType is an array type, e.g. string[].
+type TSArrayType struct {
+	baseType
+	Loc         estree.SourceLocation
+	ElementType TSType
+}
+
+func (TSArrayType) Type() string                       { return "TSArrayType" }
+func (at TSArrayType) Location() estree.SourceLocation { return at.Loc }
+
+func (at TSArrayType) IsZero() bool {
+	return at.Loc.IsZero() &&
+		(at.ElementType == nil || at.ElementType.IsZero())
+}
+
+func (at TSArrayType) Walk(v estree.Visitor) {
+	if v = v.Visit(at); v != nil {
+		defer v.Visit(nil)
+		if at.ElementType != nil {
+			at.ElementType.Walk(v)
+		}
+	}
+}
+
+func (at TSArrayType) Errors() []error {
+	c := estree.NewChecker(at)
+	c.Require(at.ElementType, "array element type")
+	return c.Errors()
+}
+
+func (at TSArrayType) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(at)
+	x["elementType"] = at.ElementType
+	return json.Marshal(x)
+}
+
+func (at *TSArrayType) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type        string          `json:"type"`
+		ElementType json.RawMessage `json:"elementType"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != at.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, at.Type(), x.Type)
+	}
+	if err == nil {
+		at.Loc = x.Location()
+		at.ElementType, err = unmarshalType(x.ElementType)
+	}
+	return err
+}
+
+// TSLiteralType is a literal value used as a type, e.g. "foo" or -1.
+type TSLiteralType struct {
+	baseType
+	Loc estree.SourceLocation
+
+	// Literal is a Literal, a TemplateLiteral without expressions, or a
+	// UnaryExpression negating a numeric Literal.
+	Literal estree.Expression
+}
+
+func (TSLiteralType) Type() string                       { return "TSLiteralType" }
+func (lt TSLiteralType) Location() estree.SourceLocation { return lt.Loc }
+
+func (lt TSLiteralType) IsZero() bool {
+	return lt.Loc.IsZero() && (lt.Literal == nil || lt.Literal.IsZero())
+}
+
+func (lt TSLiteralType) Walk(v estree.Visitor) {
+	if v = v.Visit(lt); v != nil {
+		defer v.Visit(nil)
+		if lt.Literal != nil {
+			lt.Literal.Walk(v)
+		}
+	}
+}
+
+func (lt TSLiteralType) Errors() []error {
+	c := estree.NewChecker(lt)
+	c.Require(lt.Literal, "literal type")
+	switch l := lt.Literal.(type) {
+	case nil, estree.Literal:
+	case estree.TemplateLiteral:
+		if len(l.Expressions) > 0 {
+			c.Appendf("%w literal type with template expressions", estree.ErrWrongValue)
+		}
+	case estree.UnaryExpression:
+		switch l.Argument.(type) {
+		case estree.NumberLiteral, estree.BigIntLiteral:
+		default:
+			c.Appendf("%w literal type with unary operator", estree.ErrWrongValue)
+		}
+		if l.Operator != estree.Minus {
+			c.Appendf("%w literal type with unary operator %q", estree.ErrWrongValue, l.Operator)
+		}
+	default:
+		c.Appendf("%w literal type %s", estree.ErrWrongValue, lt.Literal.Type())
+	}
+	return c.Errors()
+}
+
+func (lt TSLiteralType) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(lt)
+	x["literal"] = lt.Literal
+	return json.Marshal(x)
+}
+
+func (lt *TSLiteralType) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type    string          `json:"type"`
+		Literal json.RawMessage `json:"literal"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != lt.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, lt.Type(), x.Type)
+	}
+	if err == nil {
+		lt.Loc = x.Location()
+		lt.Literal, err = estree.UnmarshalExpression(x.Literal)
+	}
+	return err
+}
+
+// TSTypeElement is a member of a TSTypeLiteral or TSInterfaceBody.
+type TSTypeElement interface {
+	estree.Node
+	isTSTypeElement()
+}
+
+func unmarshalTypeElement(m json.RawMessage) (TSTypeElement, error) {
+	var x struct {
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(m, &x)
+	if err == nil {
+		switch x.Type {
+		case TSPropertySignature{}.Type():
+			var ps TSPropertySignature
+			if err = json.Unmarshal(m, &ps); err == nil {
+				return ps, nil
+			}
+		case TSIndexSignature{}.Type():
+			var is TSIndexSignature
+			if err = json.Unmarshal(m, &is); err == nil {
+				return is, nil
+			}
+		default:
+			err = fmt.Errorf("%w TSTypeElement, got %v", estree.ErrWrongType, string(m))
+		}
+	}
+	return nil, err
+}
+
+// unmarshalTypeElements decodes a list of TSTypeElements, returning the first
+// error encountered.
+func unmarshalTypeElements(ms []json.RawMessage) ([]TSTypeElement, error) {
+	if len(ms) == 0 {
+		return nil, nil
+	}
+	var err error
+	elements := make([]TSTypeElement, len(ms))
+	for i := range ms {
+		var err2 error
+		elements[i], err2 = unmarshalTypeElement(ms[i])
+		if err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return elements, err
+}
+
+// TSTypeLiteral is an object type, e.g. { foo: string }.
+type TSTypeLiteral struct {
+	baseType
+	Loc     estree.SourceLocation
+	Members []TSTypeElement
+}
+
+func (TSTypeLiteral) Type() string                       { return "TSTypeLiteral" }
+func (tl TSTypeLiteral) Location() estree.SourceLocation { return tl.Loc }
+func (TSTypeLiteral) IsZero() bool                       { return false }
+
+func (tl TSTypeLiteral) Walk(v estree.Visitor) {
+	if v = v.Visit(tl); v != nil {
+		defer v.Visit(nil)
+		for _, m := range tl.Members {
+			if m != nil {
+				m.Walk(v)
+			}
+		}
+	}
+}
+
+func (tl TSTypeLiteral) Errors() []error {
+	c := estree.NewChecker(tl)
+	c.RequireEach(len(tl.Members), func(i int) estree.Node { return tl.Members[i] }, "type member")
+	return c.Errors()
+}
+
+func (tl TSTypeLiteral) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(tl)
+	if len(tl.Members) == 0 {
+		x["members"] = []TSTypeElement{}
+	} else {
+		x["members"] = tl.Members
+	}
+	return json.Marshal(x)
+}
+
+func (tl *TSTypeLiteral) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type    string            `json:"type"`
+		Members []json.RawMessage `json:"members"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tl.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tl.Type(), x.Type)
+	}
+	if err == nil {
+		tl.Loc = x.Location()
+		tl.Members, err = unmarshalTypeElements(x.Members)
+	}
+	return err
+}
+
+// TSPropertySignature is a property of a TSTypeLiteral or TSInterfaceBody,
+// e.g. readonly foo?: string.
+type TSPropertySignature struct {
+	Loc            estree.SourceLocation
+	Key            estree.Expression
+	TypeAnnotation TSTypeAnnotation // possibly zero
+
+	// Computed indicates Key is an arbitrary Expression ([key]: type).  If
+	// Computed is false, Key is an Identifier or Literal.
+	Computed bool
+
+	Optional bool
+	Readonly bool
+}
+
+func (TSPropertySignature) Type() string                       { return "TSPropertySignature" }
+func (ps TSPropertySignature) Location() estree.SourceLocation { return ps.Loc }
+func (TSPropertySignature) MinVersion() estree.Version         { return estree.ES5 }
+func (TSPropertySignature) isTSTypeElement()                   {}
+
+func (ps TSPropertySignature) IsZero() bool {
+	return ps.Loc.IsZero() &&
+		(ps.Key == nil || ps.Key.IsZero()) &&
+		ps.TypeAnnotation.IsZero() &&
+		!ps.Computed &&
+		!ps.Optional &&
+		!ps.Readonly
+}
+
+func (ps TSPropertySignature) Walk(v estree.Visitor) {
+	if v = v.Visit(ps); v != nil {
+		defer v.Visit(nil)
+		if ps.Key != nil {
+			ps.Key.Walk(v)
+		}
+		if !ps.TypeAnnotation.IsZero() {
+			ps.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (ps TSPropertySignature) Errors() []error {
+	c := estree.NewChecker(ps)
+	c.Require(ps.Key, "property name")
+	if _, ok := ps.Key.(estree.LiteralOrIdentifier); ps.Key != nil && !ok && !ps.Computed {
+		c.Appendf("%w property name %s without Computed", estree.ErrWrongValue, ps.Key.Type())
+	}
+	c.Optional(ps.TypeAnnotation)
+	return c.Errors()
+}
+
+func (ps TSPropertySignature) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ps)
+	x["key"] = ps.Key
+	if ps.TypeAnnotation.IsZero() {
+		x["typeAnnotation"] = nil
+	} else {
+		x["typeAnnotation"] = ps.TypeAnnotation
+	}
+	x["computed"] = ps.Computed
+	x["optional"] = ps.Optional
+	x["readonly"] = ps.Readonly
+	return json.Marshal(x)
+}
+
+func (ps *TSPropertySignature) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string           `json:"type"`
+		Key            json.RawMessage  `json:"key"`
+		TypeAnnotation TSTypeAnnotation `json:"typeAnnotation"`
+		Computed       bool             `json:"computed"`
+		Optional       bool             `json:"optional"`
+		Readonly       bool             `json:"readonly"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ps.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ps.Type(), x.Type)
+	}
+	if err == nil {
+		ps.Loc, ps.TypeAnnotation = x.Location(), x.TypeAnnotation
+		ps.Computed, ps.Optional, ps.Readonly = x.Computed, x.Optional, x.Readonly
+		ps.Key, err = estree.UnmarshalExpression(x.Key)
+	}
+	return err
+}
+
+// TSIndexSignature is an index signature in a TSTypeLiteral, TSInterfaceBody,
+// or ClassBody, e.g. [key: string]: number.
+type TSIndexSignature struct {
+	estree.BaseClassElement
+	Loc            estree.SourceLocation
+	Parameters     []estree.Identifier
+	TypeAnnotation TSTypeAnnotation // possibly zero
+	Readonly       bool
+
+	// Static indicates the index signature belongs to the class, rather
+	// than its instances.  It is only allowed in a ClassBody.
+	Static bool
+}
+
+func (TSIndexSignature) Type() string                       { return "TSIndexSignature" }
+func (is TSIndexSignature) Location() estree.SourceLocation { return is.Loc }
+func (TSIndexSignature) MinVersion() estree.Version         { return estree.ES5 }
+func (TSIndexSignature) isTSTypeElement()                   {}
+
+func (is TSIndexSignature) IsZero() bool {
+	return is.Loc.IsZero() &&
+		len(is.Parameters) == 0 &&
+		is.TypeAnnotation.IsZero() &&
+		!is.Readonly &&
+		!is.Static
+}
+
+func (is TSIndexSignature) Walk(v estree.Visitor) {
+	if v = v.Visit(is); v != nil {
+		defer v.Visit(nil)
+		for _, p := range is.Parameters {
+			p.Walk(v)
+		}
+		if !is.TypeAnnotation.IsZero() {
+			is.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (is TSIndexSignature) Errors() []error {
+	c := estree.NewChecker(is)
+	c.RequireEach(len(is.Parameters), func(i int) estree.Node { return is.Parameters[i] }, "index signature parameter")
+	if len(is.Parameters) != 1 {
+		c.Appendf("%w %d index signature parameters", estree.ErrWrongValue, len(is.Parameters))
+	}
+	for _, p := range is.Parameters {
+		if p.TypeAnnotation == nil {
+			c.Appendf("%w index signature parameter type", estree.ErrMissingNode)
+		}
+	}
+	c.Optional(is.TypeAnnotation)
+	return c.Errors()
+}
+
+func (is TSIndexSignature) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(is)
+	if len(is.Parameters) == 0 {
+		x["parameters"] = []estree.Identifier{}
+	} else {
+		x["parameters"] = is.Parameters
+	}
+	if is.TypeAnnotation.IsZero() {
+		x["typeAnnotation"] = nil
+	} else {
+		x["typeAnnotation"] = is.TypeAnnotation
+	}
+	x["readonly"] = is.Readonly
+	x["static"] = is.Static
+	return json.Marshal(x)
+}
+
+func (is *TSIndexSignature) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string              `json:"type"`
+		Parameters     []estree.Identifier `json:"parameters"`
+		TypeAnnotation TSTypeAnnotation    `json:"typeAnnotation"`
+		Readonly       bool                `json:"readonly"`
+		Static         bool                `json:"static"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != is.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, is.Type(), x.Type)
+	}
+	if err == nil {
+		is.Loc, is.TypeAnnotation = x.Location(), x.TypeAnnotation
+		is.Readonly, is.Static = x.Readonly, x.Static
+		if len(x.Parameters) == 0 {
+			is.Parameters = nil
+		} else {
+			is.Parameters = x.Parameters
+		}
+	}
+	return err
+}
+
+// TSFunctionType is a function type, e.g. (x: number) => string.
+type TSFunctionType struct {
+	baseType
+	Loc        estree.SourceLocation
+	Params     []estree.Pattern
+	ReturnType TSTypeAnnotation
+}
+
+func (TSFunctionType) Type() string                       { return "TSFunctionType" }
+func (ft TSFunctionType) Location() estree.SourceLocation { return ft.Loc }
+
+func (ft TSFunctionType) IsZero() bool {
+	return ft.Loc.IsZero() &&
+		len(ft.Params) == 0 &&
+		ft.ReturnType.IsZero()
+}
+
+func (ft TSFunctionType) Walk(v estree.Visitor) {
+	if v = v.Visit(ft); v != nil {
+		defer v.Visit(nil)
+		for _, p := range ft.Params {
+			if p != nil {
+				p.Walk(v)
+			}
+		}
+		ft.ReturnType.Walk(v)
+	}
+}
+
+func (ft TSFunctionType) Errors() []error {
+	c := estree.NewChecker(ft)
+	c.RequireEach(len(ft.Params), func(i int) estree.Node { return ft.Params[i] }, "function parameter")
+	c.Require(ft.ReturnType, "return type")
+	return c.Errors()
+}
+
+func (ft TSFunctionType) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ft)
+	if len(ft.Params) == 0 {
+		x["params"] = []estree.Pattern{}
+	} else {
+		x["params"] = ft.Params
+	}
+	x["returnType"] = ft.ReturnType
+	return json.Marshal(x)
+}
+
+func (ft *TSFunctionType) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type       string            `json:"type"`
+		Params     []json.RawMessage `json:"params"`
+		ReturnType TSTypeAnnotation  `json:"returnType"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ft.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ft.Type(), x.Type)
+	}
+	if err == nil {
+		ft.Loc, ft.ReturnType = x.Location(), x.ReturnType
+		if len(x.Params) == 0 {
+			ft.Params = nil
+		} else {
+			ft.Params = make([]estree.Pattern, len(x.Params))
+			for i := range x.Params {
+				var err2 error
+				ft.Params[i], err2 = estree.UnmarshalPattern(x.Params[i])
+				if err == nil && err2 != nil {
+					err = err2
+				}
+			}
+		}
+	}
+	return err
+}
+
+// TSTupleType is a tuple type, e.g. [string, number?, ...boolean[]].
+type TSTupleType struct {
+	baseType
+	Loc estree.SourceLocation
+
+	// ElementTypes may include TSNamedTupleMember, TSOptionalType, and
+	// TSRestType, as well as any other TSType.
+	ElementTypes []TSType
+}
+
+func (TSTupleType) Type() string                       { return "TSTupleType" }
+func (tt TSTupleType) Location() estree.SourceLocation { return tt.Loc }
+func (TSTupleType) IsZero() bool                       { return false }
+
+func (tt TSTupleType) Walk(v estree.Visitor) {
+	if v = v.Visit(tt); v != nil {
+		defer v.Visit(nil)
+		for _, t := range tt.ElementTypes {
+			if t != nil {
+				t.Walk(v)
+			}
+		}
+	}
+}
+
+func (tt TSTupleType) Errors() []error {
+	c := estree.NewChecker(tt)
+	c.RequireEach(len(tt.ElementTypes), func(i int) estree.Node { return tt.ElementTypes[i] }, "tuple element")
+	return c.Errors()
+}
+
+func (tt TSTupleType) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(tt)
+	if len(tt.ElementTypes) == 0 {
+		x["elementTypes"] = []TSType{}
+	} else {
+		x["elementTypes"] = tt.ElementTypes
+	}
+	return json.Marshal(x)
+}
+
+func (tt *TSTupleType) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type         string            `json:"type"`
+		ElementTypes []json.RawMessage `json:"elementTypes"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tt.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tt.Type(), x.Type)
+	}
+	if err == nil {
+		tt.Loc = x.Location()
+		tt.ElementTypes, err = unmarshalTypes(x.ElementTypes)
+	}
+	return err
+}
+
+// TSNamedTupleMember is a labeled element of a TSTupleType, e.g. the
+// start?: number in [start?: number].
+type TSNamedTupleMember struct {
+	baseType
+	Loc         estree.SourceLocation
+	Label       estree.Identifier
+	ElementType TSType
+	Optional    bool
+}
+
+func (TSNamedTupleMember) Type() string                       { return "TSNamedTupleMember" }
+func (tm TSNamedTupleMember) Location() estree.SourceLocation { return tm.Loc }
+
+func (tm TSNamedTupleMember) IsZero() bool {
+	return tm.Loc.IsZero() &&
+		tm.Label.IsZero() &&
+		(tm.ElementType == nil || tm.ElementType.IsZero()) &&
+		!tm.Optional
+}
+
+func (tm TSNamedTupleMember) Walk(v estree.Visitor) {
+	if v = v.Visit(tm); v != nil {
+		defer v.Visit(nil)
+		tm.Label.Walk(v)
+		if tm.ElementType != nil {
+			tm.ElementType.Walk(v)
+		}
+	}
+}
+
+func (tm TSNamedTupleMember) Errors() []error {
+	c := estree.NewChecker(tm)
+	c.Require(tm.Label, "tuple member label")
+	c.Require(tm.ElementType, "tuple member type")
+	return c.Errors()
+}
+
+func (tm TSNamedTupleMember) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(tm)
+	x["label"] = tm.Label
+	x["elementType"] = tm.ElementType
+	x["optional"] = tm.Optional
+	return json.Marshal(x)
+}
+
+func (tm *TSNamedTupleMember) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type        string            `json:"type"`
+		Label       estree.Identifier `json:"label"`
+		ElementType json.RawMessage   `json:"elementType"`
+		Optional    bool              `json:"optional"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tm.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tm.Type(), x.Type)
+	}
+	if err == nil {
+		tm.Loc, tm.Label, tm.Optional = x.Location(), x.Label, x.Optional
+		tm.ElementType, err = unmarshalType(x.ElementType)
+	}
+	return err
+}
+
+// TSOptionalType is an optional element of a TSTupleType, e.g. the number? in
+// [string, number?].
+type TSOptionalType struct {
+	baseType
+	Loc            estree.SourceLocation
+	TypeAnnotation TSType
+}
+
+func (TSOptionalType) Type() string                       { return "TSOptionalType" }
+func (ot TSOptionalType) Location() estree.SourceLocation { return ot.Loc }
+
+func (ot TSOptionalType) IsZero() bool {
+	return ot.Loc.IsZero() &&
+		(ot.TypeAnnotation == nil || ot.TypeAnnotation.IsZero())
+}
+
+func (ot TSOptionalType) Walk(v estree.Visitor) {
+	if v = v.Visit(ot); v != nil {
+		defer v.Visit(nil)
+		if ot.TypeAnnotation != nil {
+			ot.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (ot TSOptionalType) Errors() []error {
+	c := estree.NewChecker(ot)
+	c.Require(ot.TypeAnnotation, "optional element type")
+	return c.Errors()
+}
+
+func (ot TSOptionalType) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ot)
+	x["typeAnnotation"] = ot.TypeAnnotation
+	return json.Marshal(x)
+}
+
+func (ot *TSOptionalType) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string          `json:"type"`
+		TypeAnnotation json.RawMessage `json:"typeAnnotation"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ot.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ot.Type(), x.Type)
+	}
+	if err == nil {
+		ot.Loc = x.Location()
+		ot.TypeAnnotation, err = unmarshalType(x.TypeAnnotation)
+	}
+	return err
+}
+
+// TSRestType is a rest element of a TSTupleType, e.g. the ...boolean[] in
+// [string, ...boolean[]].
+type TSRestType struct {
+	baseType
+	Loc            estree.SourceLocation
+	TypeAnnotation TSType
+}
+
+func (TSRestType) Type() string                       { return "TSRestType" }
+func (rt TSRestType) Location() estree.SourceLocation { return rt.Loc }
+
+func (rt TSRestType) IsZero() bool {
+	return rt.Loc.IsZero() &&
+		(rt.TypeAnnotation == nil || rt.TypeAnnotation.IsZero())
+}
+
+func (rt TSRestType) Walk(v estree.Visitor) {
+	if v = v.Visit(rt); v != nil {
+		defer v.Visit(nil)
+		if rt.TypeAnnotation != nil {
+			rt.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (rt TSRestType) Errors() []error {
+	c := estree.NewChecker(rt)
+	c.Require(rt.TypeAnnotation, "rest element type")
+	return c.Errors()
+}
+
+func (rt TSRestType) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(rt)
+	x["typeAnnotation"] = rt.TypeAnnotation
+	return json.Marshal(x)
+}
+
+func (rt *TSRestType) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string          `json:"type"`
+		TypeAnnotation json.RawMessage `json:"typeAnnotation"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != rt.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, rt.Type(), x.Type)
+	}
+	if err == nil {
+		rt.Loc = x.Location()
+		rt.TypeAnnotation, err = unmarshalType(x.TypeAnnotation)
+	}
+	return err
+}
+
+// TSTypeOperatorKind is a value for TSTypeOperator.Operator.
+type TSTypeOperatorKind string
+
+const (
+	KeyofOperator    TSTypeOperatorKind = "keyof"    // keyof Foo
+	UniqueOperator   TSTypeOperatorKind = "unique"   // unique symbol
+	ReadonlyOperator TSTypeOperatorKind = "readonly" // readonly string[]
+)
+
+func (ok TSTypeOperatorKind) IsValid() bool {
+	switch ok {
+	case KeyofOperator, UniqueOperator, ReadonlyOperator:
+		return true
+	}
+	return false
+}
+
+// TSTypeOperator applies a type operator to a type, e.g. keyof Foo.
+type TSTypeOperator struct {
+	baseType
+	Loc            estree.SourceLocation
+	Operator       TSTypeOperatorKind
+	TypeAnnotation TSType
+}
+
+func (TSTypeOperator) Type() string                       { return "TSTypeOperator" }
+func (to TSTypeOperator) Location() estree.SourceLocation { return to.Loc }
+
+func (to TSTypeOperator) IsZero() bool {
+	return to.Loc.IsZero() &&
+		to.Operator == "" &&
+		(to.TypeAnnotation == nil || to.TypeAnnotation.IsZero())
+}
+
+func (to TSTypeOperator) Walk(v estree.Visitor) {
+	if v = v.Visit(to); v != nil {
+		defer v.Visit(nil)
+		if to.TypeAnnotation != nil {
+			to.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (to TSTypeOperator) Errors() []error {
+	c := estree.NewChecker(to)
+	if !to.Operator.IsValid() {
+		c.Appendf("%w type operator %q", estree.ErrWrongValue, to.Operator)
+	}
+	c.Require(to.TypeAnnotation, "type operand")
+	if k, ok := to.TypeAnnotation.(TSKeyword); to.Operator == UniqueOperator && (!ok || k.Kind != Symbol) {
+		c.Appendf("unique operator without symbol %w", estree.ErrNotAllowed)
+	}
+	return c.Errors()
+}
+
+func (to TSTypeOperator) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(to)
+	x["operator"] = to.Operator
+	x["typeAnnotation"] = to.TypeAnnotation
+	return json.Marshal(x)
+}
+
+func (to *TSTypeOperator) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string             `json:"type"`
+		Operator       TSTypeOperatorKind `json:"operator"`
+		TypeAnnotation json.RawMessage    `json:"typeAnnotation"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != to.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, to.Type(), x.Type)
+	}
+	if err == nil && !x.Operator.IsValid() {
+		err = fmt.Errorf("%w TSTypeOperator.Operator %q", estree.ErrWrongValue, x.Operator)
+	}
+	if err == nil {
+		to.Loc, to.Operator = x.Location(), x.Operator
+		to.TypeAnnotation, err = unmarshalType(x.TypeAnnotation)
+	}
+	return err
+}
+
+// TSTypeQuery is the type of a value, e.g. typeof foo.bar.
+type TSTypeQuery struct {
+	baseType
+	Loc           estree.SourceLocation
+	ExprName      estree.Node                  // Identifier, TSQualifiedName, or TSImportType
+	TypeArguments TSTypeParameterInstantiation // possibly zero
+}
+
+func (TSTypeQuery) Type() string                       { return "TSTypeQuery" }
+func (tq TSTypeQuery) Location() estree.SourceLocation { return tq.Loc }
+
+func (tq TSTypeQuery) IsZero() bool {
+	return tq.Loc.IsZero() &&
+		(tq.ExprName == nil || tq.ExprName.IsZero()) &&
+		tq.TypeArguments.IsZero()
+}
+
+func (tq TSTypeQuery) Walk(v estree.Visitor) {
+	if v = v.Visit(tq); v != nil {
+		defer v.Visit(nil)
+		if tq.ExprName != nil {
+			tq.ExprName.Walk(v)
+		}
+		if !tq.TypeArguments.IsZero() {
+			tq.TypeArguments.Walk(v)
+		}
+	}
+}
+
+func (tq TSTypeQuery) Errors() []error {
+	c := estree.NewChecker(tq)
+	c.Require(tq.ExprName, "type query name")
+	if _, ok := tq.ExprName.(TSImportType); !ok {
+		checkEntityName(c, tq.ExprName, "type query name")
+	}
+	c.Optional(tq.TypeArguments)
+	return c.Errors()
+}
+
+func (tq TSTypeQuery) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(tq)
+	x["exprName"] = tq.ExprName
+	if tq.TypeArguments.IsZero() {
+		x["typeArguments"] = nil
+	} else {
+		x["typeArguments"] = tq.TypeArguments
+	}
+	return json.Marshal(x)
+}
+
+func (tq *TSTypeQuery) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type          string                       `json:"type"`
+		ExprName      json.RawMessage              `json:"exprName"`
+		TypeArguments TSTypeParameterInstantiation `json:"typeArguments"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tq.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tq.Type(), x.Type)
+	}
+	if err == nil {
+		tq.Loc, tq.TypeArguments = x.Location(), x.TypeArguments
+		var y struct {
+			Type string `json:"type"`
+		}
+		if err = json.Unmarshal(x.ExprName, &y); err == nil && y.Type == (TSImportType{}).Type() {
+			var it TSImportType
+			err, tq.ExprName = json.Unmarshal(x.ExprName, &it), it
+		} else if err == nil {
+			tq.ExprName, err = unmarshalEntityName(x.ExprName)
+		}
+	}
+	return err
+}
+
+// TSIndexedAccessType is the type of a property of another type, e.g.
+// Foo["bar"].
+type TSIndexedAccessType struct {
+	baseType
+	Loc        estree.SourceLocation
+	ObjectType TSType
+	IndexType  TSType
+}
+
+func (TSIndexedAccessType) Type() string                        { return "TSIndexedAccessType" }
+func (iat TSIndexedAccessType) Location() estree.SourceLocation { return iat.Loc }
+
+func (iat TSIndexedAccessType) IsZero() bool {
+	return iat.Loc.IsZero() &&
+		(iat.ObjectType == nil || iat.ObjectType.IsZero()) &&
+		(iat.IndexType == nil || iat.IndexType.IsZero())
+}
+
+func (iat TSIndexedAccessType) Walk(v estree.Visitor) {
+	if v = v.Visit(iat); v != nil {
+		defer v.Visit(nil)
+		if iat.ObjectType != nil {
+			iat.ObjectType.Walk(v)
+		}
+		if iat.IndexType != nil {
+			iat.IndexType.Walk(v)
+		}
+	}
+}
+
+func (iat TSIndexedAccessType) Errors() []error {
+	c := estree.NewChecker(iat)
+	c.Require(iat.ObjectType, "indexed object type")
+	c.Require(iat.IndexType, "index type")
+	return c.Errors()
+}
+
+func (iat TSIndexedAccessType) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(iat)
+	x["objectType"] = iat.ObjectType
+	x["indexType"] = iat.IndexType
+	return json.Marshal(x)
+}
+
+func (iat *TSIndexedAccessType) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type       string          `json:"type"`
+		ObjectType json.RawMessage `json:"objectType"`
+		IndexType  json.RawMessage `json:"indexType"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != iat.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, iat.Type(), x.Type)
+	}
+	if err == nil {
+		iat.Loc = x.Location()
+		iat.ObjectType, err = unmarshalType(x.ObjectType)
+		var err2 error
+		if iat.IndexType, err2 = unmarshalType(x.IndexType); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// TSConditionalType is a conditional type, e.g.
+// T extends string ? "str" : "other".
+type TSConditionalType struct {
+	baseType
+	Loc         estree.SourceLocation
+	CheckType   TSType
+	ExtendsType TSType
+	TrueType    TSType
+	FalseType   TSType
+}
+
+func (TSConditionalType) Type() string                       { return "TSConditionalType" }
+func (ct TSConditionalType) Location() estree.SourceLocation { return ct.Loc }
+
+func (ct TSConditionalType) IsZero() bool {
+	return ct.Loc.IsZero() &&
+		(ct.CheckType == nil || ct.CheckType.IsZero()) &&
+		(ct.ExtendsType == nil || ct.ExtendsType.IsZero()) &&
+		(ct.TrueType == nil || ct.TrueType.IsZero()) &&
+		(ct.FalseType == nil || ct.FalseType.IsZero())
+}
+
+func (ct TSConditionalType) Walk(v estree.Visitor) {
+	if v = v.Visit(ct); v != nil {
+		defer v.Visit(nil)
+		if ct.CheckType != nil {
+			ct.CheckType.Walk(v)
+		}
+		if ct.ExtendsType != nil {
+			ct.ExtendsType.Walk(v)
+		}
+		if ct.TrueType != nil {
+			ct.TrueType.Walk(v)
+		}
+		if ct.FalseType != nil {
+			ct.FalseType.Walk(v)
+		}
+	}
+}
+
+func (ct TSConditionalType) Errors() []error {
+	c := estree.NewChecker(ct)
+	c.Require(ct.CheckType, "checked type")
+	c.Require(ct.ExtendsType, "extends type")
+	c.Require(ct.TrueType, "true type")
+	c.Require(ct.FalseType, "false type")
+	return c.Errors()
+}
+
+func (ct TSConditionalType) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(ct)
+	x["checkType"] = ct.CheckType
+	x["extendsType"] = ct.ExtendsType
+	x["trueType"] = ct.TrueType
+	x["falseType"] = ct.FalseType
+	return json.Marshal(x)
+}
+
+func (ct *TSConditionalType) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type        string          `json:"type"`
+		CheckType   json.RawMessage `json:"checkType"`
+		ExtendsType json.RawMessage `json:"extendsType"`
+		TrueType    json.RawMessage `json:"trueType"`
+		FalseType   json.RawMessage `json:"falseType"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ct.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, ct.Type(), x.Type)
+	}
+	if err == nil {
+		ct.Loc = x.Location()
+		ct.CheckType, err = unmarshalType(x.CheckType)
+		var err2 error
+		if ct.ExtendsType, err2 = unmarshalType(x.ExtendsType); err == nil && err2 != nil {
+			err = err2
+		}
+		if ct.TrueType, err2 = unmarshalType(x.TrueType); err == nil && err2 != nil {
+			err = err2
+		}
+		if ct.FalseType, err2 = unmarshalType(x.FalseType); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// TSInferType declares a type variable within the ExtendsType of a
+// TSConditionalType, e.g. the infer U in T extends Array<infer U> ? U : never.
+type TSInferType struct {
+	baseType
+	Loc           estree.SourceLocation
+	TypeParameter TSTypeParameter
+}
+
+func (TSInferType) Type() string                       { return "TSInferType" }
+func (it TSInferType) Location() estree.SourceLocation { return it.Loc }
+
+func (it TSInferType) IsZero() bool {
+	return it.Loc.IsZero() && it.TypeParameter.IsZero()
+}
+
+func (it TSInferType) Walk(v estree.Visitor) {
+	if v = v.Visit(it); v != nil {
+		defer v.Visit(nil)
+		it.TypeParameter.Walk(v)
+	}
+}
+
+func (it TSInferType) Errors() []error {
+	c := estree.NewChecker(it)
+	c.Require(it.TypeParameter, "inferred type parameter")
+	return c.Errors()
+}
+
+func (it TSInferType) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(it)
+	x["typeParameter"] = it.TypeParameter
+	return json.Marshal(x)
+}
+
+func (it *TSInferType) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type          string          `json:"type"`
+		TypeParameter TSTypeParameter `json:"typeParameter"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != it.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, it.Type(), x.Type)
+	}
+	if err == nil {
+		it.Loc, it.TypeParameter = x.Location(), x.TypeParameter
+	}
+	return err
+}
+
+// TSMappedTypeModifier is a value for TSMappedType.Optional or
+// TSMappedType.Readonly, indicating whether the modifier is present, and
+// whether it adds or removes the property modifier.
+type TSMappedTypeModifier string
+
+const (
+	NoModifier     TSMappedTypeModifier = ""
+	HasModifier    TSMappedTypeModifier = "true" // readonly [K in T]: U
+	AddModifier    TSMappedTypeModifier = "+"    // +readonly [K in T]: U
+	RemoveModifier TSMappedTypeModifier = "-"    // -readonly [K in T]: U
+)
+
+func (mtm TSMappedTypeModifier) IsValid() bool {
+	switch mtm {
+	case NoModifier, HasModifier, AddModifier, RemoveModifier:
+		return true
+	}
+	return false
+}
+
+// MarshalJSON encodes HasModifier as true, as typescript-estree does, and
+// AddModifier and RemoveModifier as strings.
+func (mtm TSMappedTypeModifier) MarshalJSON() ([]byte, error) {
+	if mtm == HasModifier {
+		return []byte("true"), nil
+	}
+	return json.Marshal(string(mtm))
+}
+
+func (mtm *TSMappedTypeModifier) UnmarshalJSON(b []byte) error {
+	switch string(b) {
+	case "true":
+		*mtm = HasModifier
+		return nil
+	case "false", "null":
+		*mtm = NoModifier
+		return nil
+	}
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err == nil && s != string(AddModifier) && s != string(RemoveModifier) {
+		err = fmt.Errorf("%w TSMappedTypeModifier %q", estree.ErrWrongValue, s)
+	}
+	if err == nil {
+		*mtm = TSMappedTypeModifier(s)
+	}
+	return err
+}
+
+// TSMappedType is a mapped type, e.g. { readonly [K in keyof T]?: T[K] }.
+// The TypeParameter's Constraint is the type being iterated over.
+type TSMappedType struct {
+	baseType
+	Loc            estree.SourceLocation
+	TypeParameter  TSTypeParameter
+	NameType       TSType // or nil, e.g. the as clause in [K in T as Foo<K>]
+	TypeAnnotation TSType // or nil
+	Optional       TSMappedTypeModifier
+	Readonly       TSMappedTypeModifier
+}
+
+func (TSMappedType) Type() string                       { return "TSMappedType" }
+func (mt TSMappedType) Location() estree.SourceLocation { return mt.Loc }
+
+func (mt TSMappedType) IsZero() bool {
+	return mt.Loc.IsZero() &&
+		mt.TypeParameter.IsZero() &&
+		(mt.NameType == nil || mt.NameType.IsZero()) &&
+		(mt.TypeAnnotation == nil || mt.TypeAnnotation.IsZero()) &&
+		mt.Optional == NoModifier &&
+		mt.Readonly == NoModifier
+}
+
+func (mt TSMappedType) Walk(v estree.Visitor) {
+	if v = v.Visit(mt); v != nil {
+		defer v.Visit(nil)
+		mt.TypeParameter.Walk(v)
+		if mt.NameType != nil {
+			mt.NameType.Walk(v)
+		}
+		if mt.TypeAnnotation != nil {
+			mt.TypeAnnotation.Walk(v)
+		}
+	}
+}
+
+func (mt TSMappedType) Errors() []error {
+	c := estree.NewChecker(mt)
+	c.Require(mt.TypeParameter, "mapped type parameter")
+	if mt.TypeParameter.Constraint == nil {
+		c.Appendf("%w mapped type constraint", estree.ErrMissingNode)
+	}
+	if mt.TypeParameter.Default != nil {
+		c.Appendf("mapped type parameter default %w", estree.ErrNotAllowed)
+	}
+	c.Optional(mt.NameType)
+	c.Optional(mt.TypeAnnotation)
+	if !mt.Optional.IsValid() {
+		c.Appendf("%w TSMappedType.Optional %q", estree.ErrWrongValue, mt.Optional)
+	}
+	if !mt.Readonly.IsValid() {
+		c.Appendf("%w TSMappedType.Readonly %q", estree.ErrWrongValue, mt.Readonly)
+	}
+	return c.Errors()
+}
+
+func (mt TSMappedType) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(mt)
+	x["typeParameter"] = mt.TypeParameter
+	x["nameType"] = mt.NameType
+	x["typeAnnotation"] = mt.TypeAnnotation
+	if mt.Optional != NoModifier {
+		x["optional"] = mt.Optional
+	}
+	if mt.Readonly != NoModifier {
+		x["readonly"] = mt.Readonly
+	}
+	return json.Marshal(x)
+}
+
+func (mt *TSMappedType) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type           string               `json:"type"`
+		TypeParameter  TSTypeParameter      `json:"typeParameter"`
+		NameType       json.RawMessage      `json:"nameType"`
+		TypeAnnotation json.RawMessage      `json:"typeAnnotation"`
+		Optional       TSMappedTypeModifier `json:"optional"`
+		Readonly       TSMappedTypeModifier `json:"readonly"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != mt.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, mt.Type(), x.Type)
+	}
+	if err == nil {
+		mt.Loc, mt.TypeParameter = x.Location(), x.TypeParameter
+		mt.Optional, mt.Readonly = x.Optional, x.Readonly
+		mt.NameType, err = unmarshalType(x.NameType)
+		var err2 error
+		if mt.TypeAnnotation, err2 = unmarshalType(x.TypeAnnotation); err == nil && err2 != nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// TSThisType is the polymorphic this type, e.g. the return type in
+// clone(): this.
+type TSThisType struct {
+	baseType
+	Loc estree.SourceLocation
+}
+
+func (TSThisType) Type() string                       { return "TSThisType" }
+func (tt TSThisType) Location() estree.SourceLocation { return tt.Loc }
+func (TSThisType) IsZero() bool                       { return false }
+func (TSThisType) Errors() []error                    { return nil }
+
+func (tt TSThisType) Walk(v estree.Visitor) {
+	if v = v.Visit(tt); v != nil {
+		v.Visit(nil)
+	}
+}
+
+func (tt TSThisType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(estree.NodeToMap(tt))
+}
+
+func (tt *TSThisType) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != tt.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, tt.Type(), x.Type)
+	}
+	if err == nil {
+		tt.Loc = x.Location()
+	}
+	return err
+}
+
+// TSImportType is a type imported from another module, e.g.
+// import("foo").Bar<string>.
+type TSImportType struct {
+	baseType
+	Loc           estree.SourceLocation
+	Argument      TSType                       // the module name, a TSLiteralType
+	Qualifier     estree.Node                  // Identifier, TSQualifiedName, or nil
+	TypeArguments TSTypeParameterInstantiation // possibly zero
+}
+
+func (TSImportType) Type() string                       { return "TSImportType" }
+func (it TSImportType) Location() estree.SourceLocation { return it.Loc }
+
+func (it TSImportType) IsZero() bool {
+	return it.Loc.IsZero() &&
+		(it.Argument == nil || it.Argument.IsZero()) &&
+		(it.Qualifier == nil || it.Qualifier.IsZero()) &&
+		it.TypeArguments.IsZero()
+}
+
+func (it TSImportType) Walk(v estree.Visitor) {
+	if v = v.Visit(it); v != nil {
+		defer v.Visit(nil)
+		if it.Argument != nil {
+			it.Argument.Walk(v)
+		}
+		if it.Qualifier != nil {
+			it.Qualifier.Walk(v)
+		}
+		if !it.TypeArguments.IsZero() {
+			it.TypeArguments.Walk(v)
+		}
+	}
+}
+
+func (it TSImportType) Errors() []error {
+	c := estree.NewChecker(it)
+	c.Require(it.Argument, "module name")
+	switch a := it.Argument.(type) {
+	case nil:
+	case TSLiteralType:
+		if _, ok := a.Literal.(estree.StringLiteral); !ok {
+			c.Appendf("%w module name %s", estree.ErrWrongValue, a.Literal.Type())
+		}
+	default:
+		c.Appendf("%w module name %s", estree.ErrWrongValue, a.Type())
+	}
+	c.Optional(it.Qualifier)
+	checkEntityName(c, it.Qualifier, "import qualifier")
+	c.Optional(it.TypeArguments)
+	return c.Errors()
+}
+
+func (it TSImportType) MarshalJSON() ([]byte, error) {
+	x := estree.NodeToMap(it)
+	x["argument"] = it.Argument
+	x["qualifier"] = it.Qualifier
+	if it.TypeArguments.IsZero() {
+		x["typeArguments"] = nil
+	} else {
+		x["typeArguments"] = it.TypeArguments
+	}
+	return json.Marshal(x)
+}
+
+func (it *TSImportType) UnmarshalJSON(b []byte) error {
+	var x struct {
+		estree.NodeFields
+
+		Type          string                       `json:"type"`
+		Argument      json.RawMessage              `json:"argument"`
+		Qualifier     json.RawMessage              `json:"qualifier"`
+		TypeArguments TSTypeParameterInstantiation `json:"typeArguments"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != it.Type() {
+		err = fmt.Errorf("%w %s, got %q", estree.ErrWrongType, it.Type(), x.Type)
+	}
+	if err == nil {
+		it.Loc, it.TypeArguments = x.Location(), x.TypeArguments
+		it.Argument, err = unmarshalType(x.Argument)
+		if !isNull(x.Qualifier) {
+			var err2 error
+			if it.Qualifier, err2 = unmarshalEntityName(x.Qualifier); err == nil && err2 != nil {
+				err = err2
+			}
+		}
+	}
+	return err
+}
@@ -0,0 +1,497 @@
+package ts
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"pifke.org/estree"
+)
+
+func TestTSKeyword(t *testing.T) {
+	var k TSKeyword
+	if !k.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	k.Kind = Unknown
+	if k.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if k.Type() != "TSUnknownKeyword" {
+		t.Errorf("expected TSUnknownKeyword, got %s", k.Type())
+	}
+
+	var v mockVisitor
+	k.Walk(&v)
+	v.expect(t, k, nil)
+
+	testRoundtripJSON(t, k, new(TSKeyword))
+
+	if errs := k.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	k.Kind = "TSFooKeyword"
+	if !hasError(estree.ErrWrongValue, k.Errors()...) {
+		t.Error("expected ErrWrongValue for invalid Kind")
+	}
+}
+
+func TestTSTypeReference(t *testing.T) {
+	var tr TSTypeReference
+	if !tr.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// Foo.Bar<string>
+	tr.TypeName = TSQualifiedName{
+		Left:  estree.Identifier{Name: "Foo"},
+		Right: estree.Identifier{Name: "Bar"},
+	}
+	tr.TypeArguments = TSTypeParameterInstantiation{
+		Params: []TSType{TSKeyword{Kind: String}},
+	}
+	if tr.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if tr.MinVersion() != estree.ES5 {
+		t.Errorf("expected ES5, got %s", tr.MinVersion())
+	}
+
+	qn := tr.TypeName.(TSQualifiedName)
+	var v mockVisitor
+	tr.Walk(&v)
+	v.expect(t, tr,
+		qn, qn.Left, nil, qn.Right, nil, nil,
+		tr.TypeArguments, tr.TypeArguments.Params[0], nil, nil, nil)
+
+	testRoundtripJSON(t, tr, new(TSTypeReference))
+	testRoundtripJSON(t, qn, new(TSQualifiedName))
+
+	if errs := estree.Validate(tr); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	tr.TypeArguments = TSTypeParameterInstantiation{}
+	testRoundtripJSON(t, tr, new(TSTypeReference))
+	if errs := tr.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	tr.TypeName = estree.StringLiteral{Value: "Foo"}
+	if !hasError(estree.ErrWrongValue, tr.Errors()...) {
+		t.Error("expected ErrWrongValue for StringLiteral TypeName")
+	}
+	tr.TypeName = nil
+	if !hasError(estree.ErrMissingNode, tr.Errors()...) {
+		t.Error("expected ErrMissingNode for nil TypeName")
+	}
+	if !hasError(estree.ErrMissingNode, TSTypeParameterInstantiation{}.Errors()...) {
+		t.Error("expected ErrMissingNode for empty TSTypeParameterInstantiation")
+	}
+}
+
+func TestTSUnionType(t *testing.T) {
+	var ut TSUnionType
+	if !ut.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// "foo" | -1 | null
+	ut.Types = []TSType{
+		TSLiteralType{Literal: estree.StringLiteral{Value: "foo"}},
+		TSLiteralType{Literal: estree.UnaryExpression{
+			Operator: estree.Minus,
+			Prefix:   true,
+			Argument: estree.NumberLiteral{Value: 1},
+		}},
+		TSKeyword{Kind: Null},
+	}
+	if ut.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	var v mockVisitor
+	ut.Walk(&v)
+	v.expect(t, ut,
+		ut.Types[0], ut.Types[0].(TSLiteralType).Literal, nil, nil,
+		ut.Types[1], ut.Types[1].(TSLiteralType).Literal,
+		ut.Types[1].(TSLiteralType).Literal.(estree.UnaryExpression).Argument, nil, nil, nil,
+		ut.Types[2], nil, nil)
+
+	testRoundtripJSON(t, ut, new(TSUnionType))
+
+	if errs := estree.Validate(ut); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ut.Types[1] = TSLiteralType{Literal: estree.Identifier{Name: "foo"}}
+	if !hasError(estree.ErrWrongValue, ut.Types[1].Errors()...) {
+		t.Error("expected ErrWrongValue for Identifier literal type")
+	}
+	ut.Types[1] = nil
+	if !hasError(estree.ErrMissingNode, ut.Errors()...) {
+		t.Error("expected ErrMissingNode for nil member")
+	}
+	ut.Types = nil
+	if !hasError(estree.ErrMissingNode, ut.Errors()...) {
+		t.Error("expected ErrMissingNode for empty union")
+	}
+
+	it := TSIntersectionType{Types: []TSType{
+		TSTypeReference{TypeName: estree.Identifier{Name: "Foo"}},
+		TSTypeReference{TypeName: estree.Identifier{Name: "Bar"}},
+	}}
+	testRoundtripJSON(t, it, new(TSIntersectionType))
+	if errs := estree.Validate(it); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestTSTypeLiteral(t *testing.T) {
+	var tl TSTypeLiteral
+	if tl.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	// { readonly foo?: string; [bar]: (x: number) => void; [k: string]: any }
+	tl.Members = []TSTypeElement{
+		TSPropertySignature{
+			Key:            estree.Identifier{Name: "foo"},
+			TypeAnnotation: TSTypeAnnotation{TypeAnnotation: TSKeyword{Kind: String}},
+			Optional:       true,
+			Readonly:       true,
+		},
+		TSPropertySignature{
+			Key: estree.Identifier{Name: "bar"},
+			TypeAnnotation: TSTypeAnnotation{TypeAnnotation: TSFunctionType{
+				Params: []estree.Pattern{
+					estree.Identifier{
+						Name:           "x",
+						TypeAnnotation: TSTypeAnnotation{TypeAnnotation: TSKeyword{Kind: Number}},
+					},
+				},
+				ReturnType: TSTypeAnnotation{TypeAnnotation: TSKeyword{Kind: Void}},
+			}},
+			Computed: true,
+		},
+		TSIndexSignature{
+			Parameters: []estree.Identifier{
+				estree.Identifier{
+					Name:           "k",
+					TypeAnnotation: TSTypeAnnotation{TypeAnnotation: TSKeyword{Kind: String}},
+				},
+			},
+			TypeAnnotation: TSTypeAnnotation{TypeAnnotation: TSKeyword{Kind: Any}},
+		},
+	}
+
+	testRoundtripJSON(t, tl, new(TSTypeLiteral))
+	testRoundtripJSON(t, TSTypeLiteral{}, new(TSTypeLiteral))
+
+	if errs := estree.Validate(tl); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	tl.Members[0] = TSPropertySignature{
+		Key: estree.CallExpression{Callee: estree.Identifier{Name: "foo"}},
+	}
+	if !hasError(estree.ErrWrongValue, tl.Members[0].Errors()...) {
+		t.Error("expected ErrWrongValue for CallExpression key without Computed")
+	}
+	tl.Members[2] = TSIndexSignature{Parameters: []estree.Identifier{estree.Identifier{Name: "k"}}}
+	if !hasError(estree.ErrMissingNode, tl.Members[2].Errors()...) {
+		t.Error("expected ErrMissingNode for untyped index signature parameter")
+	}
+	tl.Members[0] = TSPropertySignature{}
+	if !hasError(estree.ErrMissingNode, tl.Errors()...) {
+		t.Error("expected ErrMissingNode for zero member")
+	}
+	if !hasError(estree.ErrMissingNode, TSFunctionType{}.Errors()...) {
+		t.Error("expected ErrMissingNode for missing return type")
+	}
+}
+
+func TestTSTupleType(t *testing.T) {
+	var tt TSTupleType
+	if tt.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	// [start: number, string?, ...boolean[]]
+	tt.ElementTypes = []TSType{
+		TSNamedTupleMember{
+			Label:       estree.Identifier{Name: "start"},
+			ElementType: TSKeyword{Kind: Number},
+		},
+		TSOptionalType{TypeAnnotation: TSKeyword{Kind: String}},
+		TSRestType{TypeAnnotation: TSArrayType{ElementType: TSKeyword{Kind: Boolean}}},
+	}
+
+	var v mockVisitor
+	tt.Walk(&v)
+	nm := tt.ElementTypes[0].(TSNamedTupleMember)
+	ot, rt := tt.ElementTypes[1].(TSOptionalType), tt.ElementTypes[2].(TSRestType)
+	at := rt.TypeAnnotation.(TSArrayType)
+	v.expect(t, tt,
+		nm, nm.Label, nil, nm.ElementType, nil, nil,
+		ot, ot.TypeAnnotation, nil, nil,
+		rt, at, at.ElementType, nil, nil, nil, nil)
+
+	testRoundtripJSON(t, tt, new(TSTupleType))
+	testRoundtripJSON(t, TSTupleType{}, new(TSTupleType))
+	testRoundtripJSON(t, nm, new(TSNamedTupleMember))
+	testRoundtripJSON(t, ot, new(TSOptionalType))
+	testRoundtripJSON(t, rt, new(TSRestType))
+
+	if errs := estree.Validate(tt); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	tt.ElementTypes[0] = TSNamedTupleMember{ElementType: TSKeyword{Kind: Number}}
+	if !hasError(estree.ErrMissingNode, tt.ElementTypes[0].Errors()...) {
+		t.Error("expected ErrMissingNode for missing label")
+	}
+	tt.ElementTypes[1] = nil
+	if !hasError(estree.ErrMissingNode, tt.Errors()...) {
+		t.Error("expected ErrMissingNode for nil element")
+	}
+}
+
+func TestTSTypeOperator(t *testing.T) {
+	var to TSTypeOperator
+	if !to.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// keyof Foo
+	to.Operator = KeyofOperator
+	to.TypeAnnotation = TSTypeReference{TypeName: estree.Identifier{Name: "Foo"}}
+	if to.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	var v mockVisitor
+	to.Walk(&v)
+	v.expect(t, to,
+		to.TypeAnnotation, to.TypeAnnotation.(TSTypeReference).TypeName, nil, nil, nil)
+
+	testRoundtripJSON(t, to, new(TSTypeOperator))
+
+	if errs := estree.Validate(to); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	to.Operator = UniqueOperator
+	if !hasError(estree.ErrNotAllowed, to.Errors()...) {
+		t.Error("expected ErrNotAllowed for unique without symbol")
+	}
+	to.TypeAnnotation = TSKeyword{Kind: Symbol}
+	if errs := to.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	to.Operator = "typeof"
+	if !hasError(estree.ErrWrongValue, to.Errors()...) {
+		t.Error("expected ErrWrongValue for invalid Operator")
+	}
+	b := []byte(`{"type":"TSTypeOperator","operator":"typeof","typeAnnotation":{"type":"TSAnyKeyword"}}`)
+	if err := json.Unmarshal(b, new(TSTypeOperator)); !hasError(estree.ErrWrongValue, err) {
+		t.Errorf("expected ErrWrongValue, got %v", err)
+	}
+}
+
+func TestTSTypeQuery(t *testing.T) {
+	var tq TSTypeQuery
+	if !tq.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// typeof foo.bar<string>
+	tq.ExprName = TSQualifiedName{
+		Left:  estree.Identifier{Name: "foo"},
+		Right: estree.Identifier{Name: "bar"},
+	}
+	tq.TypeArguments = TSTypeParameterInstantiation{Params: []TSType{TSKeyword{Kind: String}}}
+
+	var v mockVisitor
+	tq.Walk(&v)
+	qn := tq.ExprName.(TSQualifiedName)
+	v.expect(t, tq,
+		qn, qn.Left, nil, qn.Right, nil, nil,
+		tq.TypeArguments, tq.TypeArguments.Params[0], nil, nil, nil)
+
+	testRoundtripJSON(t, tq, new(TSTypeQuery))
+
+	if errs := estree.Validate(tq); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	// typeof import("foo")
+	tq = TSTypeQuery{ExprName: TSImportType{
+		Argument: TSLiteralType{Literal: estree.StringLiteral{Value: "foo"}},
+	}}
+	testRoundtripJSON(t, tq, new(TSTypeQuery))
+	if errs := estree.Validate(tq); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	tq.ExprName = estree.StringLiteral{Value: "foo"}
+	if !hasError(estree.ErrWrongValue, tq.Errors()...) {
+		t.Error("expected ErrWrongValue for StringLiteral ExprName")
+	}
+}
+
+func TestTSConditionalType(t *testing.T) {
+	var ct TSConditionalType
+	if !ct.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// T extends Array<infer U> ? U : T["length"]
+	ct.CheckType = TSTypeReference{TypeName: estree.Identifier{Name: "T"}}
+	ct.ExtendsType = TSTypeReference{
+		TypeName: estree.Identifier{Name: "Array"},
+		TypeArguments: TSTypeParameterInstantiation{Params: []TSType{
+			TSInferType{TypeParameter: TSTypeParameter{Name: estree.Identifier{Name: "U"}}},
+		}},
+	}
+	ct.TrueType = TSTypeReference{TypeName: estree.Identifier{Name: "U"}}
+	ct.FalseType = TSIndexedAccessType{
+		ObjectType: TSTypeReference{TypeName: estree.Identifier{Name: "T"}},
+		IndexType:  TSLiteralType{Literal: estree.StringLiteral{Value: "length"}},
+	}
+	if ct.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	testRoundtripJSON(t, ct, new(TSConditionalType))
+	it := ct.ExtendsType.(TSTypeReference).TypeArguments.Params[0].(TSInferType)
+	testRoundtripJSON(t, it, new(TSInferType))
+	iat := ct.FalseType.(TSIndexedAccessType)
+	testRoundtripJSON(t, iat, new(TSIndexedAccessType))
+
+	var v mockVisitor
+	iat.Walk(&v)
+	v.expect(t, iat,
+		iat.ObjectType, iat.ObjectType.(TSTypeReference).TypeName, nil, nil,
+		iat.IndexType, iat.IndexType.(TSLiteralType).Literal, nil, nil, nil)
+
+	if errs := estree.Validate(ct); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ct.FalseType = TSIndexedAccessType{ObjectType: iat.ObjectType}
+	if !hasError(estree.ErrMissingNode, estree.Validate(ct)...) {
+		t.Error("expected ErrMissingNode for missing IndexType")
+	}
+	ct.FalseType = nil
+	if !hasError(estree.ErrMissingNode, ct.Errors()...) {
+		t.Error("expected ErrMissingNode for nil FalseType")
+	}
+	if !hasError(estree.ErrMissingNode, TSInferType{}.Errors()...) {
+		t.Error("expected ErrMissingNode for missing TypeParameter")
+	}
+}
+
+func TestTSMappedType(t *testing.T) {
+	var mt TSMappedType
+	if !mt.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// { -readonly [K in keyof T]?: T[K] }
+	mt.TypeParameter = TSTypeParameter{
+		Name: estree.Identifier{Name: "K"},
+		Constraint: TSTypeOperator{
+			Operator:       KeyofOperator,
+			TypeAnnotation: TSTypeReference{TypeName: estree.Identifier{Name: "T"}},
+		},
+	}
+	mt.TypeAnnotation = TSIndexedAccessType{
+		ObjectType: TSTypeReference{TypeName: estree.Identifier{Name: "T"}},
+		IndexType:  TSTypeReference{TypeName: estree.Identifier{Name: "K"}},
+	}
+	mt.Optional = HasModifier
+	mt.Readonly = RemoveModifier
+	if mt.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	testRoundtripJSON(t, mt, new(TSMappedType))
+	b, err := json.Marshal(mt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b, []byte(`"optional":true`)) || !bytes.Contains(b, []byte(`"readonly":"-"`)) {
+		t.Errorf("unexpected modifiers in %s", b)
+	}
+
+	if errs := estree.Validate(mt); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	mt.Readonly = "?"
+	if !hasError(estree.ErrWrongValue, mt.Errors()...) {
+		t.Error("expected ErrWrongValue for invalid Readonly")
+	}
+	mt.Readonly = NoModifier
+	mt.TypeParameter.Constraint = nil
+	if !hasError(estree.ErrMissingNode, mt.Errors()...) {
+		t.Error("expected ErrMissingNode for missing constraint")
+	}
+
+	var m TSMappedTypeModifier
+	if err := json.Unmarshal([]byte(`"?"`), &m); !hasError(estree.ErrWrongValue, err) {
+		t.Errorf("expected ErrWrongValue, got %v", err)
+	}
+	if err := json.Unmarshal([]byte(`false`), &m); err != nil || m != NoModifier {
+		t.Errorf("expected NoModifier, got %q, %v", m, err)
+	}
+}
+
+func TestTSImportType(t *testing.T) {
+	var it TSImportType
+	if !it.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	// import("foo").Bar<this>
+	it.Argument = TSLiteralType{Literal: estree.StringLiteral{Value: "foo"}}
+	it.Qualifier = estree.Identifier{Name: "Bar"}
+	it.TypeArguments = TSTypeParameterInstantiation{Params: []TSType{TSThisType{}}}
+	if it.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+
+	var v mockVisitor
+	it.Walk(&v)
+	v.expect(t, it,
+		it.Argument, it.Argument.(TSLiteralType).Literal, nil, nil,
+		it.Qualifier, nil,
+		it.TypeArguments, it.TypeArguments.Params[0], nil, nil, nil)
+
+	testRoundtripJSON(t, it, new(TSImportType))
+	testRoundtripJSON(t, TSThisType{}, new(TSThisType))
+	it.Qualifier = nil
+	testRoundtripJSON(t, it, new(TSImportType))
+
+	if errs := estree.Validate(it); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	it.Argument = TSLiteralType{Literal: estree.NumberLiteral{Value: 1}}
+	if !hasError(estree.ErrWrongValue, it.Errors()...) {
+		t.Error("expected ErrWrongValue for non-string module name")
+	}
+	it.Argument = nil
+	if !hasError(estree.ErrMissingNode, it.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Argument")
+	}
+}
+
+func TestUnmarshalType(t *testing.T) {
+	b := []byte(`{"type":"TSArrayType","elementType":{"type":"TSTypeReference",` +
+		`"typeName":{"type":"Identifier","name":"Foo"},"typeArguments":null}}`)
+	typ, err := unmarshalType(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := TSArrayType{ElementType: TSTypeReference{TypeName: estree.Identifier{Name: "Foo"}}}
+	if !reflect.DeepEqual(typ, expect) {
+		t.Errorf("expected %#v, got %#v", expect, typ)
+	}
+	if _, err := unmarshalType([]byte(`{"type":"Identifier","name":"Foo"}`)); !hasError(estree.ErrWrongType, err) {
+		t.Errorf("expected ErrWrongType, got %v", err)
+	}
+}
@@ -83,6 +83,11 @@ func (ue UnaryExpression) Errors() []error {
 	if !ue.Operator.IsValid() {
 		c.appendf("%w UnaryOperator %q", ErrWrongValue, ue.Operator)
 	}
+	if me, ok := ue.Argument.(MemberExpression); ok && ue.Operator == Delete {
+		if _, ok := me.Property.(PrivateIdentifier); ok {
+			c.appendf("delete of private name %w", ErrNotAllowed)
+		}
+	}
 	if !ue.Prefix {
 		c.require(ue.Argument, "unary argument")
 	}
@@ -99,8 +104,9 @@ func (ue UnaryExpression) MarshalJSON() ([]byte, error) {
 
 func (ue *UnaryExpression) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type     string          `json:"type"`
-		Loc      SourceLocation  `json:"loc"`
 		Operator UnaryOperator   `json:"operator"`
 		Prefix   bool            `json:"prefix"`
 		Argument json.RawMessage `json:"argument"`
@@ -110,7 +116,7 @@ func (ue *UnaryExpression) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ue.Type(), x.Type)
 	}
 	if err == nil {
-		ue.Loc, ue.Prefix = x.Loc, x.Prefix
+		ue.Loc, ue.Prefix = x.Location(), x.Prefix
 		if x.Operator.IsValid() {
 			ue.Operator = x.Operator
 		} else {
@@ -181,6 +187,9 @@ func (ue UpdateExpression) Walk(v Visitor) {
 
 func (ue UpdateExpression) Errors() []error {
 	c := nodeChecker{Node: ue}
+	if _, ok := ue.Argument.(ChainExpression); ok {
+		c.appendf("optional chain as update target %w", ErrNotAllowed)
+	}
 	if ue.Prefix {
 		c.require(ue.Argument, "update argument")
 	}
@@ -203,8 +212,9 @@ func (ue UpdateExpression) MarshalJSON() ([]byte, error) {
 
 func (ue *UpdateExpression) UnmarshalJSON(b []byte) error {
 	var x struct {
+		NodeFields
+
 		Type     string          `json:"type"`
-		Loc      SourceLocation  `json:"loc"`
 		Operator UpdateOperator  `json:"operator"`
 		Argument json.RawMessage `json:"argument"`
 		Prefix   bool            `json:"prefix"`
@@ -214,7 +224,7 @@ func (ue *UpdateExpression) UnmarshalJSON(b []byte) error {
 		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ue.Type(), x.Type)
 	}
 	if err == nil {
-		ue.Loc, ue.Prefix = x.Loc, x.Prefix
+		ue.Loc, ue.Prefix = x.Location(), x.Prefix
 		if x.Operator.IsValid() {
 			ue.Operator = x.Operator
 		} else {
@@ -227,3 +237,155 @@ func (ue *UpdateExpression) UnmarshalJSON(b []byte) error {
 	}
 	return err
 }
+
+// YieldExpression yields a value from a generator function.  If Delegate is
+// set, it delegates to another iterable, e.g. yield* foo.
+type YieldExpression struct {
+	baseExpression
+	Loc      SourceLocation
+	Argument Expression // or nil
+	Delegate bool
+}
+
+func (YieldExpression) Type() string                { return "YieldExpression" }
+func (ye YieldExpression) Location() SourceLocation { return ye.Loc }
+func (YieldExpression) MinVersion() Version         { return ES2015 }
+func (YieldExpression) IsZero() bool                { return false }
+
+func (ye YieldExpression) Walk(v Visitor) {
+	if v = v.Visit(ye); v != nil {
+		defer v.Visit(nil)
+		if ye.Argument != nil {
+			ye.Argument.Walk(v)
+		}
+	}
+}
+
+// Errors checks the YieldExpression.  Yield is only allowed in a generator
+// function; since that can't be determined without the ancestor Nodes, use
+// Validate to check it.
+func (ye YieldExpression) Errors() []error {
+	return ye.errors(true)
+}
+
+func (ye YieldExpression) contextErrors(ctx validationContext) []error {
+	return ye.errors(yieldAllowed(ctx.ancestors))
+}
+
+func (ye YieldExpression) errors(allowYield bool) []error {
+	c := nodeChecker{Node: ye}
+	if !allowYield {
+		c.appendf("yield outside of generator function %w", ErrNotAllowed)
+	}
+	if ye.Delegate {
+		c.require(ye.Argument, "yield* argument")
+	} else {
+		c.optional(ye.Argument)
+	}
+	return c.errors()
+}
+
+func (ye YieldExpression) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(ye)
+	x["argument"] = ye.Argument
+	x["delegate"] = ye.Delegate
+	return json.Marshal(x)
+}
+
+func (ye *YieldExpression) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type     string          `json:"type"`
+		Argument json.RawMessage `json:"argument"`
+		Delegate bool            `json:"delegate"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ye.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ye.Type(), x.Type)
+	}
+	if err == nil {
+		ye.Loc, ye.Delegate = x.Location(), x.Delegate
+		ye.Argument, _, err = unmarshalExpression(x.Argument)
+	}
+	return err
+}
+
+// AwaitExpression waits for a Promise to settle, e.g. await foo.
+type AwaitExpression struct {
+	baseExpression
+	Loc      SourceLocation
+	Argument Expression
+}
+
+func (AwaitExpression) Type() string                { return "AwaitExpression" }
+func (ae AwaitExpression) Location() SourceLocation { return ae.Loc }
+
+// MinVersion reports ES2017, which introduced await in async functions.  Await
+// at the top level of a module requires ES2022; since that can't be determined
+// without the ancestor Nodes, use ValidateOptions.Version to check it.
+func (AwaitExpression) MinVersion() Version { return ES2017 }
+
+func (ae AwaitExpression) contextMinVersion(ctx validationContext) Version {
+	if enclosingFunction(ctx.ancestors) == nil && awaitAllowed(ctx.ancestors) {
+		return ES2022
+	}
+	return ae.MinVersion()
+}
+
+func (ae AwaitExpression) IsZero() bool {
+	return ae.Loc.IsZero() && (ae.Argument == nil || ae.Argument.IsZero())
+}
+
+func (ae AwaitExpression) Walk(v Visitor) {
+	if v = v.Visit(ae); v != nil {
+		defer v.Visit(nil)
+		if ae.Argument != nil {
+			ae.Argument.Walk(v)
+		}
+	}
+}
+
+// Errors checks the AwaitExpression.  Await is only allowed in an async
+// function or at the top level of a module; since that can't be determined
+// without the ancestor Nodes, use Validate to check it.
+func (ae AwaitExpression) Errors() []error {
+	return ae.errors(true)
+}
+
+func (ae AwaitExpression) contextErrors(ctx validationContext) []error {
+	return ae.errors(awaitAllowed(ctx.ancestors))
+}
+
+func (ae AwaitExpression) errors(allowAwait bool) []error {
+	c := nodeChecker{Node: ae}
+	if !allowAwait {
+		c.appendf("await outside of async function %w", ErrNotAllowed)
+	}
+	c.require(ae.Argument, "await argument")
+	return c.errors()
+}
+
+func (ae AwaitExpression) MarshalJSON() ([]byte, error) {
+	x := nodeToMap(ae)
+	x["argument"] = ae.Argument
+	return json.Marshal(x)
+}
+
+func (ae *AwaitExpression) UnmarshalJSON(b []byte) error {
+	var x struct {
+		NodeFields
+
+		Type     string          `json:"type"`
+		Argument json.RawMessage `json:"argument"`
+	}
+	err := json.Unmarshal(b, &x)
+	if err == nil && x.Type != ae.Type() {
+		err = fmt.Errorf("%w %s, got %q", ErrWrongType, ae.Type(), x.Type)
+	}
+	if err == nil {
+		ae.Loc = x.Location()
+		ae.Argument, _, err = unmarshalExpression(x.Argument)
+	}
+	return err
+}
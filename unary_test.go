@@ -83,3 +83,116 @@ func TestUpdateExpression(t *testing.T) {
 		t.Error("expected ErrMissingNode for nil Argument")
 	}
 }
+
+func TestYieldExpression(t *testing.T) {
+	var ye YieldExpression
+	if ye.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if ye.MinVersion() != ES2015 {
+		t.Errorf("expected ES2015, got %s", ye.MinVersion())
+	}
+
+	ye.Argument = Identifier{Name: "foo"}
+	ye.Delegate = true
+
+	var v mockVisitor
+	ye.Walk(&v)
+	v.expect(t, ye, ye.Argument, nil, nil)
+
+	testRoundtripJSON(t, ye, new(YieldExpression))
+
+	if errs := ye.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	fe := FunctionExpression{
+		Body: FunctionBody{
+			Body: []DirectiveOrStatement{
+				ExpressionStatement{Expression: ye},
+			},
+		},
+		Generator: true,
+	}
+	if errs := Validate(fe); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	fe.Generator = false
+	if !hasError(ErrNotAllowed, Validate(fe)...) {
+		t.Error("expected ErrNotAllowed for yield outside of generator")
+	}
+	fe.Generator = true
+	fe.Body.Body[0] = ExpressionStatement{
+		Expression: ArrowFunctionExpression{Body: ye, Expression: true},
+	}
+	if !hasError(ErrNotAllowed, Validate(fe)...) {
+		t.Error("expected ErrNotAllowed for yield in arrow function")
+	}
+	ye.Argument = nil
+	if !hasError(ErrMissingNode, ye.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Argument with Delegate")
+	}
+	ye.Delegate = false
+	if errs := ye.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestAwaitExpression(t *testing.T) {
+	var ae AwaitExpression
+	if !ae.IsZero() {
+		t.Error("expected IsZero()")
+	}
+
+	ae.Argument = CallExpression{Callee: Identifier{Name: "foo"}}
+	if ae.IsZero() {
+		t.Error("expected !IsZero()")
+	}
+	if ae.MinVersion() != ES2017 {
+		t.Errorf("expected ES2017, got %s", ae.MinVersion())
+	}
+
+	var v mockVisitor
+	ae.Walk(&v)
+	v.expect(t, ae,
+		ae.Argument,
+		ae.Argument.(CallExpression).Callee, nil, nil, nil)
+
+	testRoundtripJSON(t, ae, new(AwaitExpression))
+
+	if errs := ae.Errors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	afe := ArrowFunctionExpression{Body: ae, Expression: true, Async: true}
+	if errs := Validate(afe); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	afe.Async = false
+	if !hasError(ErrNotAllowed, Validate(afe)...) {
+		t.Error("expected ErrNotAllowed for await outside of async function")
+	}
+	p := Program{
+		Body:       []DirectiveOrStatement{ExpressionStatement{Expression: ae}},
+		SourceType: Module,
+	}
+	if errs := Validate(p); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if errs := (ValidateOptions{Version: ES2022}).Validate(p); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !hasError(ErrNotAllowed, ValidateOptions{Version: ES2021}.Validate(p)...) {
+		t.Error("expected ErrNotAllowed for top-level await before ES2022")
+	}
+	afe.Async = true
+	if errs := (ValidateOptions{Version: ES2017}).Validate(afe); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	p.SourceType = Script
+	if !hasError(ErrNotAllowed, Validate(p)...) {
+		t.Error("expected ErrNotAllowed for await at top level of script")
+	}
+	ae.Argument = nil
+	if !hasError(ErrMissingNode, ae.Errors()...) {
+		t.Error("expected ErrMissingNode for nil Argument")
+	}
+}
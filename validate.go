@@ -0,0 +1,132 @@
+package estree
+
+import "fmt"
+
+// Validate performs a depth-first search of the AST rooted at n, returning
+// the Errors for each Node encountered.  It is equivalent to calling
+// ValidateOptions.Validate with the zero value.
+//
+// Unlike calling Errors on each Node, Validate also reports errors which
+// depend on where a Node appears in the AST, and suppresses errors which are
+// only spurious outside of the parent Node.  For example, a const declaration
+// without initializers is not an error when it appears as the left-hand side
+// of a ForInStatement.
+func Validate(n Node) []error {
+	return ValidateOptions{}.Validate(n)
+}
+
+// ValidateOptions controls which errors Validate reports.  The zero value
+// is suitable for ASTs produced by any ESTree parser.
+type ValidateOptions struct {
+	// PreserveParens indicates that the AST was parsed with parentheses
+	// preserved as ParenthesizedExpressions, e.g. by Acorn with
+	// preserveParens.  ESTree does not otherwise record parentheses, so
+	// -a ** b and (-a) ** b are the same tree; with this option, operands
+	// which must be parenthesized, such as the UnaryExpression in (-a) ** b
+	// or the LogicalExpression in (a ?? b) || c, are reported unless they
+	// are wrapped in a ParenthesizedExpression.
+	PreserveParens bool
+
+	// Version, if non-zero, is the highest version of the ECMAScript
+	// specification the AST may use.  Validate reports ErrNotAllowed for
+	// each Node requiring a later Version.  Some Nodes require a later
+	// Version than MinVersion reports depending on where they appear, e.g.
+	// an AwaitExpression at the top level of a module requires ES2022;
+	// Validate takes this into account.
+	Version Version
+}
+
+// Validate performs a depth-first search of the AST rooted at n, returning
+// the Errors for each Node encountered, according to the options.
+func (vo ValidateOptions) Validate(n Node) []error {
+	v := validator{validationContext: validationContext{ValidateOptions: vo}}
+	n.Walk(&v)
+	return v.errs
+}
+
+// contextChecker is implemented by Nodes whose validity depends on their
+// ancestors.  Validate calls contextErrors in place of Errors.
+type contextChecker interface {
+	// contextErrors is like Errors, but also receives the position of the
+	// Node within the AST.
+	contextErrors(ctx validationContext) []error
+}
+
+// contextVersioner is implemented by Nodes whose MinVersion depends on their
+// ancestors.  When ValidateOptions.Version is set, Validate calls
+// contextMinVersion in place of MinVersion.
+type contextVersioner interface {
+	// contextMinVersion is like MinVersion, but also receives the position
+	// of the Node within the AST.
+	contextMinVersion(ctx validationContext) Version
+}
+
+// validationContext describes where a Node appears in the AST being
+// validated.
+type validationContext struct {
+	ValidateOptions
+
+	// ancestors is the path of Nodes from the root of the AST to the
+	// current Node's parent.
+	ancestors []Node
+
+	// children counts the children of each of the ancestors visited so
+	// far, so children[i]-1 is the index, in Walk order, of the child of
+	// ancestors[i] which is on the path to the current Node.
+	children []int
+}
+
+// validator implements Visitor for Validate.
+type validator struct {
+	validationContext
+	errs []error
+}
+
+func (v *validator) Visit(n Node) Visitor {
+	if n == nil {
+		v.ancestors = v.ancestors[:len(v.ancestors)-1]
+		v.children = v.children[:len(v.children)-1]
+		return nil
+	}
+	if len(v.children) > 0 {
+		v.children[len(v.children)-1]++
+	}
+	if cc, ok := n.(contextChecker); ok {
+		v.errs = append(v.errs, cc.contextErrors(v.validationContext)...)
+	} else {
+		v.errs = append(v.errs, n.Errors()...)
+	}
+	if v.Version != 0 {
+		min := n.MinVersion()
+		if cv, ok := n.(contextVersioner); ok {
+			min = cv.contextMinVersion(v.validationContext)
+		}
+		if min > v.Version {
+			v.errs = append(v.errs, SyntaxError{
+				Err:  fmt.Errorf("%s requires %s, %w in %s", n.Type(), min, ErrNotAllowed, v.Version),
+				Node: n,
+			})
+		}
+	}
+	v.ancestors = append(v.ancestors, n)
+	v.children = append(v.children, 0)
+	return v
+}
+
+// parentNode returns the last element of ancestors, or nil if the slice is
+// empty.
+func parentNode(ancestors []Node) Node {
+	if len(ancestors) == 0 {
+		return nil
+	}
+	return ancestors[len(ancestors)-1]
+}
+
+// rootNode returns the first element of ancestors, or nil if the slice is
+// empty.
+func rootNode(ancestors []Node) Node {
+	if len(ancestors) == 0 {
+		return nil
+	}
+	return ancestors[0]
+}
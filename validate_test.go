@@ -0,0 +1,31 @@
+package estree
+
+import (
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	decl := VariableDeclaration{
+		Kind: Const,
+		Declarations: []VariableDeclarator{
+			VariableDeclarator{
+				ID: Identifier{Name: "foo"},
+			},
+		},
+	}
+	fis := ForInStatement{
+		Left:  decl,
+		Right: Identifier{Name: "bar"},
+		Body:  EmptyStatement{},
+	}
+	if errs := Validate(fis); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	p := Program{
+		Body: []DirectiveOrStatement{decl},
+	}
+	if !hasError(ErrMissingNode, Validate(p)...) {
+		t.Error("expected ErrMissingNode for const without Init")
+	}
+}